@@ -1,21 +1,38 @@
 package main
 
 import (
+	"context"
+	"errors"
 	_ "film_library/docs"
 	"film_library/internal/config"
+	"film_library/internal/domains"
 	"film_library/internal/handlers"
+	"film_library/internal/handlers/healthhandler"
+	"film_library/internal/handlers/keyshandler"
 	"film_library/internal/logger"
+	"film_library/internal/passwords"
 	"film_library/internal/repositories/postgres"
 	"film_library/internal/services"
-	adminmw "film_library/pkg/middlewares/admin_mw"
+	"film_library/internal/tokens"
+	"film_library/pkg/metrics"
 	"film_library/pkg/middlewares/auth"
 	loggermw "film_library/pkg/middlewares/logger_mw"
+	metricsmw "film_library/pkg/middlewares/metrics_mw"
+	permissionmw "film_library/pkg/middlewares/permission_mw"
+	ratelimitmw "film_library/pkg/middlewares/ratelimit_mw"
+	requestidmw "film_library/pkg/middlewares/requestid_mw"
+	timeoutmw "film_library/pkg/middlewares/timeout_mw"
 	"film_library/pkg/mux"
 	"fmt"
 	"log/slog"
 	"net/http"
 	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	httpSwagger "github.com/swaggo/http-swagger"
 )
 
@@ -36,59 +53,262 @@ func main() {
 	cfg, err := config.New("./configs/local.yaml")
 	exitOnErr(log, err)
 
-	repository, err := postgres.New(&cfg.Database)
+	registry := prometheus.NewRegistry()
+	queryDuration := prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "db_query_duration_seconds",
+		Help:    "Duration of database statements by repository method.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method"})
+	registry.MustRegister(queryDuration)
+
+	repository, err := postgres.New(&cfg.Database, func(ctx context.Context, method string, d time.Duration, err error) {
+		queryDuration.WithLabelValues(method).Observe(d.Seconds())
+		logger.FromContext(ctx, log).Debug("query",
+			slog.String("method", method),
+			slog.String("latency", d.String()),
+			slog.Any("error", err))
+	})
+	exitOnErr(log, err)
+	registry.MustRegister(metrics.NewDBStatsCollector(repository.Stats))
+
+	// Stored scores depend on the prior, which may have changed since the last start.
+	rescored, err := repository.RefreshFilmScores(context.Background(), cfg.UserRatings.PriorMean, cfg.UserRatings.PriorWeight)
+	exitOnErr(log, err)
+	if rescored != 0 {
+		log.Info("film scores recomputed", slog.Int64("films", rescored))
+	}
+
+	issuer, err := tokens.NewIssuerFromConfig(cfg)
+	exitOnErr(log, err)
+
+	policy, err := passwords.NewPolicy(cfg.Identity.PasswordPolicy)
 	exitOnErr(log, err)
 
-	service := services.New(repository, log, cfg)
+	hasher, err := passwords.NewHasher(cfg.Identity.PasswordHashing)
+	exitOnErr(log, err)
+
+	rolePermissions, err := domains.NewRolePermissions(cfg.Identity.RolePermissions)
+	exitOnErr(log, err)
+
+	service := services.New(repository, log, cfg, issuer, policy, hasher, rolePermissions)
 
 	handler := handlers.New(service, log)
 
+	keys := keyshandler.New(issuer, log)
+
+	health := healthhandler.New(map[string]healthhandler.Checker{
+		"database": repository,
+	}, log)
+
+	rateLimits := ratelimitmw.NewMemoryStore()
+
 	router := mux.New()
+	routes(router, handler, keys, health, promhttp.HandlerFor(registry, promhttp.HandlerOpts{}), middlewares{
+		common: []func(http.Handler) http.Handler{
+			requestidmw.New(log),
+			metricsmw.New(registry),
+			loggermw.New(log),
+			timeoutmw.New(cfg.Database.QueryTimeout),
+		},
+		limitClientIP:     ratelimitmw.NewClientIP(log, rateLimits, cfg.RateLimit.ClientIP),
+		authenticate:      auth.New(log, issuer, service, rolePermissions),
+		rateLimit:         ratelimitmw.New(log, rateLimits, cfg.RateLimit),
+		requirePermission: permissionmw.New(log),
+	})
+
+	server := &http.Server{
+		Addr:              fmt.Sprintf("%s:%s", cfg.Server.Host, cfg.Server.Port),
+		Handler:           router,
+		ReadHeaderTimeout: cfg.Server.ReadHeaderTimeout,
+		ReadTimeout:       cfg.Server.ReadTimeout,
+		WriteTimeout:      cfg.Server.WriteTimeout,
+		IdleTimeout:       cfg.Server.IdleTimeout,
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	go func() {
+		ticker := time.NewTicker(cfg.Identity.TokenCleanupInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := repository.DeleteExpiredTokens(ctx); err != nil {
+					log.Error(fmt.Sprintf("delete expired tokens: %s", err.Error()))
+				}
+			}
+		}
+	}()
+
+	go func() {
+		err := server.ListenAndServe()
+		if !errors.Is(err, http.ErrServerClosed) {
+			exitOnErr(log, err)
+		}
+	}()
+
+	fmt.Println("Starting server...")
+	<-ctx.Done()
+
+	log.Info("shutting down server")
+
+	health.Drain()
+	time.Sleep(cfg.Server.DrainDelay)
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.Server.ShutdownTimeout)
+	defer cancel()
+
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		log.Error(fmt.Sprintf("server shutdown: %s", err.Error()))
+	}
+
+	if err := repository.Close(); err != nil {
+		log.Error(fmt.Sprintf("database close: %s", err.Error()))
+	}
+}
 
+// middlewares wrap the routes of the server.
+type middlewares struct {
+	// common wrap all routes but the probes, docs and metrics.
+	common            []func(http.Handler) http.Handler
+	limitClientIP     func(http.Handler) http.Handler
+	authenticate      func(http.Handler) http.Handler
+	rateLimit         func(http.Handler) http.Handler
+	requirePermission func(domains.Permission) func(http.Handler) http.Handler
+}
+
+// routes registers all routes of the server on router. Tests register them
+// as well, so patterns that ServeMux refuses fail before start.
+func routes(router *mux.Mux, handler *handlers.Handler, keys *keyshandler.KeysHandler,
+	health *healthhandler.HealthHandler, registry http.Handler, mw middlewares) {
 	router.HandleFunc("GET /swagger/", httpSwagger.Handler())
+	router.HandleFunc("GET /healthz", health.Liveness)
+	router.HandleFunc("GET /readyz", health.Readiness)
+	router.Handle("GET /metrics", registry)
 
-	router.Use(loggermw.New(log))
-	router.HandleFunc("POST /api/register", handler.Register)
-	router.HandleFunc("POST /api/login", handler.Login)
+	for _, common := range mw.common {
+		router.Use(common)
+	}
+
+	router.Group(func(r *mux.Mux) {
+		r.Use(mw.rateLimit)
+
+		r.HandleFunc("POST /api/register", handler.Register)
+		r.HandleFunc("POST /api/login", handler.Login)
+		r.HandleFunc("POST /api/token/refresh", handler.RefreshTokens)
+		r.HandleFunc("POST /api/password/reset", handler.ResetPassword)
+		r.HandleFunc("GET /.well-known/jwks.json", keys.JWKS)
+	})
 
 	router.Group(func(r *mux.Mux) {
-		r.Use(auth.New(log, cfg))
+		r.Use(mw.limitClientIP)
+		r.Use(mw.authenticate)
+		r.Use(mw.rateLimit)
+
+		r.HandleFunc("POST /api/logout", handler.Logout)
+		r.HandleFunc("GET /api/me", handler.GetMe)
+		r.HandleFunc("PUT /api/me", handler.UpdateMe)
+		r.HandleFunc("DELETE /api/me", handler.DeleteMe)
+		r.HandleFunc("PUT /api/me/password", handler.ChangePassword)
 
 		r.HandleFunc("GET /api/actors", handler.GetActorsWithFilms)
 		r.HandleFunc("GET /api/films", handler.GetFilms)
+		r.HandleFunc("GET /api/actor/{id}", handler.GetActorByID)
+		r.HandleFunc("GET /api/film/{id}", handler.GetFilmByID)
+		r.HandleFunc("GET /api/genres", handler.GetGenres)
+		r.HandleFunc("GET /api/me/watchlist", handler.GetWatchlist)
+		r.HandleFunc("PUT /api/me/watchlist/{filmID}", handler.AddToWatchlist)
+		r.HandleFunc("DELETE /api/me/watchlist/{filmID}", handler.RemoveFromWatchlist)
+		r.HandleFunc("GET /api/me/watched", handler.GetWatchedFilms)
+		r.HandleFunc("PUT /api/me/watched/{filmID}", handler.MarkWatched)
+		r.HandleFunc("DELETE /api/me/watched/{filmID}", handler.DeleteWatched)
+		r.HandleFunc("PUT /api/me/ratings/{filmID}", handler.RateFilm)
+		r.HandleFunc("DELETE /api/me/ratings/{filmID}", handler.DeleteFilmRating)
+		r.HandleFunc("GET /api/film/{id}/reviews", handler.GetFilmReviews)
+		r.HandleFunc("GET /api/review/{id}", handler.GetReview)
+
+		r.Group(func(r *mux.Mux) {
+			r.Use(mw.requirePermission(domains.PermActorWrite))
 
-		r.Group(func(adminRouter *mux.Mux) {
-			adminRouter.Use(adminmw.New(log))
-
-			adminRouter.HandleFunc("POST /api/actor", handler.CreateActor)
-			adminRouter.HandleFunc("POST /api/actors/{filmID}", handler.AddActorsToFilm)
-			adminRouter.HandleFunc("PUT /api/actor/name/{id}/{name}", handler.UpdateActorFullName)
-			adminRouter.HandleFunc("PUT /api/actor/gender/{id}/{gender}", handler.UpdateActorGender)
-			adminRouter.HandleFunc("PUT /api/actor/birthday/{id}/{birthday}", handler.UpdateActorBirthday)
-			adminRouter.HandleFunc("PUT /api/actor/{id}", handler.UpdateActor)
-			adminRouter.HandleFunc("DELETE /api/actor/{id}", handler.DeleteActor)
-			adminRouter.HandleFunc("DELETE /api/actor/{id}/{filmID}", handler.DeleteActorFromFilm)
-
-			adminRouter.HandleFunc("POST /api/film", handler.CreateFilm)
-			adminRouter.HandleFunc("PUT /api/film/name/{id}/{name}", handler.UpdateFilmName)
-			adminRouter.HandleFunc("PUT /api/film/description/{id}", handler.UpdateFilmDescription)
-			adminRouter.HandleFunc("PUT /api/film/date/{id}/{date}", handler.UpdateFilmReleaseDate)
-			adminRouter.HandleFunc("PUT /api/film/{id}/{rating}", handler.UpdateFilmRating)
-			adminRouter.HandleFunc("PUT /api/film/{id}", handler.UpdateFilm)
-			adminRouter.HandleFunc("DELETE /api/film/{id}", handler.DeleteFilm)
+			r.HandleFunc("POST /api/actor", handler.CreateActor)
+			r.HandleFunc("POST /api/actors/{filmID}", handler.AddActorsToFilm)
+			r.HandleFunc("PUT /api/actors/{filmID}", handler.ReplaceFilmActors)
+			r.HandleFunc("PUT /api/actor/name/{id}/{name}", handler.UpdateActorFullName)
+			r.HandleFunc("PUT /api/actor/gender/{id}/{gender}", handler.UpdateActorGender)
+			r.HandleFunc("PUT /api/actor/birthday/{id}/{birthday}", handler.UpdateActorBirthday)
+			r.HandleFunc("PUT /api/actor/{id}", handler.UpdateActor)
+			r.HandleFunc("DELETE /api/actor/{id}/{filmID}", handler.DeleteActorFromFilm)
 		})
-	})
 
-	done := make(chan struct{})
-	go func() {
-		err := http.ListenAndServe(fmt.Sprintf("%s:%s", cfg.Server.Host, cfg.Server.Port), router)
-		exitOnErr(log, err)
+		r.Group(func(r *mux.Mux) {
+			r.Use(mw.requirePermission(domains.PermActorDelete))
 
-		done <- struct{}{}
-	}()
+			r.HandleFunc("DELETE /api/actor/{id}", handler.DeleteActor)
+		})
 
-	fmt.Println("Starting server...")
-	<-done
+		r.Group(func(r *mux.Mux) {
+			r.Use(mw.requirePermission(domains.PermFilmWrite))
+
+			r.HandleFunc("POST /api/film", handler.CreateFilm)
+			r.HandleFunc("PUT /api/film/name/{id}/{name}", handler.UpdateFilmName)
+			r.HandleFunc("PUT /api/film/description/{id}", handler.UpdateFilmDescription)
+			r.HandleFunc("PUT /api/film/date/{id}/{date}", handler.UpdateFilmReleaseDate)
+			r.HandleFunc("PUT /api/film/{id}/{rating}", handler.UpdateFilmRating)
+			r.HandleFunc("PUT /api/film/{id}", handler.UpdateFilm)
+		})
+
+		r.Group(func(r *mux.Mux) {
+			r.Use(mw.requirePermission(domains.PermFilmDelete))
+
+			r.HandleFunc("DELETE /api/film/{id}", handler.DeleteFilm)
+		})
+
+		r.Group(func(r *mux.Mux) {
+			r.Use(mw.requirePermission(domains.PermGenreManage))
+
+			r.HandleFunc("POST /api/genre", handler.CreateGenre)
+			r.HandleFunc("PUT /api/genre/{id}", handler.UpdateGenre)
+			r.HandleFunc("DELETE /api/genre/{id}", handler.DeleteGenre)
+		})
+
+		r.Group(func(r *mux.Mux) {
+			r.Use(mw.requirePermission(domains.PermReviewWrite))
+
+			r.HandleFunc("POST /api/film/{id}/review", handler.CreateReview)
+			r.HandleFunc("PUT /api/review/{id}", handler.UpdateReview)
+			r.HandleFunc("DELETE /api/review/{id}", handler.DeleteReview)
+			r.HandleFunc("PUT /api/review/{id}/vote", handler.VoteReview)
+			r.HandleFunc("DELETE /api/review/{id}/vote", handler.DeleteReviewVote)
+			r.HandleFunc("POST /api/review/{id}/report", handler.ReportReview)
+		})
+
+		r.Group(func(r *mux.Mux) {
+			r.Use(mw.requirePermission(domains.PermReviewModerate))
+
+			r.HandleFunc("GET /api/reviews/moderation", handler.GetModerationQueue)
+			r.HandleFunc("PUT /api/review/{id}/hide", handler.HideReview)
+			r.HandleFunc("PUT /api/review/{id}/restore", handler.RestoreReview)
+		})
+
+		r.Group(func(r *mux.Mux) {
+			r.Use(mw.requirePermission(domains.PermUserManage))
+
+			r.HandleFunc("GET /api/users", handler.GetUsers)
+			r.HandleFunc("PUT /api/user/role/{id}/{role}", handler.UpdateUserRole)
+			r.HandleFunc("PUT /api/user/disable/{id}", handler.DisableUser)
+			r.HandleFunc("PUT /api/user/enable/{id}", handler.EnableUser)
+			r.HandleFunc("DELETE /api/user/{id}", handler.DeleteUser)
+			r.HandleFunc("POST /api/user/password-reset/{id}", handler.IssuePasswordReset)
+
+			r.HandleFunc("GET /api/apikeys", handler.GetAPIKeys)
+			r.HandleFunc("POST /api/apikey", handler.CreateAPIKey)
+			r.HandleFunc("DELETE /api/apikey/{id}", handler.DeleteAPIKey)
+		})
+	})
 }
 
 func exitOnErr(log *slog.Logger, err error) {
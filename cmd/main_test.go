@@ -0,0 +1,87 @@
+package main
+
+import (
+	"film_library/internal/domains"
+	"film_library/internal/handlers"
+	"film_library/internal/handlers/healthhandler"
+	"film_library/internal/handlers/keyshandler"
+	"film_library/pkg/mux"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRoutes(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	// The rate limiter stands in for the handlers, it records the pattern
+	// the request was routed to. The middlewares record the order they ran in.
+	var pattern string
+	var chain []string
+	record := func(name string) func(http.Handler) http.Handler {
+		return func(next http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				chain = append(chain, name)
+				next.ServeHTTP(w, r)
+			})
+		}
+	}
+	rateLimit := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			chain = append(chain, "rateLimit")
+			pattern = mux.Pattern(r)
+		})
+	}
+
+	router := mux.New()
+	routes(router, handlers.New(nil, log), keyshandler.New(nil, log), healthhandler.New(nil, log), http.NotFoundHandler(), middlewares{
+		limitClientIP: record("limitClientIP"),
+		authenticate:  record("authenticate"),
+		rateLimit:     rateLimit,
+		requirePermission: func(domains.Permission) func(http.Handler) http.Handler {
+			return record("requirePermission")
+		},
+	})
+
+	// Requests with invalid credentials are limited by client IP first.
+	anonymous := "rateLimit"
+	authenticated := "limitClientIP authenticate rateLimit"
+
+	tests := []struct {
+		method          string
+		url             string
+		expectedPattern string
+		expectedChain   string
+	}{
+		{http.MethodPost, "/api/login", "POST /api/login", anonymous},
+		{http.MethodGet, "/api/films", "GET /api/films", authenticated},
+		{http.MethodPut, "/api/me/ratings/1", "PUT /api/me/ratings/{filmID}", authenticated},
+		{http.MethodDelete, "/api/me/ratings/1", "DELETE /api/me/ratings/{filmID}", authenticated},
+		{http.MethodPut, "/api/film/1/8", "PUT /api/film/{id}/{rating}", authenticated},
+		{http.MethodPut, "/api/film/description/1", "PUT /api/film/description/{id}", authenticated},
+		{http.MethodPut, "/api/film/1", "PUT /api/film/{id}", authenticated},
+		{http.MethodPost, "/api/film/1/review", "POST /api/film/{id}/review", authenticated},
+		{http.MethodPut, "/api/review/1/vote", "PUT /api/review/{id}/vote", authenticated},
+		{http.MethodPut, "/api/me/watchlist/1", "PUT /api/me/watchlist/{filmID}", authenticated},
+		{http.MethodDelete, "/api/actor/1/2", "DELETE /api/actor/{id}/{filmID}", authenticated},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.method+" "+tc.url, func(t *testing.T) {
+			pattern, chain = "", nil
+
+			router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(tc.method, tc.url, nil))
+
+			if tc.expectedPattern != pattern {
+				t.Errorf("expected: %s\ngot: %s", tc.expectedPattern, pattern)
+			}
+
+			if got := strings.Join(chain, " "); tc.expectedChain != got {
+				t.Errorf("expected: %s\ngot: %s", tc.expectedChain, got)
+			}
+		})
+	}
+}
@@ -1,34 +1,125 @@
 package config
 
-import "github.com/ilyakaznacheev/cleanenv"
+import (
+	"fmt"
+	"time"
+
+	"github.com/ilyakaznacheev/cleanenv"
+)
 
 type Config struct {
 	Server          Server          `yaml:"server"`
 	Database        DataBase        `yaml:"database"`
 	Identity        Identity        `yaml:"identity"`
+	RateLimit       RateLimit       `yaml:"rateLimit"`
 	FilmValidations FilmValidations `yaml:"filmValidations"`
+	UserRatings     UserRatings     `yaml:"userRatings"`
 }
 
 type Server struct {
-	Host   string `yaml:"host"`
-	Port   string `yaml:"port"`
-	Secret string `env:"SERVER_SECRET" env-required:"true"`
+	Host              string        `yaml:"host"`
+	Port              string        `yaml:"port"`
+	Secret            string        `env:"SERVER_SECRET"`
+	ReadHeaderTimeout time.Duration `yaml:"readHeaderTimeout" env-default:"5s"`
+	ReadTimeout       time.Duration `yaml:"readTimeout" env-default:"10s"`
+	WriteTimeout      time.Duration `yaml:"writeTimeout" env-default:"10s"`
+	IdleTimeout       time.Duration `yaml:"idleTimeout" env-default:"60s"`
+	ShutdownTimeout   time.Duration `yaml:"shutdownTimeout" env-default:"10s"`
+	DrainDelay        time.Duration `yaml:"drainDelay" env-default:"0s"`
 }
 
 type DataBase struct {
-	Host     string `yaml:"host"`
-	Port     string `yaml:"port"`
-	Name     string `yaml:"name"`
-	User     string `yaml:"user"`
-	Password string `env:"DB_PASSWORD" env-required:"true"`
-	SSLMode  string `yaml:"sslmode"`
+	Host         string        `yaml:"host"`
+	Port         string        `yaml:"port"`
+	Name         string        `yaml:"name"`
+	User         string        `yaml:"user"`
+	Password     string        `env:"DB_PASSWORD" env-required:"true"`
+	SSLMode      string        `yaml:"sslmode"`
+	QueryTimeout time.Duration `yaml:"queryTimeout" env-default:"5s"`
 }
 
 type Identity struct {
-	MinLoginLen    int `yaml:"minLoginLen"`
-	MaxLoginLen    int `yaml:"maxLoginLen"`
-	MinPasswordLen int `yaml:"minPasswordLen"`
-	MaxPasswordLen int `yaml:"maxPasswordLen"`
+	MinLoginLen          int           `yaml:"minLoginLen"`
+	MaxLoginLen          int           `yaml:"maxLoginLen"`
+	MinPasswordLen       int           `yaml:"minPasswordLen"`
+	MaxPasswordLen       int           `yaml:"maxPasswordLen"`
+	AccessTokenTTL       time.Duration `yaml:"accessTokenTTL" env-default:"15m"`
+	RefreshTokenTTL      time.Duration `yaml:"refreshTokenTTL" env-default:"720h"`
+	TokenCleanupInterval time.Duration `yaml:"tokenCleanupInterval" env-default:"1h"`
+	SigningKeyFile       string        `yaml:"signingKeyFile" env:"SIGNING_KEY_FILE"`
+	SigningKeyID         string        `yaml:"signingKeyID" env:"SIGNING_KEY_ID"`
+	PreviousKeyFile      string        `yaml:"previousKeyFile" env:"PREVIOUS_KEY_FILE"`
+	PreviousKeyID        string        `yaml:"previousKeyID" env:"PREVIOUS_KEY_ID"`
+	// PreviousKeyRetiresAt is when tokens signed by the previous key, or by
+	// the server secret if AcceptServerSecret is set, stop verifying.
+	PreviousKeyRetiresAt time.Time `yaml:"previousKeyRetiresAt" env:"PREVIOUS_KEY_RETIRES_AT"`
+	// AcceptServerSecret keeps HS256 tokens signed with the server secret valid
+	// after switching to a signing key file.
+	AcceptServerSecret bool `yaml:"acceptServerSecret" env:"ACCEPT_SERVER_SECRET"`
+	// RolePermissions maps a role to the permissions it grants, e.g. editor: [film:write].
+	RolePermissions map[string][]string `yaml:"rolePermissions"`
+	LoginThrottle   LoginThrottle       `yaml:"loginThrottle"`
+	PasswordPolicy  PasswordPolicy      `yaml:"passwordPolicy"`
+	PasswordHashing PasswordHashing     `yaml:"passwordHashing"`
+	// PasswordResetTTL is how long a reset token issued by an admin is valid.
+	PasswordResetTTL time.Duration `yaml:"passwordResetTTL" env-default:"24h"`
+}
+
+// PasswordPolicy applies to every new password on top of the length limits.
+type PasswordPolicy struct {
+	// MinCharClasses out of lowercase, uppercase, digits and symbols.
+	MinCharClasses int `yaml:"minCharClasses" env-default:"2"`
+	// DenylistFile lists common passwords, one per line.
+	DenylistFile string `yaml:"denylistFile" env:"PASSWORD_DENYLIST_FILE"`
+}
+
+// PasswordHashing selects how new passwords are hashed. Hashes made with
+// another algorithm or weaker parameters still verify and are replaced on
+// the next successful login.
+type PasswordHashing struct {
+	// Algorithm is argon2id or bcrypt.
+	Algorithm  string `yaml:"algorithm" env:"PASSWORD_HASH_ALGORITHM" env-default:"argon2id"`
+	BcryptCost int    `yaml:"bcryptCost" env-default:"10"`
+	// Argon2Memory is in KiB.
+	Argon2Memory      uint32 `yaml:"argon2Memory" env-default:"19456"`
+	Argon2Iterations  uint32 `yaml:"argon2Iterations" env-default:"2"`
+	Argon2Parallelism uint8  `yaml:"argon2Parallelism" env-default:"1"`
+}
+
+// LoginThrottle slows down password guessing. Failed logins are counted per
+// login and per client IP: after the free attempts every failure blocks for
+// an exponentially growing delay, and the threshold locks out for a while.
+type LoginThrottle struct {
+	FreeAttempts       int           `yaml:"freeAttempts" env-default:"3"`
+	IPFreeAttempts     int           `yaml:"ipFreeAttempts" env-default:"20"`
+	BaseDelay          time.Duration `yaml:"baseDelay" env-default:"1s"`
+	MaxDelay           time.Duration `yaml:"maxDelay" env-default:"1m"`
+	LockoutThreshold   int           `yaml:"lockoutThreshold" env-default:"10"`
+	IPLockoutThreshold int           `yaml:"ipLockoutThreshold" env-default:"100"`
+	LockoutDuration    time.Duration `yaml:"lockoutDuration" env-default:"15m"`
+	FailureWindow      time.Duration `yaml:"failureWindow" env-default:"15m"`
+}
+
+// RateLimit configures token buckets: Anonymous applies per client IP, Roles
+// and APIKeys per authenticated principal. Routes, keyed by mux pattern such
+// as "GET /api/films", add a separate bucket per principal for that route.
+// ClientIP applies per client IP to authenticated routes before credentials
+// are checked, it must leave room for every principal behind one address.
+// A limit without requests is unlimited.
+type RateLimit struct {
+	Anonymous Limit            `yaml:"anonymous"`
+	ClientIP  Limit            `yaml:"clientIP"`
+	APIKeys   Limit            `yaml:"apiKeys"`
+	Roles     map[string]Limit `yaml:"roles"`
+	Routes    map[string]Limit `yaml:"routes"`
+}
+
+// Limit allows Requests per Per on average and bursts of up to Burst
+// requests, which defaults to Requests.
+type Limit struct {
+	Requests int           `yaml:"requests"`
+	Per      time.Duration `yaml:"per"`
+	Burst    int           `yaml:"burst"`
 }
 
 type FilmValidations struct {
@@ -40,6 +131,24 @@ type FilmValidations struct {
 	MaxRating         int `yaml:"maxRating"`
 }
 
+// UserRatings configures the score of films rated by users: the Bayesian
+// weighted rating (PriorWeight*PriorMean + sum of ratings) / (PriorWeight +
+// number of ratings) keeps films with few ratings close to PriorMean.
+// MinRating and MaxRating may narrow, but not widen, the range from
+// MinUserRating to MaxUserRating that the user_ratings table allows.
+type UserRatings struct {
+	MinRating   int     `yaml:"minRating" env-default:"1"`
+	MaxRating   int     `yaml:"maxRating" env-default:"10"`
+	PriorMean   float64 `yaml:"priorMean" env-default:"6"`
+	PriorWeight float64 `yaml:"priorWeight" env-default:"10"`
+}
+
+// The range of user ratings checked by the user_ratings table.
+const (
+	MinUserRating = 1
+	MaxUserRating = 10
+)
+
 func New(path string) (*Config, error) {
 	var cfg Config
 	err := cleanenv.ReadConfig(path, &cfg)
@@ -47,5 +156,17 @@ func New(path string) (*Config, error) {
 		return nil, err
 	}
 
+	if err := cfg.UserRatings.validate(); err != nil {
+		return nil, err
+	}
+
 	return &cfg, nil
 }
+
+func (r UserRatings) validate() error {
+	if r.MinRating < MinUserRating || r.MaxRating > MaxUserRating || r.MinRating > r.MaxRating {
+		return fmt.Errorf("config: user ratings from %d to %d are not within %d and %d",
+			r.MinRating, r.MaxRating, MinUserRating, MaxUserRating)
+	}
+	return nil
+}
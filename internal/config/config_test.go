@@ -0,0 +1,27 @@
+package config
+
+import "testing"
+
+func TestUserRatingsValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		ratings UserRatings
+		valid   bool
+	}{
+		{name: "Default", ratings: UserRatings{MinRating: 1, MaxRating: 10}, valid: true},
+		{name: "Narrower", ratings: UserRatings{MinRating: 1, MaxRating: 5}, valid: true},
+		{name: "Zero", ratings: UserRatings{MinRating: 0, MaxRating: 10}},
+		{name: "Wider", ratings: UserRatings{MinRating: 1, MaxRating: 100}},
+		{name: "Inverted", ratings: UserRatings{MinRating: 8, MaxRating: 3}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.ratings.validate()
+
+			if tc.valid != (err == nil) {
+				t.Errorf("expected valid: %t\ngot: %v", tc.valid, err)
+			}
+		})
+	}
+}
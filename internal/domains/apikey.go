@@ -0,0 +1,26 @@
+package domains
+
+import "time"
+
+type APIKey struct {
+	ID          uint32       `json:"id"`
+	Name        string       `json:"name"`
+	Prefix      string       `json:"prefix"`
+	Hash        string       `json:"-"`
+	Permissions []Permission `json:"permissions"`
+	CreatedBy   uint32       `json:"createdBy,omitempty"`
+	CreatedAt   time.Time    `json:"createdAt"`
+	ExpiresAt   *time.Time   `json:"expiresAt,omitempty"`
+	LastUsedAt  *time.Time   `json:"lastUsedAt,omitempty"`
+
+	// CreatorRole and CreatorDisabled are loaded to authenticate the key,
+	// which acts with no more permissions than its creator holds.
+	CreatorRole     Role `json:"-"`
+	CreatorDisabled bool `json:"-"`
+}
+
+// CreatedAPIKey carries the plain key, which is only shown once.
+type CreatedAPIKey struct {
+	APIKey
+	Key string `json:"key"`
+}
@@ -0,0 +1,72 @@
+package domains
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+const (
+	CreditActor    CreditType = "actor"
+	CreditDirector CreditType = "director"
+	CreditWriter   CreditType = "writer"
+	CreditProducer CreditType = "producer"
+	CreditComposer CreditType = "composer"
+)
+
+var CreditTypes = map[string]struct{}{
+	"actor":    struct{}{},
+	"director": struct{}{},
+	"writer":   struct{}{},
+	"producer": struct{}{},
+	"composer": struct{}{},
+}
+
+type CreditType string
+
+func (t CreditType) IsValid() bool {
+	_, ok := CreditTypes[string(t)]
+	return ok
+}
+
+// Credit links a person to a film. Character is set for acting roles only;
+// credits are listed by ascending BillingOrder.
+type Credit struct {
+	PersonID     uint32     `json:"personId"`
+	Type         CreditType `json:"type" enums:"actor,director,writer,producer,composer"`
+	Character    string     `json:"character,omitempty"`
+	BillingOrder int        `json:"billingOrder"`
+}
+
+// UnmarshalJSON also accepts a bare person id, which stands for an acting
+// credit, so that lists of actor ids keep working.
+func (c *Credit) UnmarshalJSON(data []byte) error {
+	var id uint32
+	if err := json.Unmarshal(data, &id); err == nil {
+		*c = Credit{PersonID: id, Type: CreditActor}
+		return nil
+	}
+
+	type credit Credit
+	parsed := credit{Type: CreditActor}
+	if err := json.Unmarshal(data, &parsed); err != nil {
+		return fmt.Errorf("credit: %w", err)
+	}
+	*c = Credit(parsed)
+	return nil
+}
+
+// PersonCredit is a credit in a film response.
+type PersonCredit struct {
+	Person
+	Type         CreditType `json:"type"`
+	Character    string     `json:"character,omitempty"`
+	BillingOrder int        `json:"billingOrder"`
+}
+
+// FilmCredit is a credit in a person response.
+type FilmCredit struct {
+	Film
+	Type         CreditType `json:"type"`
+	Character    string     `json:"character,omitempty"`
+	BillingOrder int        `json:"billingOrder"`
+}
@@ -1,9 +1,54 @@
 package domains
 
+import "time"
+
 type Film struct {
 	ID          uint32 `json:"id"`
 	Name        string `json:"name"`
 	Description string `json:"description"`
 	ReleaseDate Time   `json:"releaseDate" format:"2006-01-02"`
 	Rating      int    `json:"rating"`
+	// Genres and Score are loaded for film lists and single films only.
+	Genres []*Genre   `json:"genres,omitempty"`
+	Score  *FilmScore `json:"score,omitempty"`
+	// InWatchlist and Watched are set for film lists requested by a user.
+	InWatchlist *bool `json:"inWatchlist,omitempty"`
+	Watched     *bool `json:"watched,omitempty"`
+}
+
+// FilmScore aggregates the ratings given by users, unlike the editorial
+// Film.Rating. Bayesian is the weighted rating films are sorted by, it is the
+// prior mean while a film has no ratings.
+type FilmScore struct {
+	Mean     float64 `json:"mean"`
+	Count    int     `json:"count"`
+	Bayesian float64 `json:"bayesian"`
+}
+
+// WatchlistEntry is a film the user wants to watch.
+type WatchlistEntry struct {
+	Film
+	AddedAt time.Time `json:"addedAt"`
+}
+
+// WatchedEntry is a film in the watched history of the user: the date it was
+// last watched and how many times it was watched again after the first time.
+type WatchedEntry struct {
+	Film
+	WatchedAt    Time `json:"watchedAt" format:"2006-01-02"`
+	RewatchCount int  `json:"rewatchCount"`
+}
+
+type FilmWithCredits struct {
+	Film
+	Credits []*PersonCredit `json:"credits"`
+}
+
+// NewFilmScore returns the score of count ratings adding up to sum.
+func NewFilmScore(count, sum int, bayesian float64) *FilmScore {
+	score := &FilmScore{Count: count, Bayesian: bayesian}
+	if count != 0 {
+		score.Mean = float64(sum) / float64(count)
+	}
+	return score
 }
@@ -0,0 +1,6 @@
+package domains
+
+type Genre struct {
+	ID   uint32 `json:"id"`
+	Name string `json:"name"`
+}
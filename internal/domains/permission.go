@@ -0,0 +1,110 @@
+package domains
+
+import (
+	"fmt"
+	"sort"
+)
+
+const (
+	PermFilmWrite   Permission = "film:write"
+	PermFilmDelete  Permission = "film:delete"
+	PermActorWrite  Permission = "actor:write"
+	PermActorDelete Permission = "actor:delete"
+	PermGenreManage Permission = "genre:manage"
+	PermUserManage  Permission = "user:manage"
+
+	PermReviewWrite    Permission = "review:write"
+	PermReviewModerate Permission = "review:moderate"
+)
+
+var Permissions = map[Permission]struct{}{
+	PermFilmWrite:      {},
+	PermFilmDelete:     {},
+	PermActorWrite:     {},
+	PermActorDelete:    {},
+	PermGenreManage:    {},
+	PermUserManage:     {},
+	PermReviewWrite:    {},
+	PermReviewModerate: {},
+}
+
+// DefaultRolePermissions is used when the config does not map roles.
+var DefaultRolePermissions = RolePermissions{
+	RoleAdmin: {
+		PermFilmWrite:      {},
+		PermFilmDelete:     {},
+		PermActorWrite:     {},
+		PermActorDelete:    {},
+		PermGenreManage:    {},
+		PermUserManage:     {},
+		PermReviewWrite:    {},
+		PermReviewModerate: {},
+	},
+	RoleEditor: {
+		PermFilmWrite:   {},
+		PermActorWrite:  {},
+		PermReviewWrite: {},
+	},
+	RoleViewer: {
+		PermReviewWrite: {},
+	},
+}
+
+type Permission string
+
+// RolePermissions lists the actions every role is allowed to perform.
+type RolePermissions map[Role]map[Permission]struct{}
+
+// NewRolePermissions checks that the mapping only mentions known roles and
+// permissions. Roles that are not mapped get no permissions; an empty mapping
+// falls back to DefaultRolePermissions.
+func NewRolePermissions(mapping map[string][]string) (RolePermissions, error) {
+	if len(mapping) == 0 {
+		return DefaultRolePermissions, nil
+	}
+
+	rp := make(RolePermissions, len(mapping))
+	for role, permissions := range mapping {
+		if !Role(role).IsValidRole() {
+			return nil, fmt.Errorf("unknown role %q", role)
+		}
+
+		set := make(map[Permission]struct{}, len(permissions))
+		for _, p := range permissions {
+			if _, ok := Permissions[Permission(p)]; !ok {
+				return nil, fmt.Errorf("role %s: unknown permission %q", role, p)
+			}
+			set[Permission(p)] = struct{}{}
+		}
+		rp[Role(role)] = set
+	}
+
+	return rp, nil
+}
+
+func (rp RolePermissions) Allows(role Role, p Permission) bool {
+	_, ok := rp[role][p]
+	return ok
+}
+
+// Of returns the permissions of role in a stable order.
+func (rp RolePermissions) Of(role Role) []Permission {
+	permissions := make([]Permission, 0, len(rp[role]))
+	for p := range rp[role] {
+		permissions = append(permissions, p)
+	}
+	sort.Slice(permissions, func(i, j int) bool { return permissions[i] < permissions[j] })
+	return permissions
+}
+
+// With returns the roles that grant p in a stable order.
+func (rp RolePermissions) With(p Permission) []Role {
+	roles := []Role{}
+	for role := range rp {
+		if rp.Allows(role, p) {
+			roles = append(roles, role)
+		}
+	}
+	sort.Slice(roles, func(i, j int) bool { return roles[i] < roles[j] })
+	return roles
+}
@@ -0,0 +1,38 @@
+package domains
+
+import "testing"
+
+func TestNewRolePermissions(t *testing.T) {
+	tests := []struct {
+		name    string
+		mapping map[string][]string
+		wantErr bool
+	}{
+		{
+			name:    "Correct",
+			mapping: map[string][]string{"editor": {"film:write", "film:delete"}},
+		},
+		{
+			name:    "Unknown role",
+			mapping: map[string][]string{"owner": {"film:write"}},
+			wantErr: true,
+		},
+		{
+			name:    "Unknown permission",
+			mapping: map[string][]string{"editor": {"film:read"}},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			rp, err := NewRolePermissions(tc.mapping)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("expected error: %t\ngot: %v", tc.wantErr, err)
+			}
+			if err == nil && !rp.Allows(RoleEditor, PermFilmDelete) {
+				t.Errorf("expected editor to delete films")
+			}
+		})
+	}
+}
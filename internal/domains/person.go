@@ -2,7 +2,9 @@ package domains
 
 var Genders = map[string]struct{}{"male": struct{}{}, "female": struct{}{}}
 
-type Actor struct {
+// Person is anyone credited in films: actors as well as directors, writers,
+// producers and composers.
+type Person struct {
 	ID       uint32 `json:"id"`
 	FullName string `json:"fullName"`
 	Gender   Gender `json:"gender"`
@@ -16,7 +18,7 @@ func (g Gender) IsValid() bool {
 	return ok
 }
 
-type ActorWithFilms struct {
-	Actor
-	Films []*Film `json:"films"`
+type PersonWithCredits struct {
+	Person
+	Credits []*FilmCredit `json:"credits"`
 }
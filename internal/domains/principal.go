@@ -0,0 +1,20 @@
+package domains
+
+// Principal is the authenticated caller of a request: a user signed in with
+// an access token or a service using an API key.
+type Principal struct {
+	UserID      uint32       `json:"id,omitempty"`
+	Login       string       `json:"login,omitempty"`
+	Role        Role         `json:"role,omitempty"`
+	APIKeyID    uint32       `json:"apiKeyId,omitempty"`
+	Permissions []Permission `json:"permissions"`
+}
+
+func (p Principal) Can(permission Permission) bool {
+	for _, granted := range p.Permissions {
+		if granted == permission {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,36 @@
+package domains
+
+import "time"
+
+const (
+	// ModerationReported lists visible reviews with open reports,
+	// ModerationHidden reviews hidden by a moderator.
+	ModerationReported = "reported"
+	ModerationHidden   = "hidden"
+)
+
+// Review is a text review of a film. Helpful and Unhelpful count the votes of
+// other users.
+type Review struct {
+	ID         uint32     `json:"id"`
+	FilmID     uint32     `json:"filmId"`
+	AuthorID   uint32     `json:"authorId"`
+	AuthorName string     `json:"authorName"`
+	Text       string     `json:"text"`
+	Helpful    int        `json:"helpful"`
+	Unhelpful  int        `json:"unhelpful"`
+	Hidden     bool       `json:"hidden"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	UpdatedAt  *time.Time `json:"updatedAt,omitempty"`
+}
+
+// ModeratedReview is a review in the moderation queue with the reports that
+// concern it.
+type ModeratedReview struct {
+	Review
+	Reports        int        `json:"reports"`
+	Reasons        []string   `json:"reasons"`
+	LastReportedAt *time.Time `json:"lastReportedAt,omitempty"`
+	ModeratedBy    *uint32    `json:"moderatedBy,omitempty"`
+	ModeratedAt    *time.Time `json:"moderatedAt,omitempty"`
+}
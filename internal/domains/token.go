@@ -0,0 +1,45 @@
+package domains
+
+import "time"
+
+type Tokens struct {
+	AccessToken  string `json:"token"`
+	RefreshToken string `json:"refreshToken"`
+	// ExpiresIn is the access token lifetime in seconds.
+	ExpiresIn int `json:"expiresIn"`
+}
+
+// RevokeReason tells why a refresh token was revoked.
+type RevokeReason string
+
+const (
+	// RevokedRotated tokens were exchanged for a new pair, so presenting one
+	// again means it has leaked.
+	RevokedRotated        RevokeReason = "rotated"
+	RevokedLogout         RevokeReason = "logout"
+	RevokedPasswordChange RevokeReason = "password_change"
+	RevokedReuse          RevokeReason = "reuse"
+)
+
+type RefreshToken struct {
+	ID            uint32
+	UserID        uint32
+	Hash          string
+	ExpiresAt     time.Time
+	RevokedAt     *time.Time
+	RevokedReason RevokeReason
+}
+
+type PasswordResetToken struct {
+	ID        uint32
+	UserID    uint32
+	Hash      string
+	ExpiresAt time.Time
+	UsedAt    *time.Time
+}
+
+// PasswordReset is handed to the user by an admin; the token works once.
+type PasswordReset struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
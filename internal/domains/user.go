@@ -1,12 +1,37 @@
 package domains
 
-var Roles = map[string]struct{}{"admin": struct{}{}, "viewer": struct{}{}}
+import "time"
+
+const (
+	RoleAdmin  Role = "admin"
+	RoleEditor Role = "editor"
+	RoleViewer Role = "viewer"
+)
+
+var Roles = map[string]struct{}{"admin": struct{}{}, "editor": struct{}{}, "viewer": struct{}{}}
 
 type User struct {
 	ID       uint32 `json:"id"`
 	Login    string `json:"login"`
-	Password string `json:"password"`
+	Password string `json:"password,omitempty"`
 	Role     Role   `json:"role"`
+	Disabled bool   `json:"disabled"`
+	// TokenVersion is carried by access tokens; bumping it revokes all
+	// access tokens of the user.
+	TokenVersion int `json:"-"`
+
+	DisplayName string     `json:"displayName"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	LastLoginAt *time.Time `json:"lastLoginAt,omitempty"`
+}
+
+// Profile describes the caller of GET /api/me. Profile fields are empty for
+// API keys, which belong to no user.
+type Profile struct {
+	Principal
+	DisplayName string     `json:"displayName,omitempty"`
+	CreatedAt   *time.Time `json:"createdAt,omitempty"`
+	LastLoginAt *time.Time `json:"lastLoginAt,omitempty"`
 }
 
 type Role string
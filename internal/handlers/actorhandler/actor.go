@@ -1,14 +1,11 @@
 package actorhandler
 
 import (
+	"context"
 	"encoding/json"
-	"errors"
 	"film_library/internal/domains"
 	"film_library/internal/handlers/response"
-	"film_library/internal/repositories/postgres/actorrepo"
-	"film_library/internal/services/actorservice"
 	"film_library/pkg/pagination"
-	"film_library/pkg/validation"
 	"io"
 	"log/slog"
 	"net/http"
@@ -17,15 +14,17 @@ import (
 )
 
 type ActorService interface {
-	CreateActor(actor domains.Actor) error
-	AddActorsToFilm(filmID uint32, actors []uint32) error
-	UpdateActorFullName(id uint32, fullName string) error
-	UpdateActorGender(id uint32, gender domains.Gender) error
-	UpdateActorBirthday(id uint32, birthday time.Time) error
-	UpdateActor(id uint32, actor domains.Actor) error
-	DeleteActor(id uint32) error
-	DeleteActorFromFilm(actorID uint32, filmID uint32) error
-	GetActorsWithFilms(filter *pagination.ActorsFilter) ([]*domains.ActorWithFilms, error)
+	CreateActor(ctx context.Context, actor domains.Person) error
+	AddActorsToFilm(ctx context.Context, filmID uint32, credits []domains.Credit) error
+	ReplaceFilmActors(ctx context.Context, filmID uint32, credits []domains.Credit) error
+	UpdateActorFullName(ctx context.Context, id uint32, fullName string) error
+	UpdateActorGender(ctx context.Context, id uint32, gender domains.Gender) error
+	UpdateActorBirthday(ctx context.Context, id uint32, birthday time.Time) error
+	UpdateActor(ctx context.Context, id uint32, actor domains.Person) error
+	DeleteActor(ctx context.Context, id uint32) error
+	DeleteActorFromFilm(ctx context.Context, actorID uint32, filmID uint32) error
+	GetActorsWithFilms(ctx context.Context, filter *pagination.ActorsFilter) ([]*domains.PersonWithCredits, error)
+	GetActorByID(ctx context.Context, id uint32) (*domains.PersonWithCredits, error)
 }
 
 type ActorHandler struct {
@@ -49,55 +48,81 @@ func New(service ActorService, log *slog.Logger) *ActorHandler {
 // @Param page query integer false "page number"
 // @Param size query integer false "page size"
 // @Param actor query string false "full name contains"
-// @Success 200 {object} []domains.ActorWithFilms
-// @Failure 500 {object} response.ErrorReponse
+// @Param credit query string false "credit type" Enums(actor, director, writer, producer, composer)
+// @Success 200 {object} []domains.PersonWithCredits
+// @Failure 500 {object} response.Problem
 // @Security ApiKeyAuth
 // @Router /api/actors [get]
 func (h *ActorHandler) GetActorsWithFilms(w http.ResponseWriter, r *http.Request) {
 	filter := pagination.NewActorFilterFromRequest(r)
-	actorsWithFilms, err := h.service.GetActorsWithFilms(filter)
+	actorsWithFilms, err := h.service.GetActorsWithFilms(r.Context(), filter)
 	if err != nil {
-		response.JSONError(w, http.StatusInternalServerError, "unknown error", h.log)
+		response.Error(w, r, err, h.log)
 		return
 	}
 
 	response.JSON(w, http.StatusOK, actorsWithFilms, h.log)
 }
 
+// @Summary Get actor
+// @Tags actor
+// @Description get actor by id with films
+// @ID get-actor
+// @Accept  json
+// @Produce  json
+// @Param id path integer true "actor id"
+// @Success 200 {object} domains.PersonWithCredits
+// @Failure 400 {object} response.Problem
+// @Failure 404 {object} response.Problem
+// @Failure 500 {object} response.Problem
+// @Security ApiKeyAuth
+// @Router /api/actor/{id} [get]
+func (h *ActorHandler) GetActorByID(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		response.Error(w, r, response.ErrBadRequest, h.log)
+		return
+	}
+
+	actor, err := h.service.GetActorByID(r.Context(), uint32(id))
+	if err != nil {
+		response.Error(w, r, err, h.log)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, actor, h.log)
+}
+
 // @Summary Create actor
 // @Tags actor
 // @Description create actor
 // @ID create-actor
 // @Accept  json
 // @Produce  json
-// @Param input body domains.Actor true "actor info"
+// @Param input body domains.Person true "actor info"
 // @Success 200
-// @Failure 400 {object} response.ErrorsReponse
-// @Failure 500 {object} response.ErrorReponse
+// @Failure 400 {object} response.Problem
+// @Failure 500 {object} response.Problem
 // @Security ApiKeyAuth
 // @Router /api/actor [post]
 func (h *ActorHandler) CreateActor(w http.ResponseWriter, r *http.Request) {
 	b, err := io.ReadAll(r.Body)
 	if err != nil {
-		response.JSONError(w, http.StatusInternalServerError, "unknown error", h.log)
+		response.Error(w, r, err, h.log)
 		return
 	}
 	defer r.Body.Close()
 
-	var actor domains.Actor
+	var actor domains.Person
 	err = json.Unmarshal(b, &actor)
 	if err != nil {
-		response.JSONError(w, http.StatusBadRequest, "bad request", h.log)
+		response.Error(w, r, response.ErrBadRequest, h.log)
 		return
 	}
 
-	err = h.service.CreateActor(actor)
+	err = h.service.CreateActor(r.Context(), actor)
 	if err != nil {
-		if err, ok := err.(*validation.ValidateError); ok {
-			response.JSONErrors(w, http.StatusBadRequest, err.ToArrayErrors(), h.log)
-			return
-		}
-		response.JSONError(w, http.StatusInternalServerError, "unknown error", h.log)
+		response.Error(w, r, err, h.log)
 		return
 	}
 
@@ -106,50 +131,86 @@ func (h *ActorHandler) CreateActor(w http.ResponseWriter, r *http.Request) {
 
 // @Summary Add actors to film
 // @Tags actor
-// @Description add actors to film
+// @Description add credits to film, a bare person id is an actor credit
 // @ID add-actors
 // @Accept  json
 // @Produce  json
 // @Param filmID path integer true "film id"
-// @Param input body []uint32 true "actors id"
+// @Param input body []domains.Credit true "credits"
 // @Success 200
-// @Failure 400 {object} response.ErrorReponse
-// @Failure 500 {object} response.ErrorReponse
+// @Failure 400 {object} response.Problem
+// @Failure 404 {object} response.Problem
+// @Failure 500 {object} response.Problem
 // @Security ApiKeyAuth
 // @Router /api/actors/{filmID} [post]
 func (h *ActorHandler) AddActorsToFilm(w http.ResponseWriter, r *http.Request) {
 	b, err := io.ReadAll(r.Body)
 	if err != nil {
-		response.JSONError(w, http.StatusInternalServerError, "unknown error", h.log)
+		response.Error(w, r, err, h.log)
+		return
+	}
+	defer r.Body.Close()
+
+	filmID, err := strconv.Atoi(r.PathValue("filmID"))
+	if err != nil {
+		response.Error(w, r, response.ErrBadRequest, h.log)
+		return
+	}
+
+	var credits []domains.Credit
+	err = json.Unmarshal(b, &credits)
+	if err != nil {
+		response.Error(w, r, response.ErrBadRequest, h.log)
+		return
+	}
+
+	err = h.service.AddActorsToFilm(r.Context(), uint32(filmID), credits)
+	if err != nil {
+		response.Error(w, r, err, h.log)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// @Summary Replace film actors
+// @Tags actor
+// @Description replace all credits of the film, a bare person id is an actor credit
+// @ID replace-actors
+// @Accept  json
+// @Produce  json
+// @Param filmID path integer true "film id"
+// @Param input body []domains.Credit true "credits"
+// @Success 200
+// @Failure 400 {object} response.Problem
+// @Failure 404 {object} response.Problem
+// @Failure 500 {object} response.Problem
+// @Security ApiKeyAuth
+// @Router /api/actors/{filmID} [put]
+func (h *ActorHandler) ReplaceFilmActors(w http.ResponseWriter, r *http.Request) {
+	b, err := io.ReadAll(r.Body)
+	if err != nil {
+		response.Error(w, r, err, h.log)
 		return
 	}
 	defer r.Body.Close()
 
 	filmID, err := strconv.Atoi(r.PathValue("filmID"))
 	if err != nil {
-		response.JSONError(w, http.StatusBadRequest, "bad request", h.log)
+		response.Error(w, r, response.ErrBadRequest, h.log)
 		return
 	}
 
-	var actorsID []uint32
-	err = json.Unmarshal(b, &actorsID)
+	var credits []domains.Credit
+	err = json.Unmarshal(b, &credits)
 	if err != nil {
-		response.JSONError(w, http.StatusBadRequest, "bad request", h.log)
+		response.Error(w, r, response.ErrBadRequest, h.log)
 		return
 	}
 
-	err = h.service.AddActorsToFilm(uint32(filmID), actorsID)
+	err = h.service.ReplaceFilmActors(r.Context(), uint32(filmID), credits)
 	if err != nil {
-		if errors.Is(err, actorrepo.ErrNotFound) {
-			response.JSONError(w, http.StatusBadRequest, "actor not found", h.log)
-			return
-		}
-		if errors.Is(err, actorrepo.ErrUniqueActors) {
-			response.JSONError(w, http.StatusBadRequest, "actors must be unique", h.log)
-			return
-		}
-
-		response.JSONError(w, http.StatusInternalServerError, "unknown error", h.log)
+		response.Error(w, r, err, h.log)
 		return
 	}
 
@@ -165,30 +226,23 @@ func (h *ActorHandler) AddActorsToFilm(w http.ResponseWriter, r *http.Request) {
 // @Param id path integer true "actor id"
 // @Param name path string true "actor full name"
 // @Success 200
-// @Failure 400 {object} response.ErrorReponse
-// @Failure 500 {object} response.ErrorReponse
+// @Failure 400 {object} response.Problem
+// @Failure 404 {object} response.Problem
+// @Failure 500 {object} response.Problem
 // @Security ApiKeyAuth
 // @Router /api/actor/name/{id}/{name} [put]
 func (h *ActorHandler) UpdateActorFullName(w http.ResponseWriter, r *http.Request) {
 	id, err := strconv.Atoi(r.PathValue("id"))
 	if err != nil {
-		response.JSONError(w, http.StatusBadRequest, "bad request", h.log)
+		response.Error(w, r, response.ErrBadRequest, h.log)
 		return
 	}
 
 	fullName := r.PathValue("name")
 
-	err = h.service.UpdateActorFullName(uint32(id), fullName)
+	err = h.service.UpdateActorFullName(r.Context(), uint32(id), fullName)
 	if err != nil {
-		if errors.Is(err, actorrepo.ErrNotFound) {
-			response.JSONError(w, http.StatusBadRequest, "actor not found", h.log)
-			return
-		}
-		if errors.Is(err, actorservice.ErrInvalidFullName) {
-			response.JSONError(w, http.StatusBadRequest, "full name must be at least 1 letter long", h.log)
-			return
-		}
-		response.JSONError(w, http.StatusInternalServerError, "unknown error", h.log)
+		response.Error(w, r, err, h.log)
 		return
 	}
 
@@ -204,30 +258,23 @@ func (h *ActorHandler) UpdateActorFullName(w http.ResponseWriter, r *http.Reques
 // @Param id path integer true "actor id"
 // @Param gender path string true "actor gender"
 // @Success 200
-// @Failure 400 {object} response.ErrorReponse
-// @Failure 500 {object} response.ErrorReponse
+// @Failure 400 {object} response.Problem
+// @Failure 404 {object} response.Problem
+// @Failure 500 {object} response.Problem
 // @Security ApiKeyAuth
 // @Router /api/actor/gender/{id}/{gender} [put]
 func (h *ActorHandler) UpdateActorGender(w http.ResponseWriter, r *http.Request) {
 	id, err := strconv.Atoi(r.PathValue("id"))
 	if err != nil {
-		response.JSONError(w, http.StatusBadRequest, "bad request", h.log)
+		response.Error(w, r, response.ErrBadRequest, h.log)
 		return
 	}
 
 	gender := r.PathValue("gender")
 
-	err = h.service.UpdateActorGender(uint32(id), domains.Gender(gender))
+	err = h.service.UpdateActorGender(r.Context(), uint32(id), domains.Gender(gender))
 	if err != nil {
-		if errors.Is(err, actorrepo.ErrNotFound) {
-			response.JSONError(w, http.StatusBadRequest, "actor not found", h.log)
-			return
-		}
-		if errors.Is(err, actorservice.ErrInvalidGender) {
-			response.JSONError(w, http.StatusBadRequest, "gender must be male or female", h.log)
-			return
-		}
-		response.JSONError(w, http.StatusInternalServerError, "unknown error", h.log)
+		response.Error(w, r, err, h.log)
 		return
 	}
 
@@ -243,30 +290,27 @@ func (h *ActorHandler) UpdateActorGender(w http.ResponseWriter, r *http.Request)
 // @Param id path integer true "actor id"
 // @Param birthday path string true "actor birthday" format(2006-01-02)
 // @Success 200
-// @Failure 400 {object} response.ErrorReponse
-// @Failure 500 {object} response.ErrorReponse
+// @Failure 400 {object} response.Problem
+// @Failure 404 {object} response.Problem
+// @Failure 500 {object} response.Problem
 // @Security ApiKeyAuth
 // @Router /api/actor/birthday/{id}/{birthday} [put]
 func (h *ActorHandler) UpdateActorBirthday(w http.ResponseWriter, r *http.Request) {
 	id, err := strconv.Atoi(r.PathValue("id"))
 	if err != nil {
-		response.JSONError(w, http.StatusBadRequest, "bad request", h.log)
+		response.Error(w, r, response.ErrBadRequest, h.log)
 		return
 	}
 
 	birthday, err := time.Parse(time.DateOnly, r.PathValue("birthday"))
 	if err != nil {
-		response.JSONError(w, http.StatusBadRequest, "invalid date", h.log)
+		response.Error(w, r, response.ErrInvalidDate, h.log)
 		return
 	}
 
-	err = h.service.UpdateActorBirthday(uint32(id), birthday)
+	err = h.service.UpdateActorBirthday(r.Context(), uint32(id), birthday)
 	if err != nil {
-		if errors.Is(err, actorrepo.ErrNotFound) {
-			response.JSONError(w, http.StatusBadRequest, "actor not found", h.log)
-			return
-		}
-		response.JSONError(w, http.StatusInternalServerError, "unknown error", h.log)
+		response.Error(w, r, err, h.log)
 		return
 	}
 
@@ -280,40 +324,37 @@ func (h *ActorHandler) UpdateActorBirthday(w http.ResponseWriter, r *http.Reques
 // @Accept  json
 // @Produce  json
 // @Param id path integer true "actor id"
-// @Param input body domains.Actor true "actor info"
+// @Param input body domains.Person true "actor info"
 // @Success 200
-// @Failure 400 {object} response.ErrorsReponse
-// @Failure 500 {object} response.ErrorReponse
+// @Failure 400 {object} response.Problem
+// @Failure 404 {object} response.Problem
+// @Failure 500 {object} response.Problem
 // @Security ApiKeyAuth
 // @Router /api/actor/{id} [put]
 func (h *ActorHandler) UpdateActor(w http.ResponseWriter, r *http.Request) {
 	id, err := strconv.Atoi(r.PathValue("id"))
 	if err != nil {
-		response.JSONError(w, http.StatusBadRequest, "bad request", h.log)
+		response.Error(w, r, response.ErrBadRequest, h.log)
 		return
 	}
 
 	b, err := io.ReadAll(r.Body)
 	if err != nil {
-		response.JSONError(w, http.StatusInternalServerError, "unknown error", h.log)
+		response.Error(w, r, err, h.log)
 		return
 	}
 	defer r.Body.Close()
 
-	actor := domains.Actor{}
+	actor := domains.Person{}
 	err = json.Unmarshal(b, &actor)
 	if err != nil {
-		response.JSONError(w, http.StatusBadRequest, "bad request", h.log)
+		response.Error(w, r, response.ErrBadRequest, h.log)
 		return
 	}
 
-	err = h.service.UpdateActor(uint32(id), actor)
+	err = h.service.UpdateActor(r.Context(), uint32(id), actor)
 	if err != nil {
-		if err, ok := err.(*validation.ValidateError); ok {
-			response.JSONErrors(w, http.StatusBadRequest, err.ToArrayErrors(), h.log)
-			return
-		}
-		response.JSONError(w, http.StatusInternalServerError, "unknown error", h.log)
+		response.Error(w, r, err, h.log)
 		return
 	}
 
@@ -328,24 +369,21 @@ func (h *ActorHandler) UpdateActor(w http.ResponseWriter, r *http.Request) {
 // @Produce  json
 // @Param id path integer true "actor id"
 // @Success 200
-// @Failure 400 {object} response.ErrorReponse
-// @Failure 500 {object} response.ErrorReponse
+// @Failure 400 {object} response.Problem
+// @Failure 404 {object} response.Problem
+// @Failure 500 {object} response.Problem
 // @Security ApiKeyAuth
 // @Router /api/actor/{id} [delete]
 func (h *ActorHandler) DeleteActor(w http.ResponseWriter, r *http.Request) {
 	id, err := strconv.Atoi(r.PathValue("id"))
 	if err != nil {
-		response.JSONError(w, http.StatusBadRequest, "bad request", h.log)
+		response.Error(w, r, response.ErrBadRequest, h.log)
 		return
 	}
 
-	err = h.service.DeleteActor(uint32(id))
+	err = h.service.DeleteActor(r.Context(), uint32(id))
 	if err != nil {
-		if errors.Is(err, actorrepo.ErrNotFound) {
-			response.JSONError(w, http.StatusBadRequest, "actor not found", h.log)
-			return
-		}
-		response.JSONError(w, http.StatusInternalServerError, "unknown error", h.log)
+		response.Error(w, r, err, h.log)
 		return
 	}
 
@@ -361,29 +399,26 @@ func (h *ActorHandler) DeleteActor(w http.ResponseWriter, r *http.Request) {
 // @Param id path integer true "actor id"
 // @Param filmID path integer true "film id"
 // @Success 200
-// @Failure 400 {object} response.ErrorReponse
-// @Failure 500 {object} response.ErrorReponse
+// @Failure 400 {object} response.Problem
+// @Failure 404 {object} response.Problem
+// @Failure 500 {object} response.Problem
 // @Security ApiKeyAuth
 // @Router /api/actor/{id}/{filmID} [delete]
 func (h *ActorHandler) DeleteActorFromFilm(w http.ResponseWriter, r *http.Request) {
 	id, err := strconv.Atoi(r.PathValue("id"))
 	if err != nil {
-		response.JSONError(w, http.StatusBadRequest, "bad request", h.log)
+		response.Error(w, r, response.ErrBadRequest, h.log)
 		return
 	}
 	filmID, err := strconv.Atoi(r.PathValue("id"))
 	if err != nil {
-		response.JSONError(w, http.StatusBadRequest, "bad request", h.log)
+		response.Error(w, r, response.ErrBadRequest, h.log)
 		return
 	}
 
-	err = h.service.DeleteActorFromFilm(uint32(id), uint32(filmID))
+	err = h.service.DeleteActorFromFilm(r.Context(), uint32(id), uint32(filmID))
 	if err != nil {
-		if errors.Is(err, actorrepo.ErrNotFound) {
-			response.JSONError(w, http.StatusBadRequest, "actor not found", h.log)
-			return
-		}
-		response.JSONError(w, http.StatusInternalServerError, "unknown error", h.log)
+		response.Error(w, r, err, h.log)
 		return
 	}
 
@@ -2,9 +2,11 @@ package actorhandler
 
 import (
 	"film_library/internal/domains"
+	"film_library/internal/repositories/postgres/actorrepo"
 	mock_services "film_library/internal/services/mocks"
 	"film_library/pkg/mux"
 	"film_library/pkg/pagination"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -31,23 +33,28 @@ func TestActorHandlerGetActors(t *testing.T) {
 			queryParams: `page=1&size=5`,
 			inputFilter: pagination.ActorsFilter{Pagination: pagination.New(1, 5)},
 			mockBehavior: func(r *mock_services.MockActorService, filter pagination.ActorsFilter) {
-				r.EXPECT().GetActorsWithFilms(&filter).Return([]*domains.ActorWithFilms{
+				r.EXPECT().GetActorsWithFilms(gomock.Any(), &filter).Return([]*domains.PersonWithCredits{
 					{
-						Actor: domains.Actor{ID: 1, FullName: "Denis", Gender: "male", Birthday: domains.Time(time)},
-						Films: []*domains.Film{
+						Person: domains.Person{ID: 1, FullName: "Denis", Gender: "male", Birthday: domains.Time(time)},
+						Credits: []*domains.FilmCredit{
 							{
-								ID:          1,
-								Name:        "Test",
-								Description: "",
-								ReleaseDate: domains.Time(time),
-								Rating:      10,
+								Film: domains.Film{
+									ID:          1,
+									Name:        "Test",
+									Description: "",
+									ReleaseDate: domains.Time(time),
+									Rating:      10,
+								},
+								Type:         domains.CreditActor,
+								Character:    "Neo",
+								BillingOrder: 1,
 							},
 						},
 					},
 				}, nil)
 			},
 			expectedStatusCode:   http.StatusOK,
-			expectedResponseBody: `[{"id":1,"fullName":"Denis","gender":"male","birthday":"2022-06-23","films":[{"id":1,"name":"Test","description":"","releaseDate":"2022-06-23","rating":10}]}]`,
+			expectedResponseBody: `[{"id":1,"fullName":"Denis","gender":"male","birthday":"2022-06-23","credits":[{"id":1,"name":"Test","description":"","releaseDate":"2022-06-23","rating":10,"type":"actor","character":"Neo","billingOrder":1}]}]`,
 		},
 	}
 
@@ -78,3 +85,92 @@ func TestActorHandlerGetActors(t *testing.T) {
 		})
 	}
 }
+
+func TestActorHandlerGetActorByID(t *testing.T) {
+	type mockBehavior func(r *mock_services.MockActorService, id uint32)
+
+	time, _ := time.Parse(time.DateOnly, "2022-06-23")
+
+	tests := []struct {
+		name                 string
+		path                 string
+		inputID              uint32
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:    "Correct",
+			path:    "/api/actor/1",
+			inputID: 1,
+			mockBehavior: func(r *mock_services.MockActorService, id uint32) {
+				r.EXPECT().GetActorByID(gomock.Any(), id).Return(&domains.PersonWithCredits{
+					Person: domains.Person{ID: 1, FullName: "Denis", Gender: "male", Birthday: domains.Time(time)},
+					Credits: []*domains.FilmCredit{
+						{
+							Film:         domains.Film{ID: 1, Name: "Test", ReleaseDate: domains.Time(time), Rating: 10},
+							Type:         domains.CreditDirector,
+							BillingOrder: 2,
+						},
+					},
+				}, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"id":1,"fullName":"Denis","gender":"male","birthday":"2022-06-23","credits":[{"id":1,"name":"Test","description":"","releaseDate":"2022-06-23","rating":10,"type":"director","billingOrder":2}]}`,
+		},
+		{
+			name:                 "Bad id",
+			path:                 "/api/actor/abc",
+			mockBehavior:         func(r *mock_services.MockActorService, id uint32) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"type":"about:blank","title":"Bad Request","status":400,"code":"bad_request","detail":"bad request","instance":"/api/actor/abc"}`,
+		},
+		{
+			name:    "Not found",
+			path:    "/api/actor/2",
+			inputID: 2,
+			mockBehavior: func(r *mock_services.MockActorService, id uint32) {
+				r.EXPECT().GetActorByID(gomock.Any(), id).Return(nil, actorrepo.ErrNotFound)
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"type":"about:blank","title":"Not Found","status":404,"code":"actor_not_found","detail":"actor not found","instance":"/api/actor/2"}`,
+		},
+		{
+			name:    "Unknown error",
+			path:    "/api/actor/3",
+			inputID: 3,
+			mockBehavior: func(r *mock_services.MockActorService, id uint32) {
+				r.EXPECT().GetActorByID(gomock.Any(), id).Return(nil, fmt.Errorf("some error"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: `{"type":"about:blank","title":"Internal Server Error","status":500,"code":"internal_error","detail":"internal error","instance":"/api/actor/3"}`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			service := mock_services.NewMockActorService(c)
+			handler := ActorHandler{service: service}
+			tc.mockBehavior(service, tc.inputID)
+
+			r := mux.New()
+			r.HandleFunc("GET /api/actor/{id}", handler.GetActorByID)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, tc.path, nil)
+
+			r.ServeHTTP(w, req)
+
+			if tc.expectedStatusCode != w.Code {
+				t.Errorf("expected: %d\ngot: %d", tc.expectedStatusCode, w.Code)
+			}
+
+			if tc.expectedResponseBody != w.Body.String() {
+				t.Errorf("expected: %s\ngot: %s", tc.expectedResponseBody, w.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,139 @@
+package apikeyhandler
+
+import (
+	"context"
+	"encoding/json"
+	"film_library/internal/domains"
+	"film_library/internal/handlers/response"
+	"film_library/pkg/middlewares/auth"
+	"film_library/pkg/pagination"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+type APIKeyService interface {
+	CreateAPIKey(ctx context.Context, creator domains.Principal, key domains.APIKey) (*domains.CreatedAPIKey, error)
+	GetAPIKeys(ctx context.Context, page *pagination.Pagination) ([]*domains.APIKey, error)
+	DeleteAPIKey(ctx context.Context, id uint32) error
+}
+
+type APIKeyHandler struct {
+	service APIKeyService
+	log     *slog.Logger
+}
+
+func New(service APIKeyService, log *slog.Logger) *APIKeyHandler {
+	return &APIKeyHandler{
+		service: service,
+		log:     log,
+	}
+}
+
+type InputAPIKey struct {
+	Name        string               `json:"name"`
+	Permissions []domains.Permission `json:"permissions"`
+	ExpiresAt   *time.Time           `json:"expiresAt,omitempty"`
+}
+
+// @Summary Create API key
+// @Security ApiKeyAuth
+// @Tags apikey
+// @Description create an API key scoped to permissions the caller holds; the key is only returned once. Only users create keys, a key stops working with its creator
+// @ID create-api-key
+// @Accept  json
+// @Produce  json
+// @Param input body InputAPIKey true "api key info"
+// @Success 200 {object} domains.CreatedAPIKey
+// @Failure 400 {object} response.Problem
+// @Failure 401 {object} response.Problem
+// @Failure 403 {object} response.Problem
+// @Failure 500 {object} response.Problem
+// @Router /api/apikey [post]
+func (h *APIKeyHandler) CreateAPIKey(w http.ResponseWriter, r *http.Request) {
+	creator, err := auth.CurrentUser(r)
+	if err != nil {
+		response.Error(w, r, err, h.log)
+		return
+	}
+
+	b, err := io.ReadAll(r.Body)
+	if err != nil {
+		response.Error(w, r, err, h.log)
+		return
+	}
+	defer r.Body.Close()
+
+	var input InputAPIKey
+	err = json.Unmarshal(b, &input)
+	if err != nil {
+		response.Error(w, r, response.ErrBadRequest, h.log)
+		return
+	}
+
+	key, err := h.service.CreateAPIKey(r.Context(), creator, domains.APIKey{
+		Name:        input.Name,
+		Permissions: input.Permissions,
+		ExpiresAt:   input.ExpiresAt,
+	})
+	if err != nil {
+		response.Error(w, r, err, h.log)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, key, h.log)
+}
+
+// @Summary Get API keys
+// @Security ApiKeyAuth
+// @Tags apikey
+// @Description get API keys without the keys themselves
+// @ID get-api-keys
+// @Produce  json
+// @Param page query integer false "page number"
+// @Param size query integer false "page size"
+// @Success 200 {object} []domains.APIKey
+// @Failure 401 {object} response.Problem
+// @Failure 403 {object} response.Problem
+// @Failure 500 {object} response.Problem
+// @Router /api/apikeys [get]
+func (h *APIKeyHandler) GetAPIKeys(w http.ResponseWriter, r *http.Request) {
+	keys, err := h.service.GetAPIKeys(r.Context(), pagination.NewFromRequest(r))
+	if err != nil {
+		response.Error(w, r, err, h.log)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, keys, h.log)
+}
+
+// @Summary Delete API key
+// @Security ApiKeyAuth
+// @Tags apikey
+// @Description revoke an API key
+// @ID delete-api-key
+// @Param id path int true "api key id"
+// @Success 200
+// @Failure 400 {object} response.Problem
+// @Failure 401 {object} response.Problem
+// @Failure 403 {object} response.Problem
+// @Failure 404 {object} response.Problem
+// @Failure 500 {object} response.Problem
+// @Router /api/apikey/{id} [delete]
+func (h *APIKeyHandler) DeleteAPIKey(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		response.Error(w, r, response.ErrBadRequest, h.log)
+		return
+	}
+
+	err = h.service.DeleteAPIKey(r.Context(), uint32(id))
+	if err != nil {
+		response.Error(w, r, err, h.log)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
@@ -0,0 +1,110 @@
+package apikeyhandler
+
+import (
+	"bytes"
+	"context"
+	"film_library/internal/domains"
+	"film_library/internal/services/apikeyservice"
+	mock_services "film_library/internal/services/mocks"
+	"film_library/pkg/middlewares/auth"
+	"film_library/pkg/mux"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestAPIKeyHandlerCreateAPIKey(t *testing.T) {
+	type mockBehavior func(r *mock_services.MockAPIKeyService, creator domains.Principal, key domains.APIKey)
+
+	creator := domains.Principal{UserID: 1, Login: "admin", Role: domains.RoleAdmin, Permissions: []domains.Permission{domains.PermFilmWrite}}
+	createdAt := time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name                 string
+		byAPIKey             bool
+		inputBody            string
+		inputKey             domains.APIKey
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:      "Correct",
+			inputBody: `{"name":"batch","permissions":["film:write"]}`,
+			inputKey:  domains.APIKey{Name: "batch", Permissions: []domains.Permission{domains.PermFilmWrite}},
+			mockBehavior: func(r *mock_services.MockAPIKeyService, creator domains.Principal, key domains.APIKey) {
+				stored := key
+				stored.ID = 1
+				stored.Prefix = "flk_abcdef"
+				stored.CreatedBy = creator.UserID
+				stored.CreatedAt = createdAt
+				r.EXPECT().CreateAPIKey(gomock.Any(), creator, key).
+					Return(&domains.CreatedAPIKey{APIKey: stored, Key: "flk_abcdefgh"}, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"id":1,"name":"batch","prefix":"flk_abcdef","permissions":["film:write"],"createdBy":1,"createdAt":"2024-03-15T12:00:00Z","key":"flk_abcdefgh"}`,
+		},
+		{
+			name:                 "Invalid body",
+			inputBody:            `{"name":`,
+			mockBehavior:         func(r *mock_services.MockAPIKeyService, creator domains.Principal, key domains.APIKey) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"type":"about:blank","title":"Bad Request","status":400,"code":"bad_request","detail":"bad request","instance":"/api/apikey"}`,
+		},
+		{
+			name:      "Permission not held",
+			inputBody: `{"name":"batch","permissions":["user:manage"]}`,
+			inputKey:  domains.APIKey{Name: "batch", Permissions: []domains.Permission{domains.PermUserManage}},
+			mockBehavior: func(r *mock_services.MockAPIKeyService, creator domains.Principal, key domains.APIKey) {
+				r.EXPECT().CreateAPIKey(gomock.Any(), creator, key).
+					Return(nil, fmt.Errorf("create: %w", apikeyservice.ErrPermissionNotHeld))
+			},
+			expectedStatusCode:   http.StatusForbidden,
+			expectedResponseBody: `{"type":"about:blank","title":"Forbidden","status":403,"code":"permission_not_held","detail":"permission not held by the caller","instance":"/api/apikey"}`,
+		},
+		{
+			name:                 "API key",
+			byAPIKey:             true,
+			inputBody:            `{"name":"batch","permissions":["film:write"]}`,
+			mockBehavior:         func(r *mock_services.MockAPIKeyService, creator domains.Principal, key domains.APIKey) {},
+			expectedStatusCode:   http.StatusForbidden,
+			expectedResponseBody: `{"type":"about:blank","title":"Forbidden","status":403,"code":"forbidden","detail":"forbidden","instance":"/api/apikey"}`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			service := mock_services.NewMockAPIKeyService(c)
+			handler := APIKeyHandler{service: service}
+			tc.mockBehavior(service, creator, tc.inputKey)
+
+			r := mux.New()
+			r.HandleFunc("POST /api/apikey", handler.CreateAPIKey)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/api/apikey", bytes.NewBufferString(tc.inputBody))
+			principal := creator
+			if tc.byAPIKey {
+				principal = domains.Principal{APIKeyID: 5, Permissions: []domains.Permission{domains.PermUserManage}}
+			}
+			req = req.WithContext(context.WithValue(req.Context(), auth.UserKey("user"), principal))
+
+			r.ServeHTTP(w, req)
+
+			if tc.expectedStatusCode != w.Code {
+				t.Errorf("expected: %d\ngot: %d", tc.expectedStatusCode, w.Code)
+			}
+
+			if tc.expectedResponseBody != w.Body.String() {
+				t.Errorf("expected: %s\ngot: %s", tc.expectedResponseBody, w.Body.String())
+			}
+		})
+	}
+}
@@ -1,14 +1,12 @@
 package filmhandler
 
 import (
+	"context"
 	"encoding/json"
-	"errors"
 	"film_library/internal/domains"
 	"film_library/internal/handlers/response"
-	"film_library/internal/repositories/postgres/filmrepo"
-	"film_library/internal/services/filmservice"
+	"film_library/pkg/middlewares/auth"
 	"film_library/pkg/pagination"
-	"film_library/pkg/validation"
 	"io"
 	"log/slog"
 	"net/http"
@@ -17,14 +15,23 @@ import (
 )
 
 type FilmService interface {
-	CreateFilm(film domains.Film, actors []uint32) (uint32, error)
-	UpdateFilmName(id uint32, name string) error
-	UpdateFilmDescription(id uint32, descrtion string) error
-	UpdateFilmReleaseDate(id uint32, releaseDate time.Time) error
-	UpdateFilmRating(id uint32, rating int) error
-	UpdateFilm(id uint32, film domains.Film) error
-	DeleteFilm(id uint32) error
-	GetFilms(filter *pagination.FilmFilter) ([]*domains.Film, error)
+	CreateFilm(ctx context.Context, film domains.Film, actors, genres []uint32) (uint32, error)
+	UpdateFilmName(ctx context.Context, id uint32, name string) error
+	UpdateFilmDescription(ctx context.Context, id uint32, descrtion string) error
+	UpdateFilmReleaseDate(ctx context.Context, id uint32, releaseDate time.Time) error
+	UpdateFilmRating(ctx context.Context, id uint32, rating int) error
+	UpdateFilm(ctx context.Context, id uint32, film domains.Film, genres []uint32) error
+	DeleteFilm(ctx context.Context, id uint32) error
+	GetFilms(ctx context.Context, filter *pagination.FilmFilter) ([]*domains.Film, error)
+	GetFilmByID(ctx context.Context, id uint32) (*domains.FilmWithCredits, error)
+	RateFilm(ctx context.Context, userID, filmID uint32, rating int) (*domains.FilmScore, error)
+	DeleteFilmRating(ctx context.Context, userID, filmID uint32) (*domains.FilmScore, error)
+	AddToWatchlist(ctx context.Context, userID, filmID uint32) error
+	RemoveFromWatchlist(ctx context.Context, userID, filmID uint32) error
+	GetWatchlist(ctx context.Context, userID uint32, filter *pagination.FilmFilter) ([]*domains.WatchlistEntry, error)
+	MarkWatched(ctx context.Context, userID, filmID uint32, watchedAt time.Time, rewatchCount *int) error
+	DeleteWatched(ctx context.Context, userID, filmID uint32) error
+	GetWatchedFilms(ctx context.Context, userID uint32, filter *pagination.FilmFilter) ([]*domains.WatchedEntry, error)
 }
 
 type FilmHandler struct {
@@ -42,6 +49,13 @@ func New(service FilmService, log *slog.Logger) *FilmHandler {
 type InputCreateFilm struct {
 	Film     domains.Film `json:"film"`
 	ActorsID []uint32     `json:"actorsID"`
+	GenresID []uint32     `json:"genresID"`
+}
+
+// InputUpdateFilm keeps the genres of the film when genresID is missing.
+type InputUpdateFilm struct {
+	domains.Film
+	GenresID []uint32 `json:"genresID"`
 }
 
 // @Summary Create film
@@ -50,16 +64,18 @@ type InputCreateFilm struct {
 // @ID create-film
 // @Accept  json
 // @Produce  json
-// @Param input body InputCreateFilm true "film and actors info"
+// @Param input body InputCreateFilm true "film, actors and genres info"
 // @Success 200 {object} integer
-// @Success 400 {object} response.ErrorsReponse
-// @Failure 500 {object} response.ErrorReponse
+// @Failure 400 {object} response.Problem
+// @Failure 404 {object} response.Problem
+// @Failure 409 {object} response.Problem
+// @Failure 500 {object} response.Problem
 // @Security ApiKeyAuth
 // @Router /api/film [post]
 func (h *FilmHandler) CreateFilm(w http.ResponseWriter, r *http.Request) {
 	b, err := io.ReadAll(r.Body)
 	if err != nil {
-		response.JSONError(w, http.StatusInternalServerError, "unknown error", h.log)
+		response.Error(w, r, err, h.log)
 		return
 	}
 	defer r.Body.Close()
@@ -67,24 +83,17 @@ func (h *FilmHandler) CreateFilm(w http.ResponseWriter, r *http.Request) {
 	input := InputCreateFilm{}
 	err = json.Unmarshal(b, &input)
 	if err != nil {
-		response.JSONError(w, http.StatusBadRequest, "bad request", h.log)
+		response.Error(w, r, response.ErrBadRequest, h.log)
 		return
 	}
 
 	film := input.Film
 	actors := input.ActorsID
+	genres := input.GenresID
 
-	id, err := h.service.CreateFilm(film, actors)
+	id, err := h.service.CreateFilm(r.Context(), film, actors, genres)
 	if err != nil {
-		if err, ok := err.(*validation.ValidateError); ok {
-			response.JSONErrors(w, http.StatusBadRequest, err.ToArrayErrors(), h.log)
-			return
-		}
-		if errors.Is(err, filmrepo.ErrAlreadyExists) {
-			response.JSONError(w, http.StatusBadRequest, "film already exists", h.log)
-			return
-		}
-		response.JSONError(w, http.StatusInternalServerError, "unknown error", h.log)
+		response.Error(w, r, err, h.log)
 		return
 	}
 
@@ -95,7 +104,7 @@ func (h *FilmHandler) CreateFilm(w http.ResponseWriter, r *http.Request) {
 
 // @Summary Get films
 // @Tags film
-// @Description get films
+// @Description get films, for users flagged whether they are in the watchlist or watched
 // @ID get-films
 // @Accept  json
 // @Produce  json
@@ -103,23 +112,58 @@ func (h *FilmHandler) CreateFilm(w http.ResponseWriter, r *http.Request) {
 // @Param size query integer false "page size"
 // @Param film query string false "film name contains"
 // @Param actor query string false "actor full name contains"
-// @Param sort query string false "films order by"
+// @Param genre query string false "genre names, comma separated"
+// @Param genreMatch query string false "films with any or all of the genres" Enums(any, all)
+// @Param sort query string false "films order by" Enums(name, rating, release_date, score)
 // @Success 200 {object} []domains.Film
-// @Failure 500 {object} response.ErrorReponse
+// @Failure 500 {object} response.Problem
 // @Security ApiKeyAuth
 // @Router /api/films [get]
 func (h *FilmHandler) GetFilms(w http.ResponseWriter, r *http.Request) {
 	filter := pagination.NewFilmFilterFromRequest(r)
+	// Films requested with an API key have no user to flag them for.
+	if principal, ok := r.Context().Value(auth.UserKey("user")).(domains.Principal); ok {
+		filter.UserID = principal.UserID
+	}
 
-	actorsWithFilms, err := h.service.GetFilms(filter)
+	actorsWithFilms, err := h.service.GetFilms(r.Context(), filter)
 	if err != nil {
-		response.JSONError(w, http.StatusInternalServerError, "unknown error", h.log)
+		response.Error(w, r, err, h.log)
 		return
 	}
 
 	response.JSON(w, http.StatusOK, actorsWithFilms, h.log)
 }
 
+// @Summary Get film
+// @Tags film
+// @Description get film by id with its actors and genres
+// @ID get-film
+// @Accept  json
+// @Produce  json
+// @Param id path integer true "film id"
+// @Success 200 {object} domains.FilmWithCredits
+// @Failure 400 {object} response.Problem
+// @Failure 404 {object} response.Problem
+// @Failure 500 {object} response.Problem
+// @Security ApiKeyAuth
+// @Router /api/film/{id} [get]
+func (h *FilmHandler) GetFilmByID(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		response.Error(w, r, response.ErrBadRequest, h.log)
+		return
+	}
+
+	film, err := h.service.GetFilmByID(r.Context(), uint32(id))
+	if err != nil {
+		response.Error(w, r, err, h.log)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, film, h.log)
+}
+
 // @Summary Update film name
 // @Tags film
 // @Description update film name
@@ -129,30 +173,23 @@ func (h *FilmHandler) GetFilms(w http.ResponseWriter, r *http.Request) {
 // @Param id path integer true "film id"
 // @Param name path string true "film name"
 // @Success 200
-// @Failure 400 {object} response.ErrorReponse
-// @Failure 500 {object} response.ErrorReponse
+// @Failure 400 {object} response.Problem
+// @Failure 404 {object} response.Problem
+// @Failure 500 {object} response.Problem
 // @Security ApiKeyAuth
 // @Router /api/film/name/{id}/{name} [put]
 func (h *FilmHandler) UpdateFilmName(w http.ResponseWriter, r *http.Request) {
 	id, err := strconv.Atoi(r.PathValue("id"))
 	if err != nil {
-		response.JSONError(w, http.StatusBadRequest, "bad request", h.log)
+		response.Error(w, r, response.ErrBadRequest, h.log)
 		return
 	}
 
 	name := r.PathValue("name")
 
-	err = h.service.UpdateFilmName(uint32(id), name)
+	err = h.service.UpdateFilmName(r.Context(), uint32(id), name)
 	if err != nil {
-		if errors.Is(err, filmrepo.ErrNotFound) {
-			response.JSONError(w, http.StatusBadRequest, "film not found", h.log)
-			return
-		}
-		if errors.Is(err, filmrepo.ErrInvalidNameLength) {
-			response.JSONError(w, http.StatusBadRequest, "invalid film name length", h.log)
-			return
-		}
-		response.JSONError(w, http.StatusInternalServerError, "unknown error", h.log)
+		response.Error(w, r, err, h.log)
 		return
 	}
 
@@ -172,20 +209,21 @@ type InputDescription struct {
 // @Param id path integer true "film id"
 // @Param description body InputDescription true "actor gender"
 // @Success 200
-// @Failure 400 {object} response.ErrorReponse
-// @Failure 500 {object} response.ErrorReponse
+// @Failure 400 {object} response.Problem
+// @Failure 404 {object} response.Problem
+// @Failure 500 {object} response.Problem
 // @Security ApiKeyAuth
 // @Router /api/film/description/{id} [put]
 func (h *FilmHandler) UpdateFilmDescription(w http.ResponseWriter, r *http.Request) {
 	id, err := strconv.Atoi(r.PathValue("id"))
 	if err != nil {
-		response.JSONError(w, http.StatusBadRequest, "bad request", h.log)
+		response.Error(w, r, response.ErrBadRequest, h.log)
 		return
 	}
 
 	b, err := io.ReadAll(r.Body)
 	if err != nil {
-		response.JSONError(w, http.StatusInternalServerError, "unknown error", h.log)
+		response.Error(w, r, err, h.log)
 		return
 	}
 	defer r.Body.Close()
@@ -193,17 +231,13 @@ func (h *FilmHandler) UpdateFilmDescription(w http.ResponseWriter, r *http.Reque
 	description := InputDescription{}
 	err = json.Unmarshal(b, &description)
 	if err != nil {
-		response.JSONError(w, http.StatusBadRequest, "bad request", h.log)
+		response.Error(w, r, response.ErrBadRequest, h.log)
 		return
 	}
 
-	err = h.service.UpdateFilmDescription(uint32(id), description.Description)
+	err = h.service.UpdateFilmDescription(r.Context(), uint32(id), description.Description)
 	if err != nil {
-		if errors.Is(err, filmrepo.ErrNotFound) {
-			response.JSONError(w, http.StatusBadRequest, "film not found", h.log)
-			return
-		}
-		response.JSONError(w, http.StatusInternalServerError, "unknown error", h.log)
+		response.Error(w, r, err, h.log)
 		return
 	}
 
@@ -219,30 +253,27 @@ func (h *FilmHandler) UpdateFilmDescription(w http.ResponseWriter, r *http.Reque
 // @Param id path integer true "film id"
 // @Param date path string true "film release date" format(2006-01-02)
 // @Success 200
-// @Failure 400 {object} response.ErrorReponse
-// @Failure 500 {object} response.ErrorReponse
+// @Failure 400 {object} response.Problem
+// @Failure 404 {object} response.Problem
+// @Failure 500 {object} response.Problem
 // @Security ApiKeyAuth
 // @Router /api/film/date/{id}/{date} [put]
 func (h *FilmHandler) UpdateFilmReleaseDate(w http.ResponseWriter, r *http.Request) {
 	id, err := strconv.Atoi(r.PathValue("id"))
 	if err != nil {
-		response.JSONError(w, http.StatusBadRequest, "bad request", h.log)
+		response.Error(w, r, response.ErrBadRequest, h.log)
 		return
 	}
 
 	releaseDate, err := time.Parse(time.DateOnly, r.PathValue("date"))
 	if err != nil {
-		response.JSONError(w, http.StatusBadRequest, "invalid date", h.log)
+		response.Error(w, r, response.ErrInvalidDate, h.log)
 		return
 	}
 
-	err = h.service.UpdateFilmReleaseDate(uint32(id), releaseDate)
+	err = h.service.UpdateFilmReleaseDate(r.Context(), uint32(id), releaseDate)
 	if err != nil {
-		if errors.Is(err, filmrepo.ErrNotFound) {
-			response.JSONError(w, http.StatusBadRequest, "actor not found", h.log)
-			return
-		}
-		response.JSONError(w, http.StatusInternalServerError, "unknown error", h.log)
+		response.Error(w, r, err, h.log)
 		return
 	}
 
@@ -258,34 +289,27 @@ func (h *FilmHandler) UpdateFilmReleaseDate(w http.ResponseWriter, r *http.Reque
 // @Param id path integer true "film id"
 // @Param rating path integer true "film rating"
 // @Success 200
-// @Failure 400 {object} response.ErrorsReponse
-// @Failure 500 {object} response.ErrorReponse
+// @Failure 400 {object} response.Problem
+// @Failure 404 {object} response.Problem
+// @Failure 500 {object} response.Problem
 // @Security ApiKeyAuth
 // @Router /api/film/{id}/{rating} [put]
 func (h *FilmHandler) UpdateFilmRating(w http.ResponseWriter, r *http.Request) {
 	id, err := strconv.Atoi(r.PathValue("id"))
 	if err != nil {
-		response.JSONError(w, http.StatusBadRequest, "bad request", h.log)
+		response.Error(w, r, response.ErrBadRequest, h.log)
 		return
 	}
 
 	rating, err := strconv.Atoi(r.PathValue("rating"))
 	if err != nil {
-		response.JSONError(w, http.StatusBadRequest, "bad request", h.log)
+		response.Error(w, r, response.ErrBadRequest, h.log)
 		return
 	}
 
-	err = h.service.UpdateFilmRating(uint32(id), rating)
+	err = h.service.UpdateFilmRating(r.Context(), uint32(id), rating)
 	if err != nil {
-		if errors.Is(err, filmrepo.ErrNotFound) {
-			response.JSONError(w, http.StatusBadRequest, "actor not found", h.log)
-			return
-		}
-		if errors.Is(err, filmservice.ErrInvalidRating) {
-			response.JSONError(w, http.StatusBadRequest, "invalid rating", h.log)
-			return
-		}
-		response.JSONError(w, http.StatusInternalServerError, "unknown error", h.log)
+		response.Error(w, r, err, h.log)
 		return
 	}
 
@@ -299,40 +323,37 @@ func (h *FilmHandler) UpdateFilmRating(w http.ResponseWriter, r *http.Request) {
 // @Accept  json
 // @Produce  json
 // @Param id path integer true "film id"
-// @Param input body domains.Film true "film info"
+// @Param input body InputUpdateFilm true "film info"
 // @Success 200
-// @Failure 400 {object} response.ErrorsReponse
-// @Failure 500 {object} response.ErrorReponse
+// @Failure 400 {object} response.Problem
+// @Failure 404 {object} response.Problem
+// @Failure 500 {object} response.Problem
 // @Security ApiKeyAuth
 // @Router /api/film/{id} [put]
 func (h *FilmHandler) UpdateFilm(w http.ResponseWriter, r *http.Request) {
 	id, err := strconv.Atoi(r.PathValue("id"))
 	if err != nil {
-		response.JSONError(w, http.StatusBadRequest, "bad request", h.log)
+		response.Error(w, r, response.ErrBadRequest, h.log)
 		return
 	}
 
 	b, err := io.ReadAll(r.Body)
 	if err != nil {
-		response.JSONError(w, http.StatusInternalServerError, "unknown error", h.log)
+		response.Error(w, r, err, h.log)
 		return
 	}
 	defer r.Body.Close()
 
-	film := domains.Film{}
-	err = json.Unmarshal(b, &film)
+	input := InputUpdateFilm{}
+	err = json.Unmarshal(b, &input)
 	if err != nil {
-		response.JSONError(w, http.StatusBadRequest, "bad request", h.log)
+		response.Error(w, r, response.ErrBadRequest, h.log)
 		return
 	}
 
-	err = h.service.UpdateFilm(uint32(id), film)
+	err = h.service.UpdateFilm(r.Context(), uint32(id), input.Film, input.GenresID)
 	if err != nil {
-		if err, ok := err.(*validation.ValidateError); ok {
-			response.JSONErrors(w, http.StatusBadRequest, err.ToArrayErrors(), h.log)
-			return
-		}
-		response.JSONError(w, http.StatusInternalServerError, "unknown error", h.log)
+		response.Error(w, r, err, h.log)
 		return
 	}
 
@@ -347,24 +368,21 @@ func (h *FilmHandler) UpdateFilm(w http.ResponseWriter, r *http.Request) {
 // @Produce  json
 // @Param id path integer true "film id"
 // @Success 200
-// @Failure 400 {object} response.ErrorReponse
-// @Failure 500 {object} response.ErrorReponse
+// @Failure 400 {object} response.Problem
+// @Failure 404 {object} response.Problem
+// @Failure 500 {object} response.Problem
 // @Security ApiKeyAuth
 // @Router /api/film/{id} [delete]
 func (h *FilmHandler) DeleteFilm(w http.ResponseWriter, r *http.Request) {
 	id, err := strconv.Atoi(r.PathValue("id"))
 	if err != nil {
-		response.JSONError(w, http.StatusBadRequest, "bad request", h.log)
+		response.Error(w, r, response.ErrBadRequest, h.log)
 		return
 	}
 
-	err = h.service.DeleteFilm(uint32(id))
+	err = h.service.DeleteFilm(r.Context(), uint32(id))
 	if err != nil {
-		if errors.Is(err, filmrepo.ErrNotFound) {
-			response.JSONError(w, http.StatusBadRequest, "film not found", h.log)
-			return
-		}
-		response.JSONError(w, http.StatusInternalServerError, "unknown error", h.log)
+		response.Error(w, r, err, h.log)
 		return
 	}
 
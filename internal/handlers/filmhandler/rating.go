@@ -0,0 +1,101 @@
+package filmhandler
+
+import (
+	"encoding/json"
+	"film_library/internal/handlers/response"
+	"film_library/pkg/middlewares/auth"
+	"io"
+	"net/http"
+	"strconv"
+)
+
+type InputRating struct {
+	Rating int `json:"rating"`
+}
+
+// @Summary Rate film
+// @Tags film
+// @Description set the rating the current user gives the film, returns the updated score
+// @ID rate-film
+// @Accept  json
+// @Produce  json
+// @Param filmID path integer true "film id"
+// @Param input body InputRating true "rating"
+// @Success 200 {object} domains.FilmScore
+// @Failure 400 {object} response.Problem
+// @Failure 403 {object} response.Problem
+// @Failure 404 {object} response.Problem
+// @Failure 500 {object} response.Problem
+// @Security ApiKeyAuth
+// @Router /api/me/ratings/{filmID} [put]
+func (h *FilmHandler) RateFilm(w http.ResponseWriter, r *http.Request) {
+	principal, err := auth.CurrentUser(r)
+	if err != nil {
+		response.Error(w, r, err, h.log)
+		return
+	}
+
+	filmID, err := strconv.Atoi(r.PathValue("filmID"))
+	if err != nil {
+		response.Error(w, r, response.ErrBadRequest, h.log)
+		return
+	}
+
+	b, err := io.ReadAll(r.Body)
+	if err != nil {
+		response.Error(w, r, err, h.log)
+		return
+	}
+	defer r.Body.Close()
+
+	input := InputRating{}
+	err = json.Unmarshal(b, &input)
+	if err != nil {
+		response.Error(w, r, response.ErrBadRequest, h.log)
+		return
+	}
+
+	score, err := h.service.RateFilm(r.Context(), principal.UserID, uint32(filmID), input.Rating)
+	if err != nil {
+		response.Error(w, r, err, h.log)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, score, h.log)
+}
+
+// @Summary Delete film rating
+// @Tags film
+// @Description delete the rating the current user gave the film, returns the updated score
+// @ID delete-film-rating
+// @Accept  json
+// @Produce  json
+// @Param filmID path integer true "film id"
+// @Success 200 {object} domains.FilmScore
+// @Failure 400 {object} response.Problem
+// @Failure 403 {object} response.Problem
+// @Failure 404 {object} response.Problem
+// @Failure 500 {object} response.Problem
+// @Security ApiKeyAuth
+// @Router /api/me/ratings/{filmID} [delete]
+func (h *FilmHandler) DeleteFilmRating(w http.ResponseWriter, r *http.Request) {
+	principal, err := auth.CurrentUser(r)
+	if err != nil {
+		response.Error(w, r, err, h.log)
+		return
+	}
+
+	filmID, err := strconv.Atoi(r.PathValue("filmID"))
+	if err != nil {
+		response.Error(w, r, response.ErrBadRequest, h.log)
+		return
+	}
+
+	score, err := h.service.DeleteFilmRating(r.Context(), principal.UserID, uint32(filmID))
+	if err != nil {
+		response.Error(w, r, err, h.log)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, score, h.log)
+}
@@ -0,0 +1,75 @@
+package filmhandler
+
+import (
+	"bytes"
+	"context"
+	"film_library/internal/domains"
+	mock_services "film_library/internal/services/mocks"
+	"film_library/pkg/middlewares/auth"
+	"film_library/pkg/mux"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestFilmHandlerRateFilm(t *testing.T) {
+	type mockBehavior func(r *mock_services.MockFilmService)
+
+	viewer := domains.Principal{UserID: 2, Login: "viewer", Role: domains.RoleViewer}
+
+	tests := []struct {
+		name                 string
+		inputBody            string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:      "Correct",
+			inputBody: `{"rating":8}`,
+			mockBehavior: func(r *mock_services.MockFilmService) {
+				r.EXPECT().RateFilm(gomock.Any(), uint32(2), uint32(1), 8).
+					Return(&domains.FilmScore{Mean: 8, Count: 1, Bayesian: 6.2}, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"mean":8,"count":1,"bayesian":6.2}`,
+		},
+		{
+			name:                 "Wrong input",
+			inputBody:            `{"rating":"eight"}`,
+			mockBehavior:         func(r *mock_services.MockFilmService) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"type":"about:blank","title":"Bad Request","status":400,"code":"bad_request","detail":"bad request","instance":"/api/me/ratings/1"}`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			service := mock_services.NewMockFilmService(c)
+			handler := FilmHandler{service: service}
+			tc.mockBehavior(service)
+
+			r := mux.New()
+			r.HandleFunc("PUT /api/me/ratings/{filmID}", handler.RateFilm)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPut, "/api/me/ratings/1", bytes.NewBufferString(tc.inputBody))
+			req = req.WithContext(context.WithValue(req.Context(), auth.UserKey("user"), viewer))
+
+			r.ServeHTTP(w, req)
+
+			if tc.expectedStatusCode != w.Code {
+				t.Errorf("expected: %d\ngot: %d", tc.expectedStatusCode, w.Code)
+			}
+
+			if tc.expectedResponseBody != w.Body.String() {
+				t.Errorf("expected: %s\ngot: %s", tc.expectedResponseBody, w.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,252 @@
+package filmhandler
+
+import (
+	"encoding/json"
+	"film_library/internal/domains"
+	"film_library/internal/handlers/response"
+	"film_library/pkg/middlewares/auth"
+	"film_library/pkg/pagination"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+type InputWatched struct {
+	WatchedAt    *domains.Time `json:"watchedAt" format:"2006-01-02"`
+	RewatchCount *int          `json:"rewatchCount"`
+}
+
+// @Summary Add film to watchlist
+// @Tags watchlist
+// @Description add film to the watchlist of the current user
+// @ID add-to-watchlist
+// @Accept  json
+// @Produce  json
+// @Param filmID path integer true "film id"
+// @Success 200
+// @Failure 400 {object} response.Problem
+// @Failure 403 {object} response.Problem
+// @Failure 404 {object} response.Problem
+// @Failure 500 {object} response.Problem
+// @Security ApiKeyAuth
+// @Router /api/me/watchlist/{filmID} [put]
+func (h *FilmHandler) AddToWatchlist(w http.ResponseWriter, r *http.Request) {
+	principal, err := auth.CurrentUser(r)
+	if err != nil {
+		response.Error(w, r, err, h.log)
+		return
+	}
+
+	filmID, err := strconv.Atoi(r.PathValue("filmID"))
+	if err != nil {
+		response.Error(w, r, response.ErrBadRequest, h.log)
+		return
+	}
+
+	err = h.service.AddToWatchlist(r.Context(), principal.UserID, uint32(filmID))
+	if err != nil {
+		response.Error(w, r, err, h.log)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// @Summary Remove film from watchlist
+// @Tags watchlist
+// @Description remove film from the watchlist of the current user
+// @ID remove-from-watchlist
+// @Accept  json
+// @Produce  json
+// @Param filmID path integer true "film id"
+// @Success 200
+// @Failure 400 {object} response.Problem
+// @Failure 403 {object} response.Problem
+// @Failure 404 {object} response.Problem
+// @Failure 500 {object} response.Problem
+// @Security ApiKeyAuth
+// @Router /api/me/watchlist/{filmID} [delete]
+func (h *FilmHandler) RemoveFromWatchlist(w http.ResponseWriter, r *http.Request) {
+	principal, err := auth.CurrentUser(r)
+	if err != nil {
+		response.Error(w, r, err, h.log)
+		return
+	}
+
+	filmID, err := strconv.Atoi(r.PathValue("filmID"))
+	if err != nil {
+		response.Error(w, r, response.ErrBadRequest, h.log)
+		return
+	}
+
+	err = h.service.RemoveFromWatchlist(r.Context(), principal.UserID, uint32(filmID))
+	if err != nil {
+		response.Error(w, r, err, h.log)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// @Summary Get watchlist
+// @Tags watchlist
+// @Description get the watchlist of the current user, filtered and sorted like films
+// @ID get-watchlist
+// @Accept  json
+// @Produce  json
+// @Param page query integer false "page number"
+// @Param size query integer false "page size"
+// @Param film query string false "film name contains"
+// @Param actor query string false "actor full name contains"
+// @Param genre query string false "genre names, comma separated"
+// @Param genreMatch query string false "films with any or all of the genres" Enums(any, all)
+// @Param sort query string false "films order by" Enums(name, rating, release_date, score, added_at)
+// @Success 200 {object} []domains.WatchlistEntry
+// @Failure 403 {object} response.Problem
+// @Failure 500 {object} response.Problem
+// @Security ApiKeyAuth
+// @Router /api/me/watchlist [get]
+func (h *FilmHandler) GetWatchlist(w http.ResponseWriter, r *http.Request) {
+	principal, err := auth.CurrentUser(r)
+	if err != nil {
+		response.Error(w, r, err, h.log)
+		return
+	}
+
+	entries, err := h.service.GetWatchlist(r.Context(), principal.UserID, pagination.NewFilmFilterFromRequest(r))
+	if err != nil {
+		response.Error(w, r, err, h.log)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, entries, h.log)
+}
+
+// @Summary Mark film watched
+// @Tags watchlist
+// @Description add film to the watched history of the current user or update its entry, the date defaults to today and an omitted rewatch count is kept
+// @ID mark-watched
+// @Accept  json
+// @Produce  json
+// @Param filmID path integer true "film id"
+// @Param input body InputWatched false "watched date and rewatch count"
+// @Success 200
+// @Failure 400 {object} response.Problem
+// @Failure 403 {object} response.Problem
+// @Failure 404 {object} response.Problem
+// @Failure 500 {object} response.Problem
+// @Security ApiKeyAuth
+// @Router /api/me/watched/{filmID} [put]
+func (h *FilmHandler) MarkWatched(w http.ResponseWriter, r *http.Request) {
+	principal, err := auth.CurrentUser(r)
+	if err != nil {
+		response.Error(w, r, err, h.log)
+		return
+	}
+
+	filmID, err := strconv.Atoi(r.PathValue("filmID"))
+	if err != nil {
+		response.Error(w, r, response.ErrBadRequest, h.log)
+		return
+	}
+
+	b, err := io.ReadAll(r.Body)
+	if err != nil {
+		response.Error(w, r, err, h.log)
+		return
+	}
+	defer r.Body.Close()
+
+	// Both fields are optional, so is the body.
+	input := InputWatched{}
+	if len(b) != 0 {
+		if err := json.Unmarshal(b, &input); err != nil {
+			response.Error(w, r, response.ErrBadRequest, h.log)
+			return
+		}
+	}
+
+	var watchedAt time.Time
+	if input.WatchedAt != nil {
+		watchedAt = time.Time(*input.WatchedAt)
+	}
+
+	err = h.service.MarkWatched(r.Context(), principal.UserID, uint32(filmID), watchedAt, input.RewatchCount)
+	if err != nil {
+		response.Error(w, r, err, h.log)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// @Summary Delete watched film
+// @Tags watchlist
+// @Description remove film from the watched history of the current user
+// @ID delete-watched
+// @Accept  json
+// @Produce  json
+// @Param filmID path integer true "film id"
+// @Success 200
+// @Failure 400 {object} response.Problem
+// @Failure 403 {object} response.Problem
+// @Failure 404 {object} response.Problem
+// @Failure 500 {object} response.Problem
+// @Security ApiKeyAuth
+// @Router /api/me/watched/{filmID} [delete]
+func (h *FilmHandler) DeleteWatched(w http.ResponseWriter, r *http.Request) {
+	principal, err := auth.CurrentUser(r)
+	if err != nil {
+		response.Error(w, r, err, h.log)
+		return
+	}
+
+	filmID, err := strconv.Atoi(r.PathValue("filmID"))
+	if err != nil {
+		response.Error(w, r, response.ErrBadRequest, h.log)
+		return
+	}
+
+	err = h.service.DeleteWatched(r.Context(), principal.UserID, uint32(filmID))
+	if err != nil {
+		response.Error(w, r, err, h.log)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// @Summary Get watched films
+// @Tags watchlist
+// @Description get the watched history of the current user, filtered and sorted like films
+// @ID get-watched
+// @Accept  json
+// @Produce  json
+// @Param page query integer false "page number"
+// @Param size query integer false "page size"
+// @Param film query string false "film name contains"
+// @Param actor query string false "actor full name contains"
+// @Param genre query string false "genre names, comma separated"
+// @Param genreMatch query string false "films with any or all of the genres" Enums(any, all)
+// @Param sort query string false "films order by" Enums(name, rating, release_date, score, watched_at)
+// @Success 200 {object} []domains.WatchedEntry
+// @Failure 403 {object} response.Problem
+// @Failure 500 {object} response.Problem
+// @Security ApiKeyAuth
+// @Router /api/me/watched [get]
+func (h *FilmHandler) GetWatchedFilms(w http.ResponseWriter, r *http.Request) {
+	principal, err := auth.CurrentUser(r)
+	if err != nil {
+		response.Error(w, r, err, h.log)
+		return
+	}
+
+	entries, err := h.service.GetWatchedFilms(r.Context(), principal.UserID, pagination.NewFilmFilterFromRequest(r))
+	if err != nil {
+		response.Error(w, r, err, h.log)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, entries, h.log)
+}
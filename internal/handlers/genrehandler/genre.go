@@ -0,0 +1,168 @@
+package genrehandler
+
+import (
+	"context"
+	"encoding/json"
+	"film_library/internal/domains"
+	"film_library/internal/handlers/response"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+type GenreService interface {
+	CreateGenre(ctx context.Context, genre domains.Genre) (uint32, error)
+	GetGenres(ctx context.Context) ([]*domains.Genre, error)
+	UpdateGenre(ctx context.Context, id uint32, genre domains.Genre) error
+	DeleteGenre(ctx context.Context, id uint32) error
+}
+
+type GenreHandler struct {
+	service GenreService
+	log     *slog.Logger
+}
+
+func New(service GenreService, log *slog.Logger) *GenreHandler {
+	return &GenreHandler{
+		service: service,
+		log:     log,
+	}
+}
+
+type InputGenre struct {
+	Name string `json:"name"`
+}
+
+// @Summary Create genre
+// @Tags genre
+// @Description create genre
+// @ID create-genre
+// @Accept  json
+// @Produce  json
+// @Param input body InputGenre true "genre info"
+// @Success 200 {object} integer
+// @Failure 400 {object} response.Problem
+// @Failure 409 {object} response.Problem
+// @Failure 500 {object} response.Problem
+// @Security ApiKeyAuth
+// @Router /api/genre [post]
+func (h *GenreHandler) CreateGenre(w http.ResponseWriter, r *http.Request) {
+	b, err := io.ReadAll(r.Body)
+	if err != nil {
+		response.Error(w, r, err, h.log)
+		return
+	}
+	defer r.Body.Close()
+
+	input := InputGenre{}
+	err = json.Unmarshal(b, &input)
+	if err != nil {
+		response.Error(w, r, response.ErrBadRequest, h.log)
+		return
+	}
+
+	id, err := h.service.CreateGenre(r.Context(), domains.Genre{Name: input.Name})
+	if err != nil {
+		response.Error(w, r, err, h.log)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, map[string]any{
+		"id": id,
+	}, h.log)
+}
+
+// @Summary Get genres
+// @Tags genre
+// @Description get all genres ordered by name
+// @ID get-genres
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} []domains.Genre
+// @Failure 500 {object} response.Problem
+// @Security ApiKeyAuth
+// @Router /api/genres [get]
+func (h *GenreHandler) GetGenres(w http.ResponseWriter, r *http.Request) {
+	genres, err := h.service.GetGenres(r.Context())
+	if err != nil {
+		response.Error(w, r, err, h.log)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, genres, h.log)
+}
+
+// @Summary Update genre
+// @Tags genre
+// @Description rename genre
+// @ID update-genre
+// @Accept  json
+// @Produce  json
+// @Param id path integer true "genre id"
+// @Param input body InputGenre true "genre info"
+// @Success 200
+// @Failure 400 {object} response.Problem
+// @Failure 404 {object} response.Problem
+// @Failure 409 {object} response.Problem
+// @Failure 500 {object} response.Problem
+// @Security ApiKeyAuth
+// @Router /api/genre/{id} [put]
+func (h *GenreHandler) UpdateGenre(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		response.Error(w, r, response.ErrBadRequest, h.log)
+		return
+	}
+
+	b, err := io.ReadAll(r.Body)
+	if err != nil {
+		response.Error(w, r, err, h.log)
+		return
+	}
+	defer r.Body.Close()
+
+	input := InputGenre{}
+	err = json.Unmarshal(b, &input)
+	if err != nil {
+		response.Error(w, r, response.ErrBadRequest, h.log)
+		return
+	}
+
+	err = h.service.UpdateGenre(r.Context(), uint32(id), domains.Genre{Name: input.Name})
+	if err != nil {
+		response.Error(w, r, err, h.log)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// @Summary Delete genre
+// @Tags genre
+// @Description delete genre, films keep their other genres
+// @ID delete-genre
+// @Accept  json
+// @Produce  json
+// @Param id path integer true "genre id"
+// @Success 200
+// @Failure 400 {object} response.Problem
+// @Failure 404 {object} response.Problem
+// @Failure 500 {object} response.Problem
+// @Security ApiKeyAuth
+// @Router /api/genre/{id} [delete]
+func (h *GenreHandler) DeleteGenre(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		response.Error(w, r, response.ErrBadRequest, h.log)
+		return
+	}
+
+	err = h.service.DeleteGenre(r.Context(), uint32(id))
+	if err != nil {
+		response.Error(w, r, err, h.log)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
@@ -0,0 +1,82 @@
+package genrehandler
+
+import (
+	"bytes"
+	"film_library/internal/domains"
+	"film_library/internal/repositories/postgres/genrerepo"
+	mock_services "film_library/internal/services/mocks"
+	"film_library/pkg/mux"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestGenreHandlerCreateGenre(t *testing.T) {
+	type mockBehavior func(r *mock_services.MockGenreService, genre domains.Genre)
+
+	tests := []struct {
+		name                 string
+		inputBody            string
+		inputGenre           domains.Genre
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:       "Correct",
+			inputBody:  `{"name":"Drama"}`,
+			inputGenre: domains.Genre{Name: "Drama"},
+			mockBehavior: func(r *mock_services.MockGenreService, genre domains.Genre) {
+				r.EXPECT().CreateGenre(gomock.Any(), genre).Return(uint32(1), nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"id":1}`,
+		},
+		{
+			name:       "Already exists",
+			inputBody:  `{"name":"Drama"}`,
+			inputGenre: domains.Genre{Name: "Drama"},
+			mockBehavior: func(r *mock_services.MockGenreService, genre domains.Genre) {
+				r.EXPECT().CreateGenre(gomock.Any(), genre).Return(uint32(0), genrerepo.ErrAlreadyExists)
+			},
+			expectedStatusCode:   http.StatusConflict,
+			expectedResponseBody: `{"type":"about:blank","title":"Conflict","status":409,"code":"genre_already_exists","detail":"genre already exists","instance":"/api/genre"}`,
+		},
+		{
+			name:                 "Invalid body",
+			inputBody:            `{"name":`,
+			mockBehavior:         func(r *mock_services.MockGenreService, genre domains.Genre) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"type":"about:blank","title":"Bad Request","status":400,"code":"bad_request","detail":"bad request","instance":"/api/genre"}`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			service := mock_services.NewMockGenreService(c)
+			handler := GenreHandler{service: service}
+			tc.mockBehavior(service, tc.inputGenre)
+
+			r := mux.New()
+			r.HandleFunc("POST /api/genre", handler.CreateGenre)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/api/genre", bytes.NewBufferString(tc.inputBody))
+
+			r.ServeHTTP(w, req)
+
+			if tc.expectedStatusCode != w.Code {
+				t.Errorf("expected: %d\ngot: %d", tc.expectedStatusCode, w.Code)
+			}
+
+			if tc.expectedResponseBody != w.Body.String() {
+				t.Errorf("expected: %s\ngot: %s", tc.expectedResponseBody, w.Body.String())
+			}
+		})
+	}
+}
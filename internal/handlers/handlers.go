@@ -2,7 +2,10 @@ package handlers
 
 import (
 	"film_library/internal/handlers/actorhandler"
+	"film_library/internal/handlers/apikeyhandler"
 	"film_library/internal/handlers/filmhandler"
+	"film_library/internal/handlers/genrehandler"
+	"film_library/internal/handlers/reviewhandler"
 	"film_library/internal/handlers/userhandler"
 	"film_library/internal/services"
 	"log/slog"
@@ -12,6 +15,9 @@ type Handler struct {
 	*userhandler.UserHandler
 	*actorhandler.ActorHandler
 	*filmhandler.FilmHandler
+	*genrehandler.GenreHandler
+	*reviewhandler.ReviewHandler
+	*apikeyhandler.APIKeyHandler
 }
 
 func New(service services.IService, log *slog.Logger) *Handler {
@@ -19,5 +25,8 @@ func New(service services.IService, log *slog.Logger) *Handler {
 		userhandler.New(service, log),
 		actorhandler.New(service, log),
 		filmhandler.New(service, log),
+		genrehandler.New(service, log),
+		reviewhandler.New(service, log),
+		apikeyhandler.New(service, log),
 	}
 }
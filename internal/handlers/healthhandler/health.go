@@ -0,0 +1,124 @@
+package healthhandler
+
+import (
+	"context"
+	"film_library/internal/handlers/response"
+	"log/slog"
+	"net/http"
+	"sort"
+	"sync/atomic"
+	"time"
+)
+
+const checkTimeout = 2 * time.Second
+
+const (
+	StatusOK          = "ok"
+	StatusUnavailable = "unavailable"
+	StatusDraining    = "draining"
+	StatusUp          = "up"
+	StatusDown        = "down"
+)
+
+type Checker interface {
+	Ping(ctx context.Context) error
+}
+
+type HealthHandler struct {
+	checks   map[string]Checker
+	draining atomic.Bool
+	log      *slog.Logger
+}
+
+func New(checks map[string]Checker, log *slog.Logger) *HealthHandler {
+	return &HealthHandler{
+		checks: checks,
+		log:    log,
+	}
+}
+
+// CheckResult leaves out the error of a failed check: the probe is not
+// authenticated and errors may tell about the infrastructure.
+type CheckResult struct {
+	Status  string `json:"status"`
+	Latency string `json:"latency"`
+}
+
+type Readiness struct {
+	Status string                 `json:"status"`
+	Checks map[string]CheckResult `json:"checks"`
+}
+
+// Drain makes the readiness probe fail, so that load balancers stop
+// routing new requests before the server shuts down.
+func (h *HealthHandler) Drain() {
+	h.draining.Store(true)
+}
+
+// @Summary Liveness probe
+// @Tags health
+// @Description reports that the process is alive
+// @ID healthz
+// @Produce  json
+// @Success 200 {object} map[string]string
+// @Router /healthz [get]
+func (h *HealthHandler) Liveness(w http.ResponseWriter, r *http.Request) {
+	response.JSON(w, http.StatusOK, map[string]string{
+		"status": StatusOK,
+	}, h.log)
+}
+
+// @Summary Readiness probe
+// @Tags health
+// @Description reports whether the server and its dependencies can serve traffic
+// @ID readyz
+// @Produce  json
+// @Success 200 {object} Readiness
+// @Failure 503 {object} Readiness
+// @Router /readyz [get]
+func (h *HealthHandler) Readiness(w http.ResponseWriter, r *http.Request) {
+	if h.draining.Load() {
+		response.JSON(w, http.StatusServiceUnavailable, Readiness{
+			Status: StatusDraining,
+			Checks: map[string]CheckResult{},
+		}, h.log)
+		return
+	}
+
+	names := make([]string, 0, len(h.checks))
+	for name := range h.checks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	res := Readiness{Status: StatusOK, Checks: make(map[string]CheckResult, len(h.checks))}
+	for _, name := range names {
+		result, err := h.check(r.Context(), h.checks[name])
+		if err != nil {
+			res.Status = StatusUnavailable
+			h.log.Warn("readiness check failed", slog.String("check", name), slog.String("error", err.Error()))
+		}
+		res.Checks[name] = result
+	}
+
+	code := http.StatusOK
+	if res.Status != StatusOK {
+		code = http.StatusServiceUnavailable
+	}
+
+	response.JSON(w, code, res, h.log)
+}
+
+func (h *HealthHandler) check(ctx context.Context, checker Checker) (CheckResult, error) {
+	ctx, cancel := context.WithTimeout(ctx, checkTimeout)
+	defer cancel()
+
+	start := time.Now()
+	err := checker.Ping(ctx)
+	latency := time.Since(start)
+
+	if err != nil {
+		return CheckResult{Status: StatusDown, Latency: latency.String()}, err
+	}
+	return CheckResult{Status: StatusUp, Latency: latency.String()}, nil
+}
@@ -0,0 +1,100 @@
+package healthhandler
+
+import (
+	"context"
+	"encoding/json"
+	"film_library/pkg/mux"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type checkerFunc func(ctx context.Context) error
+
+func (f checkerFunc) Ping(ctx context.Context) error {
+	return f(ctx)
+}
+
+func TestHealthHandlerReadiness(t *testing.T) {
+	up := checkerFunc(func(ctx context.Context) error { return nil })
+	down := checkerFunc(func(ctx context.Context) error { return fmt.Errorf("dial tcp db.internal:5432: connection refused") })
+
+	tests := []struct {
+		name               string
+		checks             map[string]Checker
+		draining           bool
+		expectedStatusCode int
+		expectedStatus     string
+		expectedChecks     map[string]string
+	}{
+		{
+			name:               "Ready",
+			checks:             map[string]Checker{"database": up},
+			expectedStatusCode: http.StatusOK,
+			expectedStatus:     StatusOK,
+			expectedChecks:     map[string]string{"database": StatusUp},
+		},
+		{
+			name:               "Database down",
+			checks:             map[string]Checker{"database": down},
+			expectedStatusCode: http.StatusServiceUnavailable,
+			expectedStatus:     StatusUnavailable,
+			expectedChecks:     map[string]string{"database": StatusDown},
+		},
+		{
+			name:               "Draining",
+			checks:             map[string]Checker{"database": up},
+			draining:           true,
+			expectedStatusCode: http.StatusServiceUnavailable,
+			expectedStatus:     StatusDraining,
+			expectedChecks:     map[string]string{},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			handler := New(tc.checks, slog.New(slog.NewTextHandler(io.Discard, nil)))
+			if tc.draining {
+				handler.Drain()
+			}
+
+			r := mux.New()
+			r.HandleFunc("GET /readyz", handler.Readiness)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/readyz", nil)
+
+			r.ServeHTTP(w, req)
+
+			if tc.expectedStatusCode != w.Code {
+				t.Errorf("expected: %d\ngot: %d", tc.expectedStatusCode, w.Code)
+			}
+
+			if strings.Contains(w.Body.String(), "db.internal") {
+				t.Errorf("check error leaked: %s", w.Body.String())
+			}
+
+			var got Readiness
+			if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+				t.Fatalf("%s", err.Error())
+			}
+
+			if got.Status != tc.expectedStatus {
+				t.Errorf("expected: %s\ngot: %s", tc.expectedStatus, got.Status)
+			}
+
+			if len(got.Checks) != len(tc.expectedChecks) {
+				t.Errorf("expected: %#v\ngot: %#v", tc.expectedChecks, got.Checks)
+			}
+			for name, status := range tc.expectedChecks {
+				if got.Checks[name].Status != status {
+					t.Errorf("expected: %s\ngot: %s", status, got.Checks[name].Status)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package keyshandler
+
+import (
+	"film_library/internal/handlers/response"
+	"film_library/internal/tokens"
+	"log/slog"
+	"net/http"
+)
+
+// cacheControl lets verifiers cache the key set for a while; a rotation window
+// must be longer than max-age so that new keys are picked up in time.
+const cacheControl = "public, max-age=300"
+
+type KeySource interface {
+	JWKS() tokens.JWKS
+}
+
+type KeysHandler struct {
+	keys KeySource
+	log  *slog.Logger
+}
+
+func New(keys KeySource, log *slog.Logger) *KeysHandler {
+	return &KeysHandler{
+		keys: keys,
+		log:  log,
+	}
+}
+
+// @Summary JSON Web Key Set
+// @Tags auth
+// @Description public keys that verify access tokens
+// @ID jwks
+// @Produce  json
+// @Success 200 {object} tokens.JWKS
+// @Router /.well-known/jwks.json [get]
+func (h *KeysHandler) JWKS(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Cache-Control", cacheControl)
+	response.JSON(w, http.StatusOK, h.keys.JWKS(), h.log)
+}
@@ -0,0 +1,231 @@
+package response
+
+import (
+	"context"
+	"errors"
+	"film_library/internal/lockout"
+	"film_library/internal/repositories/postgres/actorrepo"
+	"film_library/internal/repositories/postgres/apikeyrepo"
+	"film_library/internal/repositories/postgres/filmrepo"
+	"film_library/internal/repositories/postgres/genrerepo"
+	"film_library/internal/repositories/postgres/ratingrepo"
+	"film_library/internal/repositories/postgres/reviewrepo"
+	"film_library/internal/repositories/postgres/userrepo"
+	"film_library/internal/services/actorservice"
+	"film_library/internal/services/apikeyservice"
+	"film_library/internal/services/filmservice"
+	"film_library/internal/services/genreservice"
+	"film_library/internal/services/reviewservice"
+	"film_library/internal/services/userservice"
+	"film_library/internal/tokens"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Errors raised by handlers and middlewares themselves.
+var (
+	ErrBadRequest   = fmt.Errorf("bad request")
+	ErrInvalidDate  = fmt.Errorf("invalid date")
+	ErrUnauthorized = fmt.Errorf("unauthorized")
+	ErrForbidden    = fmt.Errorf("forbidden")
+
+	ErrTooManyRequests = fmt.Errorf("too many requests")
+)
+
+// RetryError asks the client to come back after Retry: Error sets the
+// Retry-After header for it.
+type RetryError struct {
+	Err   error
+	Retry time.Duration
+}
+
+func (e *RetryError) Error() string {
+	return fmt.Sprintf("%s, retry after %s", e.Err.Error(), e.Retry)
+}
+
+func (e *RetryError) Unwrap() error {
+	return e.Err
+}
+
+func (e *RetryError) RetryAfter() time.Duration {
+	return e.Retry
+}
+
+// Codes are part of the API contract: once published they must not change.
+const (
+	CodeInternal         = "internal_error"
+	CodeTimeout          = "timeout"
+	CodeBadRequest       = "bad_request"
+	CodeValidationFailed = "validation_failed"
+	CodeInvalidDate      = "invalid_date"
+	CodeUnauthorized     = "unauthorized"
+	CodeInvalidToken     = "invalid_token"
+	CodeTokenExpired     = "token_expired"
+	CodeTokenRevoked     = "token_revoked"
+	CodeForbidden        = "forbidden"
+	CodeRateLimited      = "rate_limited"
+
+	CodeInvalidCredentials = "invalid_credentials"
+	CodeTooManyAttempts    = "too_many_attempts"
+	CodeUserDisabled       = "user_disabled"
+	CodeInvalidRefresh     = "invalid_refresh_token"
+	CodeUserNotFound       = "user_not_found"
+	CodeUserAlreadyExists  = "user_already_exists"
+	CodeInvalidRole        = "invalid_role"
+	CodeInvalidLogin       = "invalid_login"
+	CodeInvalidPassword    = "invalid_password"
+	CodeWrongPassword      = "invalid_current_password"
+	CodeInvalidResetToken  = "invalid_reset_token"
+	CodeInvalidDisplayName = "invalid_display_name"
+	CodeOwnAccount         = "own_account"
+	CodeTargetPrivileged   = "target_privileged"
+	CodeLastUserManager    = "last_user_manager"
+
+	CodeAPIKeyNotFound      = "api_key_not_found"
+	CodeInvalidAPIKey       = "invalid_api_key"
+	CodeAPIKeyExpired       = "api_key_expired"
+	CodeInvalidAPIKeyName   = "invalid_api_key_name"
+	CodeInvalidPermission   = "invalid_permission"
+	CodePermissionNotHeld   = "permission_not_held"
+	CodeInvalidAPIKeyExpiry = "invalid_api_key_expiry"
+
+	CodeFilmNotFound           = "film_not_found"
+	CodeFilmAlreadyExists      = "film_already_exists"
+	CodeInvalidFilmName        = "invalid_film_name"
+	CodeInvalidFilmDescription = "invalid_film_description"
+	CodeInvalidFilmRating      = "invalid_film_rating"
+
+	CodeRatingNotFound    = "rating_not_found"
+	CodeInvalidUserRating = "invalid_user_rating"
+
+	CodeNotInWatchlist      = "not_in_watchlist"
+	CodeNotWatched          = "not_watched"
+	CodeInvalidWatchedAt    = "invalid_watched_at"
+	CodeInvalidRewatchCount = "invalid_rewatch_count"
+
+	CodeReviewNotFound          = "review_not_found"
+	CodeReviewAlreadyExists     = "review_already_exists"
+	CodeInvalidReviewText       = "invalid_review_text"
+	CodeOwnReview               = "own_review"
+	CodeVoteNotFound            = "vote_not_found"
+	CodeReviewAlreadyReported   = "review_already_reported"
+	CodeInvalidReportReason     = "invalid_report_reason"
+	CodeInvalidModerationStatus = "invalid_moderation_status"
+
+	CodeGenreNotFound      = "genre_not_found"
+	CodeGenreAlreadyExists = "genre_already_exists"
+	CodeGenresNotUnique    = "genres_not_unique"
+	CodeInvalidGenreName   = "invalid_genre_name"
+
+	CodeActorNotFound      = "actor_not_found"
+	CodeActorsNotUnique    = "actors_not_unique"
+	CodeInvalidActorName   = "invalid_actor_name"
+	CodeInvalidActorGender = "invalid_actor_gender"
+	CodeInvalidCreditType  = "invalid_credit_type"
+	CodeInvalidCharacter   = "invalid_character"
+)
+
+type mapping struct {
+	err    error
+	status int
+	code   string
+	// detail replaces the error text when it must not reach clients.
+	detail string
+}
+
+// mappings is checked in order with errors.Is, the first match wins.
+var mappings = []mapping{
+	{ErrBadRequest, http.StatusBadRequest, CodeBadRequest, ""},
+	{ErrInvalidDate, http.StatusBadRequest, CodeInvalidDate, ""},
+	{ErrUnauthorized, http.StatusUnauthorized, CodeUnauthorized, ""},
+	{ErrForbidden, http.StatusForbidden, CodeForbidden, ""},
+	{ErrTooManyRequests, http.StatusTooManyRequests, CodeRateLimited, ""},
+	{tokens.ErrInvalid, http.StatusUnauthorized, CodeInvalidToken, ""},
+	{tokens.ErrExpired, http.StatusUnauthorized, CodeTokenExpired, ""},
+	{tokens.ErrRevoked, http.StatusUnauthorized, CodeTokenRevoked, ""},
+	{context.DeadlineExceeded, http.StatusGatewayTimeout, CodeTimeout, ""},
+
+	{userservice.ErrNotFound, http.StatusUnauthorized, CodeInvalidCredentials, "invalid login or password"},
+	{userservice.ErrInvalidPassword, http.StatusUnauthorized, CodeInvalidCredentials, "invalid login or password"},
+	{lockout.ErrLocked, http.StatusTooManyRequests, CodeTooManyAttempts, ""},
+	{userservice.ErrUserDisabled, http.StatusForbidden, CodeUserDisabled, ""},
+	{userservice.ErrInvalidRefresh, http.StatusUnauthorized, CodeInvalidRefresh, ""},
+	{userservice.ErrInvalidRole, http.StatusBadRequest, CodeInvalidRole, ""},
+	{userservice.ErrInvalidLoginLen, http.StatusBadRequest, CodeInvalidLogin, ""},
+	{userservice.ErrInvalidPasswordLen, http.StatusBadRequest, CodeInvalidPassword, ""},
+	{userservice.ErrWrongPassword, http.StatusForbidden, CodeWrongPassword, ""},
+	{userservice.ErrInvalidResetToken, http.StatusBadRequest, CodeInvalidResetToken, ""},
+	{userservice.ErrInvalidDisplayName, http.StatusBadRequest, CodeInvalidDisplayName, ""},
+	{userservice.ErrOwnAccount, http.StatusForbidden, CodeOwnAccount, ""},
+	{userservice.ErrTargetPrivileged, http.StatusForbidden, CodeTargetPrivileged, ""},
+	{userservice.ErrLastUserManager, http.StatusConflict, CodeLastUserManager, ""},
+	{userrepo.ErrNotFound, http.StatusNotFound, CodeUserNotFound, ""},
+	{userrepo.ErrAlreadyExists, http.StatusConflict, CodeUserAlreadyExists, ""},
+	{userrepo.ErrInvalidRole, http.StatusBadRequest, CodeInvalidRole, ""},
+
+	{apikeyservice.ErrInvalidAPIKey, http.StatusUnauthorized, CodeInvalidAPIKey, ""},
+	{apikeyservice.ErrAPIKeyExpired, http.StatusUnauthorized, CodeAPIKeyExpired, ""},
+	{apikeyservice.ErrCreatorDisabled, http.StatusUnauthorized, CodeInvalidAPIKey, ""},
+	{apikeyservice.ErrCreatorRequired, http.StatusForbidden, CodeForbidden, ""},
+	{apikeyservice.ErrInvalidName, http.StatusBadRequest, CodeInvalidAPIKeyName, ""},
+	{apikeyservice.ErrInvalidPermission, http.StatusBadRequest, CodeInvalidPermission, ""},
+	{apikeyservice.ErrPermissionNotHeld, http.StatusForbidden, CodePermissionNotHeld, ""},
+	{apikeyservice.ErrInvalidExpiry, http.StatusBadRequest, CodeInvalidAPIKeyExpiry, ""},
+	{apikeyrepo.ErrNotFound, http.StatusNotFound, CodeAPIKeyNotFound, ""},
+
+	{filmservice.ErrInvalidName, http.StatusBadRequest, CodeInvalidFilmName, ""},
+	{filmservice.ErrInvalidDescription, http.StatusBadRequest, CodeInvalidFilmDescription, ""},
+	{filmservice.ErrInvalidRating, http.StatusBadRequest, CodeInvalidFilmRating, ""},
+	{filmrepo.ErrNotFound, http.StatusNotFound, CodeFilmNotFound, ""},
+	{filmrepo.ErrAlreadyExists, http.StatusConflict, CodeFilmAlreadyExists, ""},
+	{filmrepo.ErrInvalidNameLength, http.StatusBadRequest, CodeInvalidFilmName, ""},
+	{filmrepo.ErrInvalidRating, http.StatusBadRequest, CodeInvalidFilmRating, ""},
+
+	{filmservice.ErrInvalidUserRating, http.StatusBadRequest, CodeInvalidUserRating, ""},
+	{ratingrepo.ErrNotFound, http.StatusNotFound, CodeRatingNotFound, ""},
+	{ratingrepo.ErrFilmNotFound, http.StatusNotFound, CodeFilmNotFound, ""},
+	{ratingrepo.ErrInvalidRating, http.StatusBadRequest, CodeInvalidUserRating, ""},
+
+	{filmservice.ErrInvalidWatchedAt, http.StatusBadRequest, CodeInvalidWatchedAt, ""},
+	{filmservice.ErrInvalidRewatchCount, http.StatusBadRequest, CodeInvalidRewatchCount, ""},
+	{filmrepo.ErrNotInWatchlist, http.StatusNotFound, CodeNotInWatchlist, ""},
+	{filmrepo.ErrNotWatched, http.StatusNotFound, CodeNotWatched, ""},
+	{filmrepo.ErrInvalidRewatchCount, http.StatusBadRequest, CodeInvalidRewatchCount, ""},
+
+	{reviewservice.ErrInvalidText, http.StatusBadRequest, CodeInvalidReviewText, ""},
+	{reviewservice.ErrInvalidReason, http.StatusBadRequest, CodeInvalidReportReason, ""},
+	{reviewservice.ErrOwnReview, http.StatusForbidden, CodeOwnReview, ""},
+	{reviewservice.ErrInvalidModerationStatus, http.StatusBadRequest, CodeInvalidModerationStatus, ""},
+	{reviewrepo.ErrNotFound, http.StatusNotFound, CodeReviewNotFound, ""},
+	{reviewrepo.ErrFilmNotFound, http.StatusNotFound, CodeFilmNotFound, ""},
+	{reviewrepo.ErrAlreadyExists, http.StatusConflict, CodeReviewAlreadyExists, ""},
+	{reviewrepo.ErrInvalidText, http.StatusBadRequest, CodeInvalidReviewText, ""},
+	{reviewrepo.ErrVoteNotFound, http.StatusNotFound, CodeVoteNotFound, ""},
+	{reviewrepo.ErrAlreadyReported, http.StatusConflict, CodeReviewAlreadyReported, ""},
+
+	{genreservice.ErrInvalidName, http.StatusBadRequest, CodeInvalidGenreName, ""},
+	{genrerepo.ErrNotFound, http.StatusNotFound, CodeGenreNotFound, ""},
+	{genrerepo.ErrAlreadyExists, http.StatusConflict, CodeGenreAlreadyExists, ""},
+	{genrerepo.ErrInvalidNameLength, http.StatusBadRequest, CodeInvalidGenreName, ""},
+	{genrerepo.ErrUniqueGenres, http.StatusBadRequest, CodeGenresNotUnique, ""},
+	{genrerepo.ErrFilmNotFound, http.StatusNotFound, CodeFilmNotFound, ""},
+
+	{actorservice.ErrInvalidFullName, http.StatusBadRequest, CodeInvalidActorName, ""},
+	{actorservice.ErrInvalidGender, http.StatusBadRequest, CodeInvalidActorGender, ""},
+	{actorrepo.ErrNotFound, http.StatusNotFound, CodeActorNotFound, ""},
+	{actorrepo.ErrUniqueActors, http.StatusBadRequest, CodeActorsNotUnique, ""},
+	{actorrepo.ErrInvalidGender, http.StatusBadRequest, CodeInvalidActorGender, ""},
+	{actorservice.ErrInvalidCreditType, http.StatusBadRequest, CodeInvalidCreditType, ""},
+	{actorservice.ErrInvalidCharacter, http.StatusBadRequest, CodeInvalidCharacter, ""},
+	{actorrepo.ErrInvalidCredit, http.StatusBadRequest, CodeInvalidCreditType, ""},
+}
+
+func lookup(err error) (mapping, bool) {
+	for _, m := range mappings {
+		if errors.Is(err, m.err) {
+			return m, true
+		}
+	}
+	return mapping{}, false
+}
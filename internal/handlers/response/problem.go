@@ -0,0 +1,88 @@
+package response
+
+import (
+	"encoding/json"
+	"errors"
+	requestidmw "film_library/pkg/middlewares/requestid_mw"
+	"film_library/pkg/validation"
+	"log/slog"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const ProblemContentType = "application/problem+json"
+
+// Problem is an RFC 7807 error body. Code is stable and meant for clients to
+// branch on, Detail is for humans and may change.
+type Problem struct {
+	Type      string `json:"type"`
+	Title     string `json:"title"`
+	Status    int    `json:"status"`
+	Code      string `json:"code"`
+	Detail    string `json:"detail,omitempty"`
+	Instance  string `json:"instance,omitempty"`
+	RequestID string `json:"requestId,omitempty"`
+	// Errors lists the failed fields of a validation problem.
+	Errors validation.ValidateError `json:"errors,omitempty"`
+}
+
+// Error writes err as a problem. Errors without a mapping are reported as
+// internal errors, so their text never reaches the client.
+func Error(w http.ResponseWriter, r *http.Request, err error, log *slog.Logger) {
+	problem := NewProblem(err)
+	problem.Instance = r.URL.Path
+	problem.RequestID = requestidmw.ID(r.Context())
+
+	var retry interface{ RetryAfter() time.Duration }
+	if errors.As(err, &retry) {
+		w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retry.RetryAfter().Seconds()))))
+	}
+
+	WriteProblem(w, problem, log)
+}
+
+// NewProblem maps err to a problem without request specific fields.
+func NewProblem(err error) Problem {
+	var validateErr *validation.ValidateError
+	if errors.As(err, &validateErr) {
+		problem := newProblem(http.StatusBadRequest, CodeValidationFailed, "request validation failed")
+		problem.Errors = *validateErr
+		return problem
+	}
+
+	if m, ok := lookup(err); ok {
+		detail := m.detail
+		if detail == "" {
+			detail = m.err.Error()
+		}
+		return newProblem(m.status, m.code, detail)
+	}
+
+	return newProblem(http.StatusInternalServerError, CodeInternal, "internal error")
+}
+
+func WriteProblem(w http.ResponseWriter, problem Problem, log *slog.Logger) {
+	w.Header().Set("Content-Type", ProblemContentType)
+	w.WriteHeader(problem.Status)
+
+	b, err := json.Marshal(problem)
+	if err != nil {
+		log.Error(err.Error())
+	}
+
+	if _, err := w.Write(b); err != nil {
+		log.Error(err.Error())
+	}
+}
+
+func newProblem(status int, code, detail string) Problem {
+	return Problem{
+		Type:   "about:blank",
+		Title:  http.StatusText(status),
+		Status: status,
+		Code:   code,
+		Detail: detail,
+	}
+}
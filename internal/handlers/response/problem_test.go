@@ -0,0 +1,120 @@
+package response
+
+import (
+	"context"
+	"encoding/json"
+	"film_library/internal/repositories/postgres/actorrepo"
+	"film_library/internal/repositories/postgres/filmrepo"
+	"film_library/internal/services/filmservice"
+	"film_library/internal/services/userservice"
+	"film_library/pkg/validation"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestNewProblem(t *testing.T) {
+	tests := []struct {
+		name           string
+		err            error
+		expectedStatus int
+		expectedCode   string
+		expectedDetail string
+		expectedErrors string
+	}{
+		{
+			name:           "Wrapped film not found",
+			err:            fmt.Errorf("filmService.UpdateFilmRating: %w", filmrepo.ErrNotFound),
+			expectedStatus: http.StatusNotFound,
+			expectedCode:   CodeFilmNotFound,
+			expectedDetail: "film not found",
+		},
+		{
+			name:           "Actor not found",
+			err:            actorrepo.ErrNotFound,
+			expectedStatus: http.StatusNotFound,
+			expectedCode:   CodeActorNotFound,
+			expectedDetail: "actor not found",
+		},
+		{
+			name:           "Credentials are not disclosed",
+			err:            userservice.ErrNotFound,
+			expectedStatus: http.StatusUnauthorized,
+			expectedCode:   CodeInvalidCredentials,
+			expectedDetail: "invalid login or password",
+		},
+		{
+			name: "Wrapped validation error",
+			err: fmt.Errorf("filmService.UpdateFilmName: %w",
+				validation.Check("name", "", validation.Length(1, 150).Err(filmservice.ErrInvalidName))),
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   CodeValidationFailed,
+			expectedDetail: "request validation failed",
+			expectedErrors: `[{"field":"name","code":"length","message":"invalid film name"}]`,
+		},
+		{
+			name:           "Timeout",
+			err:            fmt.Errorf("filmRepository.GetFilms: %w", context.DeadlineExceeded),
+			expectedStatus: http.StatusGatewayTimeout,
+			expectedCode:   CodeTimeout,
+			expectedDetail: context.DeadlineExceeded.Error(),
+		},
+		{
+			name:           "Unknown error",
+			err:            fmt.Errorf("pq: connection refused"),
+			expectedStatus: http.StatusInternalServerError,
+			expectedCode:   CodeInternal,
+			expectedDetail: "internal error",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			problem := NewProblem(tc.err)
+
+			if problem.Status != tc.expectedStatus {
+				t.Errorf("expected: %d\ngot: %d", tc.expectedStatus, problem.Status)
+			}
+			if problem.Code != tc.expectedCode {
+				t.Errorf("expected: %s\ngot: %s", tc.expectedCode, problem.Code)
+			}
+			if problem.Detail != tc.expectedDetail {
+				t.Errorf("expected: %s\ngot: %s", tc.expectedDetail, problem.Detail)
+			}
+			if tc.expectedErrors != "" {
+				b, err := json.Marshal(problem.Errors)
+				if err != nil {
+					t.Fatalf("%s", err.Error())
+				}
+				if string(b) != tc.expectedErrors {
+					t.Errorf("expected: %s\ngot: %s", tc.expectedErrors, string(b))
+				}
+			}
+		})
+	}
+}
+
+func TestError(t *testing.T) {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/api/film/1", nil)
+
+	Error(w, req, filmrepo.ErrNotFound, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected: %d\ngot: %d", http.StatusNotFound, w.Code)
+	}
+	if got := w.Header().Get("Content-Type"); got != ProblemContentType {
+		t.Errorf("expected: %s\ngot: %s", ProblemContentType, got)
+	}
+
+	var problem Problem
+	if err := json.Unmarshal(w.Body.Bytes(), &problem); err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+	if problem.Instance != "/api/film/1" {
+		t.Errorf("expected: %s\ngot: %s", "/api/film/1", problem.Instance)
+	}
+}
@@ -0,0 +1,94 @@
+package reviewhandler
+
+import (
+	"context"
+	"film_library/internal/handlers/response"
+	"film_library/pkg/middlewares/auth"
+	"film_library/pkg/pagination"
+	"net/http"
+	"strconv"
+)
+
+const queryStatus = "status"
+
+// @Summary Get moderation queue
+// @Tags review
+// @Description get reviews with open reports, most reported first, or hidden reviews, most recently hidden first
+// @ID get-moderation-queue
+// @Accept  json
+// @Produce  json
+// @Param status query string false "queue" Enums(reported, hidden)
+// @Param page query integer false "page number"
+// @Param size query integer false "page size"
+// @Success 200 {object} []domains.ModeratedReview
+// @Failure 400 {object} response.Problem
+// @Failure 500 {object} response.Problem
+// @Security ApiKeyAuth
+// @Router /api/reviews/moderation [get]
+func (h *ReviewHandler) GetModerationQueue(w http.ResponseWriter, r *http.Request) {
+	reviews, err := h.service.GetModerationQueue(r.Context(), r.URL.Query().Get(queryStatus), pagination.NewFromRequest(r))
+	if err != nil {
+		response.Error(w, r, err, h.log)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, reviews, h.log)
+}
+
+// @Summary Hide review
+// @Tags review
+// @Description hide review from other users and resolve its reports
+// @ID hide-review
+// @Accept  json
+// @Produce  json
+// @Param id path integer true "review id"
+// @Success 200
+// @Failure 400 {object} response.Problem
+// @Failure 403 {object} response.Problem
+// @Failure 404 {object} response.Problem
+// @Failure 500 {object} response.Problem
+// @Security ApiKeyAuth
+// @Router /api/review/{id}/hide [put]
+func (h *ReviewHandler) HideReview(w http.ResponseWriter, r *http.Request) {
+	h.moderate(w, r, h.service.HideReview)
+}
+
+// @Summary Restore review
+// @Tags review
+// @Description make review visible again and dismiss its reports
+// @ID restore-review
+// @Accept  json
+// @Produce  json
+// @Param id path integer true "review id"
+// @Success 200
+// @Failure 400 {object} response.Problem
+// @Failure 403 {object} response.Problem
+// @Failure 404 {object} response.Problem
+// @Failure 500 {object} response.Problem
+// @Security ApiKeyAuth
+// @Router /api/review/{id}/restore [put]
+func (h *ReviewHandler) RestoreReview(w http.ResponseWriter, r *http.Request) {
+	h.moderate(w, r, h.service.RestoreReview)
+}
+
+func (h *ReviewHandler) moderate(w http.ResponseWriter, r *http.Request, action func(ctx context.Context, moderatorID, id uint32) error) {
+	principal, err := auth.CurrentUser(r)
+	if err != nil {
+		response.Error(w, r, err, h.log)
+		return
+	}
+
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		response.Error(w, r, response.ErrBadRequest, h.log)
+		return
+	}
+
+	err = action(r.Context(), principal.UserID, uint32(id))
+	if err != nil {
+		response.Error(w, r, err, h.log)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
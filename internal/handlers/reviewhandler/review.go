@@ -0,0 +1,404 @@
+package reviewhandler
+
+import (
+	"context"
+	"encoding/json"
+	"film_library/internal/domains"
+	"film_library/internal/handlers/response"
+	"film_library/pkg/middlewares/auth"
+	"film_library/pkg/pagination"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+type ReviewService interface {
+	CreateReview(ctx context.Context, userID, filmID uint32, text string) (uint32, error)
+	GetReview(ctx context.Context, viewer domains.Principal, id uint32) (*domains.Review, error)
+	GetFilmReviews(ctx context.Context, filter *pagination.ReviewFilter) ([]*domains.Review, error)
+	UpdateReview(ctx context.Context, userID, id uint32, text string) error
+	DeleteReview(ctx context.Context, userID, id uint32) error
+	VoteReview(ctx context.Context, userID, id uint32, helpful bool) error
+	DeleteReviewVote(ctx context.Context, userID, id uint32) error
+	ReportReview(ctx context.Context, userID, id uint32, reason string) error
+	GetModerationQueue(ctx context.Context, status string, page *pagination.Pagination) ([]*domains.ModeratedReview, error)
+	HideReview(ctx context.Context, moderatorID, id uint32) error
+	RestoreReview(ctx context.Context, moderatorID, id uint32) error
+}
+
+type ReviewHandler struct {
+	service ReviewService
+	log     *slog.Logger
+}
+
+func New(service ReviewService, log *slog.Logger) *ReviewHandler {
+	return &ReviewHandler{
+		service: service,
+		log:     log,
+	}
+}
+
+type InputReview struct {
+	Text string `json:"text"`
+}
+
+type InputVote struct {
+	Helpful *bool `json:"helpful"`
+}
+
+type InputReport struct {
+	Reason string `json:"reason"`
+}
+
+// @Summary Create review
+// @Tags review
+// @Description write a review of the film as the current user, one per film
+// @ID create-review
+// @Accept  json
+// @Produce  json
+// @Param id path integer true "film id"
+// @Param input body InputReview true "review text"
+// @Success 200 {object} integer
+// @Failure 400 {object} response.Problem
+// @Failure 403 {object} response.Problem
+// @Failure 404 {object} response.Problem
+// @Failure 409 {object} response.Problem
+// @Failure 500 {object} response.Problem
+// @Security ApiKeyAuth
+// @Router /api/film/{id}/review [post]
+func (h *ReviewHandler) CreateReview(w http.ResponseWriter, r *http.Request) {
+	principal, err := auth.CurrentUser(r)
+	if err != nil {
+		response.Error(w, r, err, h.log)
+		return
+	}
+
+	filmID, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		response.Error(w, r, response.ErrBadRequest, h.log)
+		return
+	}
+
+	b, err := io.ReadAll(r.Body)
+	if err != nil {
+		response.Error(w, r, err, h.log)
+		return
+	}
+	defer r.Body.Close()
+
+	input := InputReview{}
+	err = json.Unmarshal(b, &input)
+	if err != nil {
+		response.Error(w, r, response.ErrBadRequest, h.log)
+		return
+	}
+
+	id, err := h.service.CreateReview(r.Context(), principal.UserID, uint32(filmID), input.Text)
+	if err != nil {
+		response.Error(w, r, err, h.log)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, map[string]any{
+		"id": id,
+	}, h.log)
+}
+
+// @Summary Get film reviews
+// @Tags review
+// @Description get the visible reviews of the film
+// @ID get-film-reviews
+// @Accept  json
+// @Produce  json
+// @Param id path integer true "film id"
+// @Param sort query string false "sort order" Enums(newest, helpful)
+// @Param page query integer false "page number"
+// @Param size query integer false "page size"
+// @Success 200 {object} []domains.Review
+// @Failure 400 {object} response.Problem
+// @Failure 500 {object} response.Problem
+// @Security ApiKeyAuth
+// @Router /api/film/{id}/reviews [get]
+func (h *ReviewHandler) GetFilmReviews(w http.ResponseWriter, r *http.Request) {
+	filmID, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		response.Error(w, r, response.ErrBadRequest, h.log)
+		return
+	}
+
+	reviews, err := h.service.GetFilmReviews(r.Context(), pagination.NewReviewFilterFromRequest(r, uint32(filmID)))
+	if err != nil {
+		response.Error(w, r, err, h.log)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, reviews, h.log)
+}
+
+// @Summary Get review
+// @Tags review
+// @Description get review, hidden reviews are shown only to their author and moderators
+// @ID get-review
+// @Accept  json
+// @Produce  json
+// @Param id path integer true "review id"
+// @Success 200 {object} domains.Review
+// @Failure 400 {object} response.Problem
+// @Failure 404 {object} response.Problem
+// @Failure 500 {object} response.Problem
+// @Security ApiKeyAuth
+// @Router /api/review/{id} [get]
+func (h *ReviewHandler) GetReview(w http.ResponseWriter, r *http.Request) {
+	principal, ok := r.Context().Value(auth.UserKey("user")).(domains.Principal)
+	if !ok {
+		response.Error(w, r, response.ErrUnauthorized, h.log)
+		return
+	}
+
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		response.Error(w, r, response.ErrBadRequest, h.log)
+		return
+	}
+
+	review, err := h.service.GetReview(r.Context(), principal, uint32(id))
+	if err != nil {
+		response.Error(w, r, err, h.log)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, review, h.log)
+}
+
+// @Summary Update review
+// @Tags review
+// @Description change the text of a review written by the current user
+// @ID update-review
+// @Accept  json
+// @Produce  json
+// @Param id path integer true "review id"
+// @Param input body InputReview true "review text"
+// @Success 200
+// @Failure 400 {object} response.Problem
+// @Failure 403 {object} response.Problem
+// @Failure 404 {object} response.Problem
+// @Failure 500 {object} response.Problem
+// @Security ApiKeyAuth
+// @Router /api/review/{id} [put]
+func (h *ReviewHandler) UpdateReview(w http.ResponseWriter, r *http.Request) {
+	principal, err := auth.CurrentUser(r)
+	if err != nil {
+		response.Error(w, r, err, h.log)
+		return
+	}
+
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		response.Error(w, r, response.ErrBadRequest, h.log)
+		return
+	}
+
+	b, err := io.ReadAll(r.Body)
+	if err != nil {
+		response.Error(w, r, err, h.log)
+		return
+	}
+	defer r.Body.Close()
+
+	input := InputReview{}
+	err = json.Unmarshal(b, &input)
+	if err != nil {
+		response.Error(w, r, response.ErrBadRequest, h.log)
+		return
+	}
+
+	err = h.service.UpdateReview(r.Context(), principal.UserID, uint32(id), input.Text)
+	if err != nil {
+		response.Error(w, r, err, h.log)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// @Summary Delete review
+// @Tags review
+// @Description delete a review written by the current user
+// @ID delete-review
+// @Accept  json
+// @Produce  json
+// @Param id path integer true "review id"
+// @Success 200
+// @Failure 400 {object} response.Problem
+// @Failure 403 {object} response.Problem
+// @Failure 404 {object} response.Problem
+// @Failure 500 {object} response.Problem
+// @Security ApiKeyAuth
+// @Router /api/review/{id} [delete]
+func (h *ReviewHandler) DeleteReview(w http.ResponseWriter, r *http.Request) {
+	principal, err := auth.CurrentUser(r)
+	if err != nil {
+		response.Error(w, r, err, h.log)
+		return
+	}
+
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		response.Error(w, r, response.ErrBadRequest, h.log)
+		return
+	}
+
+	err = h.service.DeleteReview(r.Context(), principal.UserID, uint32(id))
+	if err != nil {
+		response.Error(w, r, err, h.log)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// @Summary Vote on review
+// @Tags review
+// @Description mark a review of another user as helpful or unhelpful, a new vote replaces the previous one
+// @ID vote-review
+// @Accept  json
+// @Produce  json
+// @Param id path integer true "review id"
+// @Param input body InputVote true "vote"
+// @Success 200
+// @Failure 400 {object} response.Problem
+// @Failure 403 {object} response.Problem
+// @Failure 404 {object} response.Problem
+// @Failure 500 {object} response.Problem
+// @Security ApiKeyAuth
+// @Router /api/review/{id}/vote [put]
+func (h *ReviewHandler) VoteReview(w http.ResponseWriter, r *http.Request) {
+	principal, err := auth.CurrentUser(r)
+	if err != nil {
+		response.Error(w, r, err, h.log)
+		return
+	}
+
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		response.Error(w, r, response.ErrBadRequest, h.log)
+		return
+	}
+
+	b, err := io.ReadAll(r.Body)
+	if err != nil {
+		response.Error(w, r, err, h.log)
+		return
+	}
+	defer r.Body.Close()
+
+	input := InputVote{}
+	err = json.Unmarshal(b, &input)
+	if err != nil {
+		response.Error(w, r, response.ErrBadRequest, h.log)
+		return
+	}
+	if input.Helpful == nil {
+		response.Error(w, r, response.ErrBadRequest, h.log)
+		return
+	}
+
+	err = h.service.VoteReview(r.Context(), principal.UserID, uint32(id), *input.Helpful)
+	if err != nil {
+		response.Error(w, r, err, h.log)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// @Summary Delete review vote
+// @Tags review
+// @Description withdraw the vote of the current user on a review
+// @ID delete-review-vote
+// @Accept  json
+// @Produce  json
+// @Param id path integer true "review id"
+// @Success 200
+// @Failure 400 {object} response.Problem
+// @Failure 403 {object} response.Problem
+// @Failure 404 {object} response.Problem
+// @Failure 500 {object} response.Problem
+// @Security ApiKeyAuth
+// @Router /api/review/{id}/vote [delete]
+func (h *ReviewHandler) DeleteReviewVote(w http.ResponseWriter, r *http.Request) {
+	principal, err := auth.CurrentUser(r)
+	if err != nil {
+		response.Error(w, r, err, h.log)
+		return
+	}
+
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		response.Error(w, r, response.ErrBadRequest, h.log)
+		return
+	}
+
+	err = h.service.DeleteReviewVote(r.Context(), principal.UserID, uint32(id))
+	if err != nil {
+		response.Error(w, r, err, h.log)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// @Summary Report review
+// @Tags review
+// @Description report an abusive review of another user to the moderators
+// @ID report-review
+// @Accept  json
+// @Produce  json
+// @Param id path integer true "review id"
+// @Param input body InputReport false "report reason"
+// @Success 200
+// @Failure 400 {object} response.Problem
+// @Failure 403 {object} response.Problem
+// @Failure 404 {object} response.Problem
+// @Failure 409 {object} response.Problem
+// @Failure 500 {object} response.Problem
+// @Security ApiKeyAuth
+// @Router /api/review/{id}/report [post]
+func (h *ReviewHandler) ReportReview(w http.ResponseWriter, r *http.Request) {
+	principal, err := auth.CurrentUser(r)
+	if err != nil {
+		response.Error(w, r, err, h.log)
+		return
+	}
+
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		response.Error(w, r, response.ErrBadRequest, h.log)
+		return
+	}
+
+	b, err := io.ReadAll(r.Body)
+	if err != nil {
+		response.Error(w, r, err, h.log)
+		return
+	}
+	defer r.Body.Close()
+
+	// The reason is optional, so is the body.
+	input := InputReport{}
+	if len(b) != 0 {
+		if err := json.Unmarshal(b, &input); err != nil {
+			response.Error(w, r, response.ErrBadRequest, h.log)
+			return
+		}
+	}
+
+	err = h.service.ReportReview(r.Context(), principal.UserID, uint32(id), input.Reason)
+	if err != nil {
+		response.Error(w, r, err, h.log)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
@@ -0,0 +1,232 @@
+package reviewhandler
+
+import (
+	"bytes"
+	"context"
+	"film_library/internal/domains"
+	"film_library/internal/repositories/postgres/reviewrepo"
+	mock_services "film_library/internal/services/mocks"
+	"film_library/internal/services/reviewservice"
+	"film_library/pkg/middlewares/auth"
+	"film_library/pkg/mux"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestReviewHandlerCreateReview(t *testing.T) {
+	type mockBehavior func(r *mock_services.MockReviewService)
+
+	tests := []struct {
+		name                 string
+		principal            domains.Principal
+		inputBody            string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:      "Correct",
+			principal: domains.Principal{UserID: 2},
+			inputBody: `{"text":"Great film"}`,
+			mockBehavior: func(r *mock_services.MockReviewService) {
+				r.EXPECT().CreateReview(gomock.Any(), uint32(2), uint32(1), "Great film").Return(uint32(5), nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"id":5}`,
+		},
+		{
+			name:      "Already reviewed",
+			principal: domains.Principal{UserID: 2},
+			inputBody: `{"text":"Great film"}`,
+			mockBehavior: func(r *mock_services.MockReviewService) {
+				r.EXPECT().CreateReview(gomock.Any(), uint32(2), uint32(1), "Great film").Return(uint32(0), reviewrepo.ErrAlreadyExists)
+			},
+			expectedStatusCode:   http.StatusConflict,
+			expectedResponseBody: `{"type":"about:blank","title":"Conflict","status":409,"code":"review_already_exists","detail":"film already reviewed by the user","instance":"/api/film/1/review"}`,
+		},
+		{
+			name:                 "API key",
+			principal:            domains.Principal{APIKeyID: 3},
+			inputBody:            `{"text":"Great film"}`,
+			mockBehavior:         func(r *mock_services.MockReviewService) {},
+			expectedStatusCode:   http.StatusForbidden,
+			expectedResponseBody: `{"type":"about:blank","title":"Forbidden","status":403,"code":"forbidden","detail":"forbidden","instance":"/api/film/1/review"}`,
+		},
+		{
+			name:                 "Invalid body",
+			principal:            domains.Principal{UserID: 2},
+			inputBody:            `{"text":`,
+			mockBehavior:         func(r *mock_services.MockReviewService) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"type":"about:blank","title":"Bad Request","status":400,"code":"bad_request","detail":"bad request","instance":"/api/film/1/review"}`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			service := mock_services.NewMockReviewService(c)
+			handler := ReviewHandler{service: service}
+			tc.mockBehavior(service)
+
+			r := mux.New()
+			r.HandleFunc("POST /api/film/{id}/review", handler.CreateReview)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/api/film/1/review", bytes.NewBufferString(tc.inputBody))
+			req = req.WithContext(context.WithValue(req.Context(), auth.UserKey("user"), tc.principal))
+
+			r.ServeHTTP(w, req)
+
+			if tc.expectedStatusCode != w.Code {
+				t.Errorf("expected: %d\ngot: %d", tc.expectedStatusCode, w.Code)
+			}
+
+			if tc.expectedResponseBody != w.Body.String() {
+				t.Errorf("expected: %s\ngot: %s", tc.expectedResponseBody, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestReviewHandlerVoteReview(t *testing.T) {
+	type mockBehavior func(r *mock_services.MockReviewService)
+
+	tests := []struct {
+		name                 string
+		inputBody            string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:      "Helpful",
+			inputBody: `{"helpful":true}`,
+			mockBehavior: func(r *mock_services.MockReviewService) {
+				r.EXPECT().VoteReview(gomock.Any(), uint32(2), uint32(7), true).Return(nil)
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:      "Unhelpful",
+			inputBody: `{"helpful":false}`,
+			mockBehavior: func(r *mock_services.MockReviewService) {
+				r.EXPECT().VoteReview(gomock.Any(), uint32(2), uint32(7), false).Return(nil)
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:      "Own review",
+			inputBody: `{"helpful":true}`,
+			mockBehavior: func(r *mock_services.MockReviewService) {
+				r.EXPECT().VoteReview(gomock.Any(), uint32(2), uint32(7), true).Return(reviewservice.ErrOwnReview)
+			},
+			expectedStatusCode:   http.StatusForbidden,
+			expectedResponseBody: `{"type":"about:blank","title":"Forbidden","status":403,"code":"own_review","detail":"users cannot vote on or report their own reviews","instance":"/api/review/7/vote"}`,
+		},
+		{
+			name:                 "Missing vote",
+			inputBody:            `{}`,
+			mockBehavior:         func(r *mock_services.MockReviewService) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"type":"about:blank","title":"Bad Request","status":400,"code":"bad_request","detail":"bad request","instance":"/api/review/7/vote"}`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			service := mock_services.NewMockReviewService(c)
+			handler := ReviewHandler{service: service}
+			tc.mockBehavior(service)
+
+			r := mux.New()
+			r.HandleFunc("PUT /api/review/{id}/vote", handler.VoteReview)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPut, "/api/review/7/vote", bytes.NewBufferString(tc.inputBody))
+			req = req.WithContext(context.WithValue(req.Context(), auth.UserKey("user"), domains.Principal{UserID: 2}))
+
+			r.ServeHTTP(w, req)
+
+			if tc.expectedStatusCode != w.Code {
+				t.Errorf("expected: %d\ngot: %d", tc.expectedStatusCode, w.Code)
+			}
+
+			if tc.expectedResponseBody != w.Body.String() {
+				t.Errorf("expected: %s\ngot: %s", tc.expectedResponseBody, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestReviewHandlerGetModerationQueue(t *testing.T) {
+	type mockBehavior func(r *mock_services.MockReviewService)
+
+	tests := []struct {
+		name                 string
+		url                  string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name: "Reported",
+			url:  "/api/reviews/moderation?status=reported&page=1&size=1",
+			mockBehavior: func(r *mock_services.MockReviewService) {
+				r.EXPECT().GetModerationQueue(gomock.Any(), domains.ModerationReported, gomock.Any()).
+					Return([]*domains.ModeratedReview{{
+						Review:  domains.Review{ID: 7, FilmID: 1, AuthorID: 3, AuthorName: "User", Text: "Spam"},
+						Reports: 2,
+						Reasons: []string{"spam"},
+					}}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponseBody: `[{"id":7,"filmId":1,"authorId":3,"authorName":"User","text":"Spam","helpful":0,"unhelpful":0,` +
+				`"hidden":false,"createdAt":"0001-01-01T00:00:00Z","reports":2,"reasons":["spam"]}]`,
+		},
+		{
+			name: "Invalid status",
+			url:  "/api/reviews/moderation?status=all",
+			mockBehavior: func(r *mock_services.MockReviewService) {
+				r.EXPECT().GetModerationQueue(gomock.Any(), "all", gomock.Any()).Return(nil, reviewservice.ErrInvalidModerationStatus)
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"type":"about:blank","title":"Bad Request","status":400,"code":"invalid_moderation_status","detail":"moderation status must be reported or hidden","instance":"/api/reviews/moderation"}`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			service := mock_services.NewMockReviewService(c)
+			handler := ReviewHandler{service: service}
+			tc.mockBehavior(service)
+
+			r := mux.New()
+			r.HandleFunc("GET /api/reviews/moderation", handler.GetModerationQueue)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, tc.url, nil)
+
+			r.ServeHTTP(w, req)
+
+			if tc.expectedStatusCode != w.Code {
+				t.Errorf("expected: %d\ngot: %d", tc.expectedStatusCode, w.Code)
+			}
+
+			if tc.expectedResponseBody != w.Body.String() {
+				t.Errorf("expected: %s\ngot: %s", tc.expectedResponseBody, w.Body.String())
+			}
+		})
+	}
+}
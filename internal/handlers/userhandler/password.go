@@ -0,0 +1,137 @@
+package userhandler
+
+import (
+	"encoding/json"
+	"film_library/internal/handlers/response"
+	"film_library/pkg/middlewares/auth"
+	"io"
+	"net/http"
+	"strconv"
+)
+
+type InputChangePassword struct {
+	CurrentPassword string `json:"currentPassword"`
+	NewPassword     string `json:"newPassword"`
+}
+
+type InputResetPassword struct {
+	Token       string `json:"token"`
+	NewPassword string `json:"newPassword"`
+}
+
+// @Summary Change password
+// @Tags user
+// @Description change the password of the current user, all sessions of the user are ended
+// @ID change-password
+// @Accept  json
+// @Produce  json
+// @Param input body InputChangePassword true "current and new password"
+// @Success 200
+// @Failure 400 {object} response.Problem
+// @Failure 401 {object} response.Problem
+// @Failure 403 {object} response.Problem
+// @Failure 429 {object} response.Problem
+// @Failure 500 {object} response.Problem
+// @Security ApiKeyAuth
+// @Router /api/me/password [put]
+func (h *UserHandler) ChangePassword(w http.ResponseWriter, r *http.Request) {
+	principal, err := auth.CurrentUser(r)
+	if err != nil {
+		response.Error(w, r, err, h.log)
+		return
+	}
+
+	b, err := io.ReadAll(r.Body)
+	if err != nil {
+		response.Error(w, r, err, h.log)
+		return
+	}
+	defer r.Body.Close()
+
+	var input InputChangePassword
+	err = json.Unmarshal(b, &input)
+	if err != nil {
+		response.Error(w, r, response.ErrBadRequest, h.log)
+		return
+	}
+
+	err = h.service.ChangePassword(r.Context(), principal.UserID, input.CurrentPassword, input.NewPassword)
+	if err != nil {
+		response.Error(w, r, err, h.log)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// @Summary Issue password reset
+// @Tags user
+// @Description issue a one-time password reset token for another user whose permissions the caller holds, earlier unused tokens stop working
+// @ID issue-password-reset
+// @Accept  json
+// @Produce  json
+// @Param id path integer true "user id"
+// @Success 200 {object} domains.PasswordReset
+// @Failure 400 {object} response.Problem
+// @Failure 403 {object} response.Problem
+// @Failure 404 {object} response.Problem
+// @Failure 500 {object} response.Problem
+// @Security ApiKeyAuth
+// @Router /api/user/password-reset/{id} [post]
+func (h *UserHandler) IssuePasswordReset(w http.ResponseWriter, r *http.Request) {
+	// The token takes over the account, so API keys never get one.
+	principal, err := auth.CurrentUser(r)
+	if err != nil {
+		response.Error(w, r, err, h.log)
+		return
+	}
+
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		response.Error(w, r, response.ErrBadRequest, h.log)
+		return
+	}
+
+	reset, err := h.service.IssuePasswordReset(r.Context(), principal, uint32(id))
+	if err != nil {
+		response.Error(w, r, err, h.log)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, reset, h.log)
+}
+
+// @Summary Reset password
+// @Tags user
+// @Description set a new password with a reset token, all sessions of the user are ended
+// @ID reset-password
+// @Accept  json
+// @Produce  json
+// @Param input body InputResetPassword true "reset token and new password"
+// @Success 200
+// @Failure 400 {object} response.Problem
+// @Failure 500 {object} response.Problem
+// @Router /api/password/reset [post]
+func (h *UserHandler) ResetPassword(w http.ResponseWriter, r *http.Request) {
+	b, err := io.ReadAll(r.Body)
+	if err != nil {
+		response.Error(w, r, err, h.log)
+		return
+	}
+	defer r.Body.Close()
+
+	var input InputResetPassword
+	err = json.Unmarshal(b, &input)
+	if err != nil || input.Token == "" {
+		response.Error(w, r, response.ErrBadRequest, h.log)
+		return
+	}
+
+	err = h.service.ResetPassword(r.Context(), input.Token, input.NewPassword)
+	if err != nil {
+		response.Error(w, r, err, h.log)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
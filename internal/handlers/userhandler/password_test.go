@@ -0,0 +1,166 @@
+package userhandler
+
+import (
+	"bytes"
+	"context"
+	"film_library/internal/domains"
+	mock_services "film_library/internal/services/mocks"
+	userservice "film_library/internal/services/userservice"
+	"film_library/pkg/middlewares/auth"
+	"film_library/pkg/mux"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestUserHandlerChangePassword(t *testing.T) {
+	type mockBehavior func(r *mock_services.MockUserService)
+
+	user := domains.Principal{UserID: 2, Login: "user", Role: domains.RoleViewer}
+	apiKey := domains.Principal{APIKeyID: 3, Role: domains.RoleViewer}
+
+	tests := []struct {
+		name                 string
+		principal            domains.Principal
+		inputBody            string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:      "Correct",
+			principal: user,
+			inputBody: `{"currentPassword":"old-Secret1","newPassword":"new-Secret1"}`,
+			mockBehavior: func(r *mock_services.MockUserService) {
+				r.EXPECT().ChangePassword(gomock.Any(), uint32(2), "old-Secret1", "new-Secret1").Return(nil)
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:      "Wrong current password",
+			principal: user,
+			inputBody: `{"currentPassword":"guess","newPassword":"new-Secret1"}`,
+			mockBehavior: func(r *mock_services.MockUserService) {
+				r.EXPECT().ChangePassword(gomock.Any(), uint32(2), "guess", "new-Secret1").Return(userservice.ErrWrongPassword)
+			},
+			expectedStatusCode:   http.StatusForbidden,
+			expectedResponseBody: `{"type":"about:blank","title":"Forbidden","status":403,"code":"invalid_current_password","detail":"current password is wrong","instance":"/api/me/password"}`,
+		},
+		{
+			name:                 "API key",
+			principal:            apiKey,
+			inputBody:            `{"currentPassword":"old-Secret1","newPassword":"new-Secret1"}`,
+			mockBehavior:         func(r *mock_services.MockUserService) {},
+			expectedStatusCode:   http.StatusForbidden,
+			expectedResponseBody: `{"type":"about:blank","title":"Forbidden","status":403,"code":"forbidden","detail":"forbidden","instance":"/api/me/password"}`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			service := mock_services.NewMockUserService(c)
+			handler := UserHandler{service: service}
+			tc.mockBehavior(service)
+
+			r := mux.New()
+			r.HandleFunc("PUT /api/me/password", handler.ChangePassword)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPut, "/api/me/password", bytes.NewBufferString(tc.inputBody))
+			req = req.WithContext(context.WithValue(req.Context(), auth.UserKey("user"), tc.principal))
+
+			r.ServeHTTP(w, req)
+
+			if tc.expectedStatusCode != w.Code {
+				t.Errorf("expected: %d\ngot: %d", tc.expectedStatusCode, w.Code)
+			}
+
+			if tc.expectedResponseBody != w.Body.String() {
+				t.Errorf("expected: %s\ngot: %s", tc.expectedResponseBody, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestUserHandlerIssuePasswordReset(t *testing.T) {
+	type mockBehavior func(r *mock_services.MockUserService, caller domains.Principal)
+
+	admin := domains.Principal{UserID: 1, Login: "admin", Role: domains.RoleAdmin, Permissions: []domains.Permission{domains.PermUserManage}}
+	apiKey := domains.Principal{APIKeyID: 3, Permissions: []domains.Permission{domains.PermUserManage}}
+	expiresAt := time.Date(2024, 3, 16, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name                 string
+		principal            domains.Principal
+		path                 string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:      "Correct",
+			principal: admin,
+			path:      "/api/user/password-reset/2",
+			mockBehavior: func(r *mock_services.MockUserService, caller domains.Principal) {
+				r.EXPECT().IssuePasswordReset(gomock.Any(), caller, uint32(2)).
+					Return(&domains.PasswordReset{Token: "reset", ExpiresAt: expiresAt}, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"token":"reset","expiresAt":"2024-03-16T12:00:00Z"}`,
+		},
+		{
+			name:      "Privileged target",
+			principal: admin,
+			path:      "/api/user/password-reset/4",
+			mockBehavior: func(r *mock_services.MockUserService, caller domains.Principal) {
+				r.EXPECT().IssuePasswordReset(gomock.Any(), caller, uint32(4)).
+					Return(nil, fmt.Errorf("issue: %w", userservice.ErrTargetPrivileged))
+			},
+			expectedStatusCode:   http.StatusForbidden,
+			expectedResponseBody: `{"type":"about:blank","title":"Forbidden","status":403,"code":"target_privileged","detail":"user holds permissions the caller does not","instance":"/api/user/password-reset/4"}`,
+		},
+		{
+			name:                 "API key",
+			principal:            apiKey,
+			path:                 "/api/user/password-reset/2",
+			mockBehavior:         func(r *mock_services.MockUserService, caller domains.Principal) {},
+			expectedStatusCode:   http.StatusForbidden,
+			expectedResponseBody: `{"type":"about:blank","title":"Forbidden","status":403,"code":"forbidden","detail":"forbidden","instance":"/api/user/password-reset/2"}`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			service := mock_services.NewMockUserService(c)
+			handler := UserHandler{service: service}
+			tc.mockBehavior(service, tc.principal)
+
+			r := mux.New()
+			r.HandleFunc("POST /api/user/password-reset/{id}", handler.IssuePasswordReset)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, tc.path, nil)
+			req = req.WithContext(context.WithValue(req.Context(), auth.UserKey("user"), tc.principal))
+
+			r.ServeHTTP(w, req)
+
+			if tc.expectedStatusCode != w.Code {
+				t.Errorf("expected: %d\ngot: %d", tc.expectedStatusCode, w.Code)
+			}
+
+			if tc.expectedResponseBody != w.Body.String() {
+				t.Errorf("expected: %s\ngot: %s", tc.expectedResponseBody, w.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,135 @@
+package userhandler
+
+import (
+	"encoding/json"
+	"film_library/internal/domains"
+	"film_library/internal/handlers/response"
+	"film_library/pkg/middlewares/auth"
+	"io"
+	"net/http"
+)
+
+type InputProfile struct {
+	DisplayName string `json:"displayName"`
+}
+
+type InputDeleteAccount struct {
+	Password string `json:"password"`
+}
+
+// @Summary Get current user
+// @Tags user
+// @Description get the authenticated caller with the profile of the user, API keys have no profile
+// @ID get-me
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} domains.Profile
+// @Failure 401 {object} response.Problem
+// @Failure 500 {object} response.Problem
+// @Security ApiKeyAuth
+// @Router /api/me [get]
+func (h *UserHandler) GetMe(w http.ResponseWriter, r *http.Request) {
+	principal, ok := r.Context().Value(auth.UserKey("user")).(domains.Principal)
+	if !ok {
+		response.Error(w, r, response.ErrUnauthorized, h.log)
+		return
+	}
+
+	profile, err := h.service.GetProfile(r.Context(), principal)
+	if err != nil {
+		response.Error(w, r, err, h.log)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, profile, h.log)
+}
+
+// @Summary Update current user
+// @Tags user
+// @Description update the display name of the current user
+// @ID update-me
+// @Accept  json
+// @Produce  json
+// @Param input body InputProfile true "profile"
+// @Success 200
+// @Failure 400 {object} response.Problem
+// @Failure 401 {object} response.Problem
+// @Failure 403 {object} response.Problem
+// @Failure 500 {object} response.Problem
+// @Security ApiKeyAuth
+// @Router /api/me [put]
+func (h *UserHandler) UpdateMe(w http.ResponseWriter, r *http.Request) {
+	principal, err := auth.CurrentUser(r)
+	if err != nil {
+		response.Error(w, r, err, h.log)
+		return
+	}
+
+	b, err := io.ReadAll(r.Body)
+	if err != nil {
+		response.Error(w, r, err, h.log)
+		return
+	}
+	defer r.Body.Close()
+
+	var input InputProfile
+	err = json.Unmarshal(b, &input)
+	if err != nil {
+		response.Error(w, r, response.ErrBadRequest, h.log)
+		return
+	}
+
+	err = h.service.UpdateDisplayName(r.Context(), principal.UserID, input.DisplayName)
+	if err != nil {
+		response.Error(w, r, err, h.log)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// @Summary Delete current user
+// @Tags user
+// @Description delete the account of the current user together with its sessions, API keys and personal data
+// @ID delete-me
+// @Accept  json
+// @Produce  json
+// @Param input body InputDeleteAccount true "password of the account"
+// @Success 200
+// @Failure 400 {object} response.Problem
+// @Failure 401 {object} response.Problem
+// @Failure 403 {object} response.Problem
+// @Failure 429 {object} response.Problem
+// @Failure 409 {object} response.Problem
+// @Failure 500 {object} response.Problem
+// @Security ApiKeyAuth
+// @Router /api/me [delete]
+func (h *UserHandler) DeleteMe(w http.ResponseWriter, r *http.Request) {
+	principal, err := auth.CurrentUser(r)
+	if err != nil {
+		response.Error(w, r, err, h.log)
+		return
+	}
+
+	b, err := io.ReadAll(r.Body)
+	if err != nil {
+		response.Error(w, r, err, h.log)
+		return
+	}
+	defer r.Body.Close()
+
+	var input InputDeleteAccount
+	err = json.Unmarshal(b, &input)
+	if err != nil {
+		response.Error(w, r, response.ErrBadRequest, h.log)
+		return
+	}
+
+	err = h.service.DeleteAccount(r.Context(), principal.UserID, input.Password)
+	if err != nil {
+		response.Error(w, r, err, h.log)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
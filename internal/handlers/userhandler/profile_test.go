@@ -0,0 +1,77 @@
+package userhandler
+
+import (
+	"context"
+	"film_library/internal/domains"
+	mock_services "film_library/internal/services/mocks"
+	"film_library/pkg/middlewares/auth"
+	"film_library/pkg/mux"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestUserHandlerGetMe(t *testing.T) {
+	type mockBehavior func(r *mock_services.MockUserService, principal domains.Principal)
+
+	createdAt := time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name                 string
+		principal            domains.Principal
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:      "User",
+			principal: domains.Principal{UserID: 2, Login: "user", Role: domains.RoleViewer, Permissions: []domains.Permission{}},
+			mockBehavior: func(r *mock_services.MockUserService, principal domains.Principal) {
+				r.EXPECT().GetProfile(gomock.Any(), principal).
+					Return(&domains.Profile{Principal: principal, DisplayName: "User", CreatedAt: &createdAt}, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"id":2,"login":"user","role":"viewer","permissions":[],"displayName":"User","createdAt":"2024-03-15T12:00:00Z"}`,
+		},
+		{
+			name:      "API key",
+			principal: domains.Principal{APIKeyID: 3, Role: domains.RoleViewer, Permissions: []domains.Permission{domains.PermFilmWrite}},
+			mockBehavior: func(r *mock_services.MockUserService, principal domains.Principal) {
+				r.EXPECT().GetProfile(gomock.Any(), principal).Return(&domains.Profile{Principal: principal}, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"role":"viewer","apiKeyId":3,"permissions":["film:write"]}`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			service := mock_services.NewMockUserService(c)
+			handler := UserHandler{service: service}
+			tc.mockBehavior(service, tc.principal)
+
+			r := mux.New()
+			r.HandleFunc("GET /api/me", handler.GetMe)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/api/me", nil)
+			req = req.WithContext(context.WithValue(req.Context(), auth.UserKey("user"), tc.principal))
+
+			r.ServeHTTP(w, req)
+
+			if tc.expectedStatusCode != w.Code {
+				t.Errorf("expected: %d\ngot: %d", tc.expectedStatusCode, w.Code)
+			}
+
+			if tc.expectedResponseBody != w.Body.String() {
+				t.Errorf("expected: %s\ngot: %s", tc.expectedResponseBody, w.Body.String())
+			}
+		})
+	}
+}
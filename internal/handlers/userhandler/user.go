@@ -1,21 +1,35 @@
 package userhandler
 
 import (
+	"context"
 	"encoding/json"
-	"errors"
 	"film_library/internal/domains"
 	"film_library/internal/handlers/response"
-	"film_library/internal/repositories/postgres/userrepo"
-	"film_library/internal/services/userservice"
-	"film_library/pkg/validation"
+	"film_library/internal/tokens"
+	"film_library/pkg/middlewares/auth"
+	"film_library/pkg/mux"
+	"film_library/pkg/pagination"
 	"io"
 	"log/slog"
 	"net/http"
+	"strconv"
 )
 
 type UserService interface {
-	CreateUser(user domains.User) (string, error)
-	Login(login, password string) (string, error)
+	CreateUser(ctx context.Context, user domains.User) (*domains.Tokens, error)
+	Login(ctx context.Context, login, password, ip string) (*domains.Tokens, error)
+	RefreshTokens(ctx context.Context, refreshToken string) (*domains.Tokens, error)
+	Logout(ctx context.Context, claims *tokens.Claims, refreshToken string) error
+	GetUsers(ctx context.Context, page *pagination.Pagination) ([]*domains.User, error)
+	UpdateUserRole(ctx context.Context, id uint32, role domains.Role) error
+	SetUserDisabled(ctx context.Context, id uint32, disabled bool) error
+	DeleteUser(ctx context.Context, id uint32) error
+	ChangePassword(ctx context.Context, id uint32, currentPassword, newPassword string) error
+	IssuePasswordReset(ctx context.Context, caller domains.Principal, id uint32) (*domains.PasswordReset, error)
+	ResetPassword(ctx context.Context, resetToken, newPassword string) error
+	GetProfile(ctx context.Context, principal domains.Principal) (*domains.Profile, error)
+	UpdateDisplayName(ctx context.Context, id uint32, displayName string) error
+	DeleteAccount(ctx context.Context, id uint32, password string) error
 }
 
 type UserHandler struct {
@@ -30,51 +44,45 @@ func New(service UserService, log *slog.Logger) *UserHandler {
 	}
 }
 
+type InputCredentials struct {
+	Login    string `json:"login"`
+	Password string `json:"password"`
+}
+
 // @Summary Create user
 // @Tags user
-// @Description create user
+// @Description create user with the viewer role
 // @ID create-user
 // @Accept  json
 // @Produce  json
-// @Param input body domains.User true "user info"
-// @Success 200 {object} string ""
-// @Failure 400 {object} response.ErrorReponse
-// @Failure 400 {object} response.ErrorsReponse
-// @Failure 409 {object} response.ErrorReponse
-// @Failure 500 {object} response.ErrorReponse
+// @Param input body InputCredentials true "user info"
+// @Success 200 {object} domains.Tokens
+// @Failure 400 {object} response.Problem
+// @Failure 409 {object} response.Problem
+// @Failure 500 {object} response.Problem
 // @Router /api/register [post]
 func (h *UserHandler) Register(w http.ResponseWriter, r *http.Request) {
 	b, err := io.ReadAll(r.Body)
 	if err != nil {
-		response.JSONError(w, http.StatusInternalServerError, "unknown error", h.log)
+		response.Error(w, r, err, h.log)
 		return
 	}
 	defer r.Body.Close()
 
-	var user domains.User
-	err = json.Unmarshal(b, &user)
+	var input InputCredentials
+	err = json.Unmarshal(b, &input)
 	if err != nil {
-		response.JSONError(w, http.StatusBadRequest, "bad request", h.log)
+		response.Error(w, r, response.ErrBadRequest, h.log)
 		return
 	}
 
-	token, err := h.service.CreateUser(user)
+	pair, err := h.service.CreateUser(r.Context(), domains.User{Login: input.Login, Password: input.Password})
 	if err != nil {
-		if err, ok := err.(*validation.ValidateError); ok {
-			response.JSONErrors(w, http.StatusBadRequest, err.ToArrayErrors(), h.log)
-			return
-		}
-		if errors.Is(err, userrepo.ErrAlreadyExists) {
-			response.JSONError(w, http.StatusConflict, "user already exists", h.log)
-			return
-		}
-		response.JSONError(w, http.StatusInternalServerError, "unknown error", h.log)
+		response.Error(w, r, err, h.log)
 		return
 	}
 
-	response.JSON(w, http.StatusOK, map[string]any{
-		"token": token,
-	}, h.log)
+	response.JSON(w, http.StatusOK, pair, h.log)
 }
 
 // @Summary Login user
@@ -83,38 +91,263 @@ func (h *UserHandler) Register(w http.ResponseWriter, r *http.Request) {
 // @ID login
 // @Accept  json
 // @Produce  json
-// @Param input body domains.User true "user info"
-// @Success 200 {object} string ""
-// @Failure 400 {object} response.ErrorReponse
-// @Failure 401 {object} response.ErrorReponse
-// @Failure 500 {object} response.ErrorReponse
+// @Param input body InputCredentials true "user info"
+// @Success 200 {object} domains.Tokens
+// @Failure 400 {object} response.Problem
+// @Failure 401 {object} response.Problem
+// @Failure 403 {object} response.Problem
+// @Failure 429 {object} response.Problem
+// @Header 429 {integer} Retry-After "seconds until the next attempt is allowed"
+// @Failure 500 {object} response.Problem
 // @Router /api/login [post]
 func (h *UserHandler) Login(w http.ResponseWriter, r *http.Request) {
 	b, err := io.ReadAll(r.Body)
 	if err != nil {
-		response.JSONError(w, http.StatusInternalServerError, "unknown error kurwa", h.log)
+		response.Error(w, r, err, h.log)
 		return
 	}
 	defer r.Body.Close()
 
-	var user domains.User
-	err = json.Unmarshal(b, &user)
+	var input InputCredentials
+	err = json.Unmarshal(b, &input)
 	if err != nil {
-		response.JSONError(w, http.StatusBadRequest, "bad request", h.log)
+		response.Error(w, r, response.ErrBadRequest, h.log)
 		return
 	}
 
-	token, err := h.service.Login(user.Login, user.Password)
+	pair, err := h.service.Login(r.Context(), input.Login, input.Password, mux.ClientIP(r))
 	if err != nil {
-		if errors.Is(err, userservice.ErrNotFound) || errors.Is(err, userservice.ErrInvalidPassword) {
-			response.JSONError(w, http.StatusUnauthorized, "invalid login or password", h.log)
+		response.Error(w, r, err, h.log)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, pair, h.log)
+}
+
+type InputRefreshToken struct {
+	RefreshToken string `json:"refreshToken"`
+}
+
+// @Summary Refresh tokens
+// @Tags user
+// @Description exchange a refresh token for a new token pair, the presented refresh token is revoked
+// @ID refresh-token
+// @Accept  json
+// @Produce  json
+// @Param input body InputRefreshToken true "refresh token"
+// @Success 200 {object} domains.Tokens
+// @Failure 400 {object} response.Problem
+// @Failure 401 {object} response.Problem
+// @Failure 403 {object} response.Problem
+// @Failure 500 {object} response.Problem
+// @Router /api/token/refresh [post]
+func (h *UserHandler) RefreshTokens(w http.ResponseWriter, r *http.Request) {
+	b, err := io.ReadAll(r.Body)
+	if err != nil {
+		response.Error(w, r, err, h.log)
+		return
+	}
+	defer r.Body.Close()
+
+	var input InputRefreshToken
+	err = json.Unmarshal(b, &input)
+	if err != nil || input.RefreshToken == "" {
+		response.Error(w, r, response.ErrBadRequest, h.log)
+		return
+	}
+
+	pair, err := h.service.RefreshTokens(r.Context(), input.RefreshToken)
+	if err != nil {
+		response.Error(w, r, err, h.log)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, pair, h.log)
+}
+
+// @Summary Logout
+// @Tags user
+// @Description revoke the access token and, if given, the refresh token of the session
+// @ID logout
+// @Accept  json
+// @Produce  json
+// @Param input body InputRefreshToken false "refresh token"
+// @Success 200
+// @Failure 400 {object} response.Problem
+// @Failure 401 {object} response.Problem
+// @Failure 500 {object} response.Problem
+// @Security ApiKeyAuth
+// @Router /api/logout [post]
+func (h *UserHandler) Logout(w http.ResponseWriter, r *http.Request) {
+	claims, ok := r.Context().Value(auth.TokenKey("claims")).(*tokens.Claims)
+	if !ok {
+		response.Error(w, r, response.ErrUnauthorized, h.log)
+		return
+	}
+
+	b, err := io.ReadAll(r.Body)
+	if err != nil {
+		response.Error(w, r, err, h.log)
+		return
+	}
+	defer r.Body.Close()
+
+	var input InputRefreshToken
+	if len(b) != 0 {
+		err = json.Unmarshal(b, &input)
+		if err != nil {
+			response.Error(w, r, response.ErrBadRequest, h.log)
 			return
 		}
-		response.JSONError(w, http.StatusInternalServerError, "unknown error", h.log)
+	}
+
+	err = h.service.Logout(r.Context(), claims, input.RefreshToken)
+	if err != nil {
+		response.Error(w, r, err, h.log)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// @Summary Get users
+// @Tags user
+// @Description get users
+// @ID get-users
+// @Accept  json
+// @Produce  json
+// @Param page query integer false "page number"
+// @Param size query integer false "page size"
+// @Success 200 {object} []domains.User
+// @Failure 500 {object} response.Problem
+// @Security ApiKeyAuth
+// @Router /api/users [get]
+func (h *UserHandler) GetUsers(w http.ResponseWriter, r *http.Request) {
+	users, err := h.service.GetUsers(r.Context(), pagination.NewFromRequest(r))
+	if err != nil {
+		response.Error(w, r, err, h.log)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, users, h.log)
+}
+
+// @Summary Update user role
+// @Tags user
+// @Description update user role
+// @ID update-role
+// @Accept  json
+// @Produce  json
+// @Param id path integer true "user id"
+// @Param role path string true "user role" Enums(viewer, editor, admin)
+// @Success 200
+// @Failure 400 {object} response.Problem
+// @Failure 404 {object} response.Problem
+// @Failure 409 {object} response.Problem
+// @Failure 500 {object} response.Problem
+// @Security ApiKeyAuth
+// @Router /api/user/role/{id}/{role} [put]
+func (h *UserHandler) UpdateUserRole(w http.ResponseWriter, r *http.Request) {
+	// API keys must not grant roles, however they are scoped.
+	if _, err := auth.CurrentUser(r); err != nil {
+		response.Error(w, r, err, h.log)
+		return
+	}
+
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		response.Error(w, r, response.ErrBadRequest, h.log)
+		return
+	}
+
+	role := domains.Role(r.PathValue("role"))
+
+	err = h.service.UpdateUserRole(r.Context(), uint32(id), role)
+	if err != nil {
+		response.Error(w, r, err, h.log)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// @Summary Disable user
+// @Tags user
+// @Description disable user, disabled users can neither log in nor use issued tokens
+// @ID disable-user
+// @Accept  json
+// @Produce  json
+// @Param id path integer true "user id"
+// @Success 200
+// @Failure 400 {object} response.Problem
+// @Failure 404 {object} response.Problem
+// @Failure 409 {object} response.Problem
+// @Failure 500 {object} response.Problem
+// @Security ApiKeyAuth
+// @Router /api/user/disable/{id} [put]
+func (h *UserHandler) DisableUser(w http.ResponseWriter, r *http.Request) {
+	h.setUserDisabled(w, r, true)
+}
+
+// @Summary Enable user
+// @Tags user
+// @Description enable disabled user
+// @ID enable-user
+// @Accept  json
+// @Produce  json
+// @Param id path integer true "user id"
+// @Success 200
+// @Failure 400 {object} response.Problem
+// @Failure 404 {object} response.Problem
+// @Failure 500 {object} response.Problem
+// @Security ApiKeyAuth
+// @Router /api/user/enable/{id} [put]
+func (h *UserHandler) EnableUser(w http.ResponseWriter, r *http.Request) {
+	h.setUserDisabled(w, r, false)
+}
+
+func (h *UserHandler) setUserDisabled(w http.ResponseWriter, r *http.Request, disabled bool) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		response.Error(w, r, response.ErrBadRequest, h.log)
+		return
+	}
+
+	err = h.service.SetUserDisabled(r.Context(), uint32(id), disabled)
+	if err != nil {
+		response.Error(w, r, err, h.log)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// @Summary Delete user
+// @Tags user
+// @Description delete user by id
+// @ID delete-user
+// @Accept  json
+// @Produce  json
+// @Param id path integer true "user id"
+// @Success 200
+// @Failure 400 {object} response.Problem
+// @Failure 404 {object} response.Problem
+// @Failure 409 {object} response.Problem
+// @Failure 500 {object} response.Problem
+// @Security ApiKeyAuth
+// @Router /api/user/{id} [delete]
+func (h *UserHandler) DeleteUser(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		response.Error(w, r, response.ErrBadRequest, h.log)
+		return
+	}
+
+	err = h.service.DeleteUser(r.Context(), uint32(id))
+	if err != nil {
+		response.Error(w, r, err, h.log)
 		return
 	}
 
-	response.JSON(w, http.StatusOK, map[string]any{
-		"token": token,
-	}, h.log)
+	w.WriteHeader(http.StatusOK)
 }
@@ -2,16 +2,20 @@ package userhandler
 
 import (
 	"bytes"
+	"context"
 	"film_library/internal/domains"
+	"film_library/internal/lockout"
 	"film_library/internal/repositories/postgres/userrepo"
 	mock_services "film_library/internal/services/mocks"
 	userservice "film_library/internal/services/userservice"
+	"film_library/pkg/middlewares/auth"
 	"film_library/pkg/mux"
 	"film_library/pkg/validation"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 )
@@ -30,72 +34,71 @@ func TestUserHandlerRegister(t *testing.T) {
 		{
 			name:      "Correct",
 			inputBody: `{"login":"denis", "password":"password","role":"viewer"}`,
-			inputUser: domains.User{Login: "denis", Password: "password", Role: "viewer"},
+			inputUser: domains.User{Login: "denis", Password: "password"},
 			mockBehavior: func(r *mock_services.MockUserService, user domains.User) {
-				r.EXPECT().CreateUser(user).Return("token", nil)
+				r.EXPECT().CreateUser(gomock.Any(), user).Return(&domains.Tokens{AccessToken: "token", RefreshToken: "refresh", ExpiresIn: 900}, nil)
 			},
 			expectedStatusCode:   http.StatusOK,
-			expectedResponseBody: `{"token":"token"}`,
+			expectedResponseBody: `{"token":"token","refreshToken":"refresh","expiresIn":900}`,
 		},
 		{
-			name:      "Invalid role",
-			inputBody: `{"login":"denis", "password":"password","role":"aboba"}`,
-			inputUser: domains.User{Login: "denis", Password: "password", Role: "aboba"},
+			name:      "Role is ignored",
+			inputBody: `{"login":"denis", "password":"password","role":"admin"}`,
+			inputUser: domains.User{Login: "denis", Password: "password"},
 			mockBehavior: func(r *mock_services.MockUserService, user domains.User) {
-				r.EXPECT().CreateUser(user).Return("", &validation.ValidateError{fmt.Errorf("invalid role")})
+				r.EXPECT().CreateUser(gomock.Any(), user).Return(&domains.Tokens{AccessToken: "token", RefreshToken: "refresh", ExpiresIn: 900}, nil)
 			},
-			expectedStatusCode:   http.StatusBadRequest,
-			expectedResponseBody: `{"errors":["invalid role"]}`,
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"token":"token","refreshToken":"refresh","expiresIn":900}`,
 		},
 		{
 			name:      "Invalid credentials",
 			inputBody: `{"login":"", "password":"1","role":"aboba"}`,
-			inputUser: domains.User{Login: "", Password: "1", Role: "aboba"},
+			inputUser: domains.User{Login: "", Password: "1"},
 			mockBehavior: func(r *mock_services.MockUserService, user domains.User) {
-				r.EXPECT().CreateUser(user).Return("",
+				r.EXPECT().CreateUser(gomock.Any(), user).Return(nil,
 					&validation.ValidateError{
-						fmt.Errorf("invalid login length"),
-						fmt.Errorf("invalid password length"),
-						fmt.Errorf("invalid role"),
+						{Field: "login", Code: validation.CodeLength, Message: "invalid login length"},
+						{Field: "password", Code: validation.CodeLength, Message: "invalid password length"},
 					},
 				)
 			},
 			expectedStatusCode:   http.StatusBadRequest,
-			expectedResponseBody: `{"errors":["invalid login length","invalid password length","invalid role"]}`,
+			expectedResponseBody: `{"type":"about:blank","title":"Bad Request","status":400,"code":"validation_failed","detail":"request validation failed","instance":"/register","errors":[{"field":"login","code":"length","message":"invalid login length"},{"field":"password","code":"length","message":"invalid password length"}]}`,
 		},
 		{
 			name:                 "Json unmarshal error",
 			inputBody:            ``,
 			mockBehavior:         func(r *mock_services.MockUserService, user domains.User) {},
 			expectedStatusCode:   http.StatusBadRequest,
-			expectedResponseBody: `{"error":"bad request"}`,
+			expectedResponseBody: `{"type":"about:blank","title":"Bad Request","status":400,"code":"bad_request","detail":"bad request","instance":"/register"}`,
 		},
 		{
 			name:                 "Json unmarshal error",
 			inputBody:            ``,
 			mockBehavior:         func(r *mock_services.MockUserService, user domains.User) {},
 			expectedStatusCode:   http.StatusBadRequest,
-			expectedResponseBody: `{"error":"bad request"}`,
+			expectedResponseBody: `{"type":"about:blank","title":"Bad Request","status":400,"code":"bad_request","detail":"bad request","instance":"/register"}`,
 		},
 		{
 			name:      "Invalid credentials",
 			inputBody: `{"login":"admin", "password":"password","role":"admin"}`,
-			inputUser: domains.User{Login: "admin", Password: "password", Role: "admin"},
+			inputUser: domains.User{Login: "admin", Password: "password"},
 			mockBehavior: func(r *mock_services.MockUserService, user domains.User) {
-				r.EXPECT().CreateUser(user).Return("", userrepo.ErrAlreadyExists)
+				r.EXPECT().CreateUser(gomock.Any(), user).Return(nil, userrepo.ErrAlreadyExists)
 			},
 			expectedStatusCode:   http.StatusConflict,
-			expectedResponseBody: `{"error":"user already exists"}`,
+			expectedResponseBody: `{"type":"about:blank","title":"Conflict","status":409,"code":"user_already_exists","detail":"user already exists","instance":"/register"}`,
 		},
 		{
 			name:      "Unknown error",
 			inputBody: `{"login":"123", "password":"123","role":"123"}`,
-			inputUser: domains.User{Login: "123", Password: "123", Role: "123"},
+			inputUser: domains.User{Login: "123", Password: "123"},
 			mockBehavior: func(r *mock_services.MockUserService, user domains.User) {
-				r.EXPECT().CreateUser(user).Return("", fmt.Errorf("some error"))
+				r.EXPECT().CreateUser(gomock.Any(), user).Return(nil, fmt.Errorf("some error"))
 			},
 			expectedStatusCode:   http.StatusInternalServerError,
-			expectedResponseBody: `{"error":"unknown error"}`,
+			expectedResponseBody: `{"type":"about:blank","title":"Internal Server Error","status":500,"code":"internal_error","detail":"internal error","instance":"/register"}`,
 		},
 	}
 
@@ -137,53 +140,66 @@ func TestUserHandlerLogin(t *testing.T) {
 		mockBehavior         mockBehavior
 		expectedStatusCode   int
 		expectedResponseBody string
+		expectedRetryAfter   string
 	}{
 		{
 			name:      "Correct",
 			inputBody: `{"login":"denis", "password":"password","role":"viewer"}`,
 			inputUser: domains.User{Login: "denis", Password: "password", Role: "viewer"},
 			mockBehavior: func(r *mock_services.MockUserService, user domains.User) {
-				r.EXPECT().Login(user.Login, user.Password).Return("token", nil)
+				r.EXPECT().Login(gomock.Any(), user.Login, user.Password, "192.0.2.1").Return(&domains.Tokens{AccessToken: "token", RefreshToken: "refresh", ExpiresIn: 900}, nil)
 			},
 			expectedStatusCode:   http.StatusOK,
-			expectedResponseBody: `{"token":"token"}`,
+			expectedResponseBody: `{"token":"token","refreshToken":"refresh","expiresIn":900}`,
 		},
 		{
 			name:      "Invalid password",
 			inputBody: `{"login":"denis", "password":"1","role":"viewer"}`,
 			inputUser: domains.User{Login: "denis", Password: "1", Role: "viewer"},
 			mockBehavior: func(r *mock_services.MockUserService, user domains.User) {
-				r.EXPECT().Login(user.Login, user.Password).Return("", userservice.ErrInvalidPassword)
+				r.EXPECT().Login(gomock.Any(), user.Login, user.Password, "192.0.2.1").Return(nil, userservice.ErrInvalidPassword)
 			},
 			expectedStatusCode:   http.StatusUnauthorized,
-			expectedResponseBody: `{"error":"invalid login or password"}`,
+			expectedResponseBody: `{"type":"about:blank","title":"Unauthorized","status":401,"code":"invalid_credentials","detail":"invalid login or password","instance":"/login"}`,
 		},
 		{
 			name:      "User not found",
 			inputBody: `{"login":"adfgfdag", "password":"1","role":"viewer"}`,
 			inputUser: domains.User{Login: "adfgfdag", Password: "1", Role: "viewer"},
 			mockBehavior: func(r *mock_services.MockUserService, user domains.User) {
-				r.EXPECT().Login(user.Login, user.Password).Return("", userservice.ErrNotFound)
+				r.EXPECT().Login(gomock.Any(), user.Login, user.Password, "192.0.2.1").Return(nil, userservice.ErrNotFound)
 			},
 			expectedStatusCode:   http.StatusUnauthorized,
-			expectedResponseBody: `{"error":"invalid login or password"}`,
+			expectedResponseBody: `{"type":"about:blank","title":"Unauthorized","status":401,"code":"invalid_credentials","detail":"invalid login or password","instance":"/login"}`,
+		},
+		{
+			name:      "Locked out",
+			inputBody: `{"login":"denis", "password":"1"}`,
+			inputUser: domains.User{Login: "denis", Password: "1"},
+			mockBehavior: func(r *mock_services.MockUserService, user domains.User) {
+				r.EXPECT().Login(gomock.Any(), user.Login, user.Password, "192.0.2.1").
+					Return(nil, fmt.Errorf("login: %w", &lockout.Error{Key: user.Login, Retry: 1500 * time.Millisecond}))
+			},
+			expectedStatusCode:   http.StatusTooManyRequests,
+			expectedResponseBody: `{"type":"about:blank","title":"Too Many Requests","status":429,"code":"too_many_attempts","detail":"too many failed attempts","instance":"/login"}`,
+			expectedRetryAfter:   "2",
 		},
 		{
 			name:                 "Json unmarshal error",
 			inputBody:            ``,
 			mockBehavior:         func(r *mock_services.MockUserService, user domains.User) {},
 			expectedStatusCode:   http.StatusBadRequest,
-			expectedResponseBody: `{"error":"bad request"}`,
+			expectedResponseBody: `{"type":"about:blank","title":"Bad Request","status":400,"code":"bad_request","detail":"bad request","instance":"/login"}`,
 		},
 		{
 			name:      "Unknown error",
 			inputBody: `{"login":"123", "password":"123","role":"123"}`,
 			inputUser: domains.User{Login: "123", Password: "123", Role: "123"},
 			mockBehavior: func(r *mock_services.MockUserService, user domains.User) {
-				r.EXPECT().Login(user.Login, user.Password).Return("", fmt.Errorf("some error"))
+				r.EXPECT().Login(gomock.Any(), user.Login, user.Password, "192.0.2.1").Return(nil, fmt.Errorf("some error"))
 			},
 			expectedStatusCode:   http.StatusInternalServerError,
-			expectedResponseBody: `{"error":"unknown error"}`,
+			expectedResponseBody: `{"type":"about:blank","title":"Internal Server Error","status":500,"code":"internal_error","detail":"internal error","instance":"/login"}`,
 		},
 	}
 
@@ -211,6 +227,176 @@ func TestUserHandlerLogin(t *testing.T) {
 			if tc.expectedResponseBody != w.Body.String() {
 				t.Errorf("expected: %s\ngot: %s", tc.expectedResponseBody, w.Body.String())
 			}
+
+			if tc.expectedRetryAfter != w.Header().Get("Retry-After") {
+				t.Errorf("expected: %s\ngot: %s", tc.expectedRetryAfter, w.Header().Get("Retry-After"))
+			}
+		})
+	}
+}
+
+func TestUserHandlerUpdateUserRole(t *testing.T) {
+	type mockBehavior func(r *mock_services.MockUserService, id uint32, role domains.Role)
+
+	admin := domains.Principal{UserID: 1, Login: "admin", Role: domains.RoleAdmin, Permissions: []domains.Permission{domains.PermUserManage}}
+
+	tests := []struct {
+		name                 string
+		byAPIKey             bool
+		path                 string
+		inputID              uint32
+		inputRole            domains.Role
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:      "Correct",
+			path:      "/api/user/role/2/admin",
+			inputID:   2,
+			inputRole: domains.RoleAdmin,
+			mockBehavior: func(r *mock_services.MockUserService, id uint32, role domains.Role) {
+				r.EXPECT().UpdateUserRole(gomock.Any(), id, role).Return(nil)
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:                 "Bad id",
+			path:                 "/api/user/role/abc/admin",
+			mockBehavior:         func(r *mock_services.MockUserService, id uint32, role domains.Role) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"type":"about:blank","title":"Bad Request","status":400,"code":"bad_request","detail":"bad request","instance":"/api/user/role/abc/admin"}`,
+		},
+		{
+			name:      "Not found",
+			path:      "/api/user/role/3/viewer",
+			inputID:   3,
+			inputRole: domains.RoleViewer,
+			mockBehavior: func(r *mock_services.MockUserService, id uint32, role domains.Role) {
+				r.EXPECT().UpdateUserRole(gomock.Any(), id, role).Return(userrepo.ErrNotFound)
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"type":"about:blank","title":"Not Found","status":404,"code":"user_not_found","detail":"user not found","instance":"/api/user/role/3/viewer"}`,
+		},
+		{
+			name:      "Last user manager",
+			path:      "/api/user/role/1/viewer",
+			inputID:   1,
+			inputRole: domains.RoleViewer,
+			mockBehavior: func(r *mock_services.MockUserService, id uint32, role domains.Role) {
+				r.EXPECT().UpdateUserRole(gomock.Any(), id, role).Return(fmt.Errorf("update: %w", userservice.ErrLastUserManager))
+			},
+			expectedStatusCode:   http.StatusConflict,
+			expectedResponseBody: `{"type":"about:blank","title":"Conflict","status":409,"code":"last_user_manager","detail":"no other enabled user could manage users","instance":"/api/user/role/1/viewer"}`,
+		},
+		{
+			name:                 "API key",
+			byAPIKey:             true,
+			path:                 "/api/user/role/2/admin",
+			mockBehavior:         func(r *mock_services.MockUserService, id uint32, role domains.Role) {},
+			expectedStatusCode:   http.StatusForbidden,
+			expectedResponseBody: `{"type":"about:blank","title":"Forbidden","status":403,"code":"forbidden","detail":"forbidden","instance":"/api/user/role/2/admin"}`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			service := mock_services.NewMockUserService(c)
+			handler := UserHandler{service: service}
+			tc.mockBehavior(service, tc.inputID, tc.inputRole)
+
+			r := mux.New()
+			r.HandleFunc("PUT /api/user/role/{id}/{role}", handler.UpdateUserRole)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPut, tc.path, nil)
+			principal := admin
+			if tc.byAPIKey {
+				principal = domains.Principal{APIKeyID: 5, Permissions: []domains.Permission{domains.PermUserManage}}
+			}
+			req = req.WithContext(context.WithValue(req.Context(), auth.UserKey("user"), principal))
+
+			r.ServeHTTP(w, req)
+
+			if tc.expectedStatusCode != w.Code {
+				t.Errorf("expected: %d\ngot: %d", tc.expectedStatusCode, w.Code)
+			}
+
+			if tc.expectedResponseBody != w.Body.String() {
+				t.Errorf("expected: %s\ngot: %s", tc.expectedResponseBody, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestUserHandlerRefreshTokens(t *testing.T) {
+	type mockBehavior func(r *mock_services.MockUserService, refreshToken string)
+
+	tests := []struct {
+		name                 string
+		inputBody            string
+		inputToken           string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:       "Correct",
+			inputBody:  `{"refreshToken":"refresh"}`,
+			inputToken: "refresh",
+			mockBehavior: func(r *mock_services.MockUserService, refreshToken string) {
+				r.EXPECT().RefreshTokens(gomock.Any(), refreshToken).
+					Return(&domains.Tokens{AccessToken: "token", RefreshToken: "refresh2", ExpiresIn: 900}, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"token":"token","refreshToken":"refresh2","expiresIn":900}`,
+		},
+		{
+			name:                 "Empty token",
+			inputBody:            `{}`,
+			mockBehavior:         func(r *mock_services.MockUserService, refreshToken string) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"type":"about:blank","title":"Bad Request","status":400,"code":"bad_request","detail":"bad request","instance":"/api/token/refresh"}`,
+		},
+		{
+			name:       "Invalid token",
+			inputBody:  `{"refreshToken":"stolen"}`,
+			inputToken: "stolen",
+			mockBehavior: func(r *mock_services.MockUserService, refreshToken string) {
+				r.EXPECT().RefreshTokens(gomock.Any(), refreshToken).Return(nil, userservice.ErrInvalidRefresh)
+			},
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedResponseBody: `{"type":"about:blank","title":"Unauthorized","status":401,"code":"invalid_refresh_token","detail":"invalid refresh token","instance":"/api/token/refresh"}`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			service := mock_services.NewMockUserService(c)
+			handler := UserHandler{service: service}
+			tc.mockBehavior(service, tc.inputToken)
+
+			r := mux.New()
+			r.HandleFunc("POST /api/token/refresh", handler.RefreshTokens)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/api/token/refresh", bytes.NewBufferString(tc.inputBody))
+
+			r.ServeHTTP(w, req)
+
+			if tc.expectedStatusCode != w.Code {
+				t.Errorf("expected: %d\ngot: %d", tc.expectedStatusCode, w.Code)
+			}
+
+			if tc.expectedResponseBody != w.Body.String() {
+				t.Errorf("expected: %s\ngot: %s", tc.expectedResponseBody, w.Body.String())
+			}
 		})
 	}
 }
@@ -0,0 +1,204 @@
+package lockout
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+var ErrLocked = fmt.Errorf("too many failed attempts")
+
+// Error reports that a key is blocked and when it may try again.
+type Error struct {
+	Key   string
+	Retry time.Duration
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s: %s, retry after %s", e.Key, ErrLocked.Error(), e.Retry)
+}
+
+func (e *Error) Unwrap() error {
+	return ErrLocked
+}
+
+func (e *Error) RetryAfter() time.Duration {
+	return e.Retry
+}
+
+type Policy struct {
+	// FreeAttempts failures are allowed without delay.
+	FreeAttempts int
+	// BaseDelay is the block after the first failure past FreeAttempts; it
+	// doubles with every further failure up to MaxDelay.
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+	// Threshold failures lock the key for Duration.
+	Threshold int
+	Duration  time.Duration
+	// Window forgets failures after that long without a new one.
+	Window time.Duration
+}
+
+type entry struct {
+	failures     int
+	lastFailure  time.Time
+	blockedUntil time.Time
+	// pending counts the attempts begun but not settled yet.
+	pending int
+}
+
+// Guard counts failures per key, for example per login or per client IP.
+type Guard struct {
+	policy Policy
+
+	mu        sync.Mutex
+	entries   map[string]*entry
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+func New(policy Policy) *Guard {
+	return &Guard{
+		policy:  policy,
+		entries: map[string]*entry{},
+		now:     time.Now,
+	}
+}
+
+// Check returns an *Error if key is blocked.
+func (g *Guard) Check(key string) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	e, ok := g.entries[key]
+	if !ok {
+		return nil
+	}
+
+	now := g.now()
+	if wait := e.blockedUntil.Sub(now); wait > 0 {
+		return &Error{Key: key, Retry: wait}
+	}
+	return nil
+}
+
+// Begin reserves an attempt of key. It returns an *Error if key is blocked or
+// if the attempt could exceed the free attempts while others are in flight:
+// past the free attempts only one attempt at a time may run, so that
+// concurrent attempts cannot slip through before the delay of the first
+// failure applies. Every reserved attempt must be settled by Fail, Reset or
+// Release.
+func (g *Guard) Begin(key string) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	now := g.now()
+	e, ok := g.entries[key]
+	if !ok {
+		e = &entry{}
+		g.entries[key] = e
+	}
+
+	if wait := e.blockedUntil.Sub(now); wait > 0 {
+		return &Error{Key: key, Retry: wait}
+	}
+	if e.pending > 0 && e.failures+e.pending >= g.policy.FreeAttempts {
+		return &Error{Key: key, Retry: g.policy.BaseDelay}
+	}
+
+	e.pending++
+	return nil
+}
+
+// Release settles an attempt reserved by Begin that neither failed nor
+// succeeded, for example because the password could not be checked.
+func (g *Guard) Release(key string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if e, ok := g.entries[key]; ok {
+		e.settle()
+		if e.pending == 0 && e.failures == 0 && e.blockedUntil.IsZero() {
+			delete(g.entries, key)
+		}
+	}
+}
+
+// Fail records a failure of key. It reports whether the failure locked the
+// key, so that callers can raise an alert.
+func (g *Guard) Fail(key string) (locked bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	now := g.now()
+	g.sweep(now)
+
+	e, ok := g.entries[key]
+	if !ok {
+		e = &entry{}
+		g.entries[key] = e
+	}
+	e.settle()
+	if now.Sub(e.lastFailure) > g.policy.Window {
+		e.failures = 0
+	}
+
+	e.failures++
+	e.lastFailure = now
+
+	if e.failures >= g.policy.Threshold {
+		e.blockedUntil = now.Add(g.policy.Duration)
+		return e.failures == g.policy.Threshold
+	}
+
+	if e.failures > g.policy.FreeAttempts {
+		delay := g.policy.BaseDelay << (e.failures - g.policy.FreeAttempts - 1)
+		if delay > g.policy.MaxDelay || delay <= 0 {
+			delay = g.policy.MaxDelay
+		}
+		e.blockedUntil = now.Add(delay)
+	}
+
+	return false
+}
+
+// Reset forgets the failures of key after a successful attempt. Attempts of
+// key still in flight stay reserved.
+func (g *Guard) Reset(key string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	e, ok := g.entries[key]
+	if !ok {
+		return
+	}
+	e.settle()
+	if e.pending == 0 {
+		delete(g.entries, key)
+		return
+	}
+	e.failures = 0
+	e.blockedUntil = time.Time{}
+}
+
+func (e *entry) settle() {
+	if e.pending > 0 {
+		e.pending--
+	}
+}
+
+// sweep drops entries that are neither blocked nor within the window, at
+// most once per window, so the map does not grow with every login ever tried.
+func (g *Guard) sweep(now time.Time) {
+	if now.Sub(g.lastSweep) < g.policy.Window {
+		return
+	}
+	g.lastSweep = now
+
+	for key, e := range g.entries {
+		if e.pending == 0 && now.After(e.blockedUntil) && now.Sub(e.lastFailure) > g.policy.Window {
+			delete(g.entries, key)
+		}
+	}
+}
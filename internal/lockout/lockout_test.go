@@ -0,0 +1,197 @@
+package lockout
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestGuard(t *testing.T) {
+	start := time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC)
+	policy := Policy{
+		FreeAttempts: 2,
+		BaseDelay:    time.Second,
+		MaxDelay:     4 * time.Second,
+		Threshold:    6,
+		Duration:     time.Minute,
+		Window:       10 * time.Minute,
+	}
+
+	tests := []struct {
+		name           string
+		failures       int
+		after          time.Duration
+		expectedRetry  time.Duration
+		expectedLocked bool
+	}{
+		{
+			name:     "Free attempts",
+			failures: 2,
+		},
+		{
+			name:          "First delay",
+			failures:      3,
+			expectedRetry: time.Second,
+		},
+		{
+			name:          "Delay doubles",
+			failures:      4,
+			expectedRetry: 2 * time.Second,
+		},
+		{
+			name:          "Delay is capped",
+			failures:      5,
+			expectedRetry: 4 * time.Second,
+		},
+		{
+			name:           "Lockout",
+			failures:       6,
+			expectedRetry:  time.Minute,
+			expectedLocked: true,
+		},
+		{
+			name:          "Delay passed",
+			failures:      3,
+			after:         time.Second,
+			expectedRetry: 0,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			now := start
+			g := New(policy)
+			g.now = func() time.Time { return now }
+
+			locked := false
+			for i := 0; i < tc.failures; i++ {
+				locked = g.Fail("denis")
+			}
+			now = now.Add(tc.after)
+
+			if locked != tc.expectedLocked {
+				t.Errorf("expected: %t\ngot: %t", tc.expectedLocked, locked)
+			}
+
+			err := g.Check("denis")
+			var lockErr *Error
+			switch {
+			case tc.expectedRetry == 0 && err != nil:
+				t.Errorf("expected: <nil>\ngot: %s", err)
+			case tc.expectedRetry != 0 && (!errors.As(err, &lockErr) || !errors.Is(err, ErrLocked)):
+				t.Errorf("expected: %s\ngot: %v", ErrLocked, err)
+			case tc.expectedRetry != 0 && lockErr.RetryAfter() != tc.expectedRetry:
+				t.Errorf("expected: %s\ngot: %s", tc.expectedRetry, lockErr.RetryAfter())
+			}
+
+			if err := g.Check("other"); err != nil {
+				t.Errorf("expected: <nil>\ngot: %s", err)
+			}
+		})
+	}
+}
+
+func TestGuardReset(t *testing.T) {
+	now := time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC)
+	g := New(Policy{FreeAttempts: 0, BaseDelay: time.Second, MaxDelay: time.Second, Threshold: 10, Duration: time.Minute, Window: time.Minute})
+	g.now = func() time.Time { return now }
+
+	g.Fail("denis")
+	g.Reset("denis")
+
+	if err := g.Check("denis"); err != nil {
+		t.Errorf("expected: <nil>\ngot: %s", err)
+	}
+
+	g.Fail("denis")
+	now = now.Add(2 * time.Minute)
+	g.Fail("denis")
+
+	if err := g.Check("denis"); err == nil || err.(*Error).RetryAfter() != time.Second {
+		t.Errorf("expected failures outside the window to be forgotten, got: %v", err)
+	}
+}
+
+func TestGuardConcurrentAttempts(t *testing.T) {
+	policy := Policy{FreeAttempts: 2, BaseDelay: time.Second, MaxDelay: time.Minute, Threshold: 4, Duration: time.Hour, Window: time.Hour}
+	g := New(policy)
+
+	// All attempts begin before any of them fails, like parallel guesses
+	// waiting for the password hash.
+	const attempts = 50
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		started int
+	)
+	release := make(chan struct{})
+	for i := 0; i < attempts; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := g.Begin("denis"); err != nil {
+				if !errors.Is(err, ErrLocked) {
+					t.Errorf("expected: %s\ngot: %s", ErrLocked, err)
+				}
+				return
+			}
+			mu.Lock()
+			started++
+			mu.Unlock()
+			<-release
+			g.Fail("denis")
+		}()
+	}
+
+	for {
+		mu.Lock()
+		n := started
+		mu.Unlock()
+		if n == policy.FreeAttempts {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if err := g.Begin("denis"); !errors.Is(err, ErrLocked) {
+		t.Errorf("expected: %s\ngot: %v", ErrLocked, err)
+	}
+	close(release)
+	wg.Wait()
+
+	if started != policy.FreeAttempts {
+		t.Errorf("expected: %d attempts\ngot: %d", policy.FreeAttempts, started)
+	}
+
+	// Past the free attempts only one attempt runs at a time.
+	if err := g.Begin("denis"); err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+	if err := g.Begin("denis"); !errors.Is(err, ErrLocked) {
+		t.Errorf("expected: %s\ngot: %v", ErrLocked, err)
+	}
+	g.Fail("denis")
+	if err := g.Begin("denis"); !errors.Is(err, ErrLocked) {
+		t.Errorf("expected: %s\ngot: %v", ErrLocked, err)
+	}
+}
+
+func TestGuardRelease(t *testing.T) {
+	g := New(Policy{FreeAttempts: 0, BaseDelay: time.Second, MaxDelay: time.Second, Threshold: 10, Duration: time.Minute, Window: time.Minute})
+
+	if err := g.Begin("denis"); err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+	if err := g.Begin("denis"); !errors.Is(err, ErrLocked) {
+		t.Errorf("expected: %s\ngot: %v", ErrLocked, err)
+	}
+
+	g.Release("denis")
+	if err := g.Begin("denis"); err != nil {
+		t.Errorf("expected: <nil>\ngot: %s", err)
+	}
+	g.Reset("denis")
+	if len(g.entries) != 0 {
+		t.Errorf("expected: no entries\ngot: %d", len(g.entries))
+	}
+}
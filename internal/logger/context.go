@@ -0,0 +1,46 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+)
+
+type ctxKey struct{}
+
+type scope struct {
+	mu  sync.RWMutex
+	log *slog.Logger
+}
+
+// WithContext stores a request-scoped logger in the context.
+func WithContext(ctx context.Context, log *slog.Logger) context.Context {
+	return context.WithValue(ctx, ctxKey{}, &scope{log: log})
+}
+
+// FromContext returns the request-scoped logger, or fallback if the context
+// has none.
+func FromContext(ctx context.Context, fallback *slog.Logger) *slog.Logger {
+	s, ok := ctx.Value(ctxKey{}).(*scope)
+	if !ok {
+		return fallback
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.log
+}
+
+// AddAttrs adds attributes to the request-scoped logger. Unlike deriving a new
+// context, the attributes are also visible to the middlewares that run
+// outside of the caller, e.g. the access log written after the handler returns.
+func AddAttrs(ctx context.Context, attrs ...any) {
+	s, ok := ctx.Value(ctxKey{}).(*scope)
+	if !ok {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.log = s.log.With(attrs...)
+}
@@ -0,0 +1,134 @@
+package passwords
+
+import (
+	"errors"
+	"film_library/internal/config"
+	"testing"
+)
+
+// seededAdmin is the hash of the admin from testdata.sql.
+const seededAdmin = "$2a$10$TapsdRWZUU/26uZdj/gpwO4OPf4/0eqxOrlPBZfm3iH74aN5S8l0q"
+
+func TestHasher(t *testing.T) {
+	argon2id := config.PasswordHashing{
+		Algorithm:         AlgorithmArgon2id,
+		Argon2Memory:      64,
+		Argon2Iterations:  1,
+		Argon2Parallelism: 1,
+	}
+	stronger := argon2id
+	stronger.Argon2Iterations = 2
+	bcryptCfg := config.PasswordHashing{Algorithm: AlgorithmBcrypt, BcryptCost: 10}
+
+	hasher, err := NewHasher(argon2id)
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+	hash, err := hasher.Hash("qwerty")
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+
+	tests := []struct {
+		name                string
+		cfg                 config.PasswordHashing
+		hash                string
+		password            string
+		expectedErr         error
+		expectedNeedsRehash bool
+	}{
+		{
+			name:     "Argon2id",
+			cfg:      argon2id,
+			hash:     hash,
+			password: "qwerty",
+		},
+		{
+			name:        "Argon2id mismatch",
+			cfg:         argon2id,
+			hash:        hash,
+			password:    "qwertz",
+			expectedErr: ErrMismatch,
+		},
+		{
+			name:                "Argon2id with outdated parameters",
+			cfg:                 stronger,
+			hash:                hash,
+			password:            "qwerty",
+			expectedNeedsRehash: true,
+		},
+		{
+			name:                "Bcrypt with argon2id configured",
+			cfg:                 argon2id,
+			hash:                seededAdmin,
+			password:            "qwerty",
+			expectedNeedsRehash: true,
+		},
+		{
+			name:     "Bcrypt",
+			cfg:      bcryptCfg,
+			hash:     seededAdmin,
+			password: "qwerty",
+		},
+		{
+			name:        "Bcrypt mismatch",
+			cfg:         bcryptCfg,
+			hash:        seededAdmin,
+			password:    "qwertz",
+			expectedErr: ErrMismatch,
+		},
+		{
+			name:        "Unknown hash",
+			cfg:         argon2id,
+			hash:        "$md5$abc",
+			password:    "qwerty",
+			expectedErr: ErrUnknownHash,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			hasher, err := NewHasher(tc.cfg)
+			if err != nil {
+				t.Fatalf("%s", err.Error())
+			}
+
+			err = hasher.Verify(tc.hash, tc.password)
+			if !errors.Is(err, tc.expectedErr) {
+				t.Errorf("expected: %v\ngot: %v", tc.expectedErr, err)
+			}
+
+			if got := hasher.NeedsRehash(tc.hash); tc.expectedErr == nil && got != tc.expectedNeedsRehash {
+				t.Errorf("expected: %t\ngot: %t", tc.expectedNeedsRehash, got)
+			}
+		})
+	}
+}
+
+func TestNewHasherInvalid(t *testing.T) {
+	for _, cfg := range []config.PasswordHashing{
+		{Algorithm: "md5"},
+		{Algorithm: AlgorithmBcrypt, BcryptCost: 2},
+		{Algorithm: AlgorithmArgon2id},
+	} {
+		if _, err := NewHasher(cfg); err == nil {
+			t.Errorf("expected: error\ngot: <nil>")
+		}
+	}
+}
+
+func TestHasherDummy(t *testing.T) {
+	for _, cfg := range []config.PasswordHashing{
+		{Algorithm: AlgorithmArgon2id, Argon2Memory: 64, Argon2Iterations: 1, Argon2Parallelism: 1},
+		{Algorithm: AlgorithmBcrypt, BcryptCost: 10},
+	} {
+		hasher, err := NewHasher(cfg)
+		if err != nil {
+			t.Fatalf("%s", err.Error())
+		}
+		if hasher.NeedsRehash(hasher.dummy) {
+			t.Errorf("expected: dummy hash with the %s parameters\ngot: %s", cfg.Algorithm, hasher.dummy)
+		}
+		hasher.VerifyDummy("qwerty")
+	}
+}
@@ -0,0 +1,120 @@
+package passwords
+
+import (
+	"bufio"
+	"film_library/internal/config"
+	"film_library/pkg/validation"
+	"fmt"
+	"os"
+	"strings"
+	"unicode"
+)
+
+// Rule codes are returned to clients and must stay stable.
+const (
+	CodeCharClasses = "char_classes"
+	CodeDenylisted  = "denylisted"
+	CodeEqualsLogin = "equals_login"
+)
+
+var (
+	ErrTooFewClasses = fmt.Errorf("password does not mix enough character classes")
+	ErrDenylisted    = fmt.Errorf("password is too common")
+	ErrEqualsLogin   = fmt.Errorf("password must not equal the login")
+)
+
+// Policy decides which passwords are strong enough. Lengths are checked by
+// the user service together with the login.
+type Policy struct {
+	minClasses int
+	classesErr error
+	denylist   map[string]struct{}
+}
+
+// NewPolicy loads the denylist, one password per line; empty lines and lines
+// starting with # are skipped.
+func NewPolicy(cfg config.PasswordPolicy) (*Policy, error) {
+	p := &Policy{
+		minClasses: cfg.MinCharClasses,
+		classesErr: fmt.Errorf("%w: use at least %d of lowercase letters, uppercase letters, digits and symbols",
+			ErrTooFewClasses, cfg.MinCharClasses),
+		denylist: map[string]struct{}{},
+	}
+
+	if cfg.DenylistFile == "" {
+		return p, nil
+	}
+
+	f, err := os.Open(cfg.DenylistFile)
+	if err != nil {
+		return nil, fmt.Errorf("password denylist: %w", err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		p.denylist[strings.ToLower(line)] = struct{}{}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("password denylist: %w", err)
+	}
+
+	return p, nil
+}
+
+// Rules returns the checks for a password of login.
+func (p *Policy) Rules(login string) []validation.Rule[string] {
+	return []validation.Rule[string]{
+		p.charClasses(),
+		p.notDenylisted(),
+		notLogin(login),
+	}
+}
+
+func (p *Policy) charClasses() validation.Rule[string] {
+	return validation.Rule[string](func(password string) *validation.FieldError {
+		if countClasses(password) >= p.minClasses {
+			return nil
+		}
+		return &validation.FieldError{Code: CodeCharClasses}
+	}).Err(p.classesErr)
+}
+
+func (p *Policy) notDenylisted() validation.Rule[string] {
+	return validation.Rule[string](func(password string) *validation.FieldError {
+		if _, ok := p.denylist[strings.ToLower(password)]; !ok {
+			return nil
+		}
+		return &validation.FieldError{Code: CodeDenylisted}
+	}).Err(ErrDenylisted)
+}
+
+func notLogin(login string) validation.Rule[string] {
+	return validation.Rule[string](func(password string) *validation.FieldError {
+		if !strings.EqualFold(password, login) {
+			return nil
+		}
+		return &validation.FieldError{Code: CodeEqualsLogin}
+	}).Err(ErrEqualsLogin)
+}
+
+func countClasses(password string) int {
+	var lower, upper, digit, symbol int
+	for _, r := range password {
+		switch {
+		case unicode.IsLower(r):
+			lower = 1
+		case unicode.IsUpper(r):
+			upper = 1
+		case unicode.IsDigit(r):
+			digit = 1
+		default:
+			symbol = 1
+		}
+	}
+	return lower + upper + digit + symbol
+}
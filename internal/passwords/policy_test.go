@@ -0,0 +1,74 @@
+package passwords
+
+import (
+	"errors"
+	"film_library/internal/config"
+	"film_library/pkg/validation"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestPolicyRules(t *testing.T) {
+	denylist := filepath.Join(t.TempDir(), "denylist.txt")
+	err := os.WriteFile(denylist, []byte("# common passwords\n\nPassword1\nqwerty123\n"), 0o600)
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+
+	policy, err := NewPolicy(config.PasswordPolicy{MinCharClasses: 2, DenylistFile: denylist})
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+
+	tests := []struct {
+		name        string
+		login       string
+		password    string
+		expectedErr error
+	}{
+		{
+			name:     "Strong",
+			login:    "user",
+			password: "correct-horse",
+		},
+		{
+			name:        "One class",
+			login:       "user",
+			password:    "correcthorse",
+			expectedErr: ErrTooFewClasses,
+		},
+		{
+			name:        "Denylisted ignoring case",
+			login:       "user",
+			password:    "PASSWORD1",
+			expectedErr: ErrDenylisted,
+		},
+		{
+			name:        "Equals login",
+			login:       "User2024",
+			password:    "user2024",
+			expectedErr: ErrEqualsLogin,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validation.Check("password", tc.password, policy.Rules(tc.login)...)
+
+			if tc.expectedErr == nil && err != nil {
+				t.Errorf("expected: <nil>\ngot: %s", err.Error())
+			}
+			if tc.expectedErr != nil && !errors.Is(err, tc.expectedErr) {
+				t.Errorf("expected: %s\ngot: %v", tc.expectedErr.Error(), err)
+			}
+		})
+	}
+}
+
+func TestNewPolicyMissingDenylist(t *testing.T) {
+	_, err := NewPolicy(config.PasswordPolicy{MinCharClasses: 2, DenylistFile: filepath.Join(t.TempDir(), "missing.txt")})
+	if err == nil {
+		t.Errorf("expected: error\ngot: <nil>")
+	}
+}
@@ -1,9 +1,11 @@
 package actorrepo
 
 import (
+	"context"
 	"database/sql"
 	"film_library/internal/domains"
 	"film_library/pkg/pagination"
+	"film_library/pkg/sqltools/querier"
 	selectbuilder "film_library/pkg/sqltools/select_builder"
 	"fmt"
 	"strings"
@@ -16,27 +18,29 @@ var (
 	ErrInvalidGender = fmt.Errorf("invalid actor gender")
 	ErrNotFound      = fmt.Errorf("actor not found")
 	ErrUniqueActors  = fmt.Errorf("actors must be unique")
+	ErrInvalidCredit = fmt.Errorf("invalid credit type")
 )
 
 type ActorRepository struct {
-	db *sql.DB
+	db querier.Querier
 }
 
-func NewActorRepository(db *sql.DB) *ActorRepository {
+func NewActorRepository(db querier.Querier) *ActorRepository {
 	return &ActorRepository{
 		db: db,
 	}
 }
 
-func (r *ActorRepository) AddActor(actor domains.Actor) error {
+func (r *ActorRepository) AddActor(ctx context.Context, actor domains.Person) error {
 	fn := "actorRepository.AddActor"
+	ctx = querier.WithMethod(ctx, fn)
 
 	stmt := `
-		INSERT INTO actors(full_name, gender, birthday)
+		INSERT INTO persons(full_name, gender, birthday)
 		VALUES ($1, $2, $3);
 	`
 
-	_, err := r.db.Exec(stmt, actor.FullName, actor.Gender, time.Time(actor.Birthday))
+	_, err := r.db.ExecContext(ctx, stmt, actor.FullName, actor.Gender, time.Time(actor.Birthday))
 	if err != nil {
 		if err, ok := err.(*pq.Error); ok && err.Code == pq.ErrorCode("23514") {
 			return fmt.Errorf("%s: %w", fn, ErrInvalidGender)
@@ -47,14 +51,14 @@ func (r *ActorRepository) AddActor(actor domains.Actor) error {
 	return nil
 }
 
-func (r *ActorRepository) updateField(id uint32, field string, value any) error {
+func (r *ActorRepository) updateField(ctx context.Context, id uint32, field string, value any) error {
 	stmt := fmt.Sprintf(`
-		UPDATE actors
+		UPDATE persons
 		SET %s=$1
 		WHERE id=$2;
 	`, field)
 
-	res, err := r.db.Exec(stmt, value, id)
+	res, err := r.db.ExecContext(ctx, stmt, value, id)
 	if err != nil {
 		return err
 	}
@@ -71,20 +75,22 @@ func (r *ActorRepository) updateField(id uint32, field string, value any) error
 	return nil
 }
 
-func (r *ActorRepository) UpdateActorFullName(id uint32, fullName string) error {
+func (r *ActorRepository) UpdateActorFullName(ctx context.Context, id uint32, fullName string) error {
 	fn := "actorRepository.UpdateActorFullName"
+	ctx = querier.WithMethod(ctx, fn)
 
-	if err := r.updateField(id, "full_name", fullName); err != nil {
+	if err := r.updateField(ctx, id, "full_name", fullName); err != nil {
 		return fmt.Errorf("%s: %w", fn, err)
 	}
 
 	return nil
 }
 
-func (r *ActorRepository) UpdateActorGender(id uint32, gender string) error {
+func (r *ActorRepository) UpdateActorGender(ctx context.Context, id uint32, gender string) error {
 	fn := "actorRepository.UpdateActorGender"
+	ctx = querier.WithMethod(ctx, fn)
 
-	if err := r.updateField(id, "gender", gender); err != nil {
+	if err := r.updateField(ctx, id, "gender", gender); err != nil {
 		if err, ok := err.(*pq.Error); ok && err.Code == pq.ErrorCode("23514") {
 			return fmt.Errorf("%s: %w", fn, ErrInvalidGender)
 		}
@@ -94,26 +100,28 @@ func (r *ActorRepository) UpdateActorGender(id uint32, gender string) error {
 	return nil
 }
 
-func (r *ActorRepository) UpdateActorBirthday(id uint32, birthday time.Time) error {
+func (r *ActorRepository) UpdateActorBirthday(ctx context.Context, id uint32, birthday time.Time) error {
 	fn := "actorRepository.UpdateActorBirthday"
+	ctx = querier.WithMethod(ctx, fn)
 
-	if err := r.updateField(id, "birthday", birthday); err != nil {
+	if err := r.updateField(ctx, id, "birthday", birthday); err != nil {
 		return fmt.Errorf("%s: %w", fn, err)
 	}
 
 	return nil
 }
 
-func (r *ActorRepository) UpdateActor(id uint32, actor domains.Actor) error {
+func (r *ActorRepository) UpdateActor(ctx context.Context, id uint32, actor domains.Person) error {
 	fn := "actorRepository.UpdateActor"
+	ctx = querier.WithMethod(ctx, fn)
 
 	stmt := `
-		UPDATE actors
+		UPDATE persons
 		SET (full_name, gender, birthday)=($1, $2, $3)
 		WHERE id=$4;
 	`
 
-	res, err := r.db.Exec(stmt, actor.FullName, actor.Gender, time.Time(actor.Birthday), id)
+	res, err := r.db.ExecContext(ctx, stmt, actor.FullName, actor.Gender, time.Time(actor.Birthday), id)
 	if err != nil {
 		return fmt.Errorf("%s: %w", fn, err)
 	}
@@ -130,15 +138,16 @@ func (r *ActorRepository) UpdateActor(id uint32, actor domains.Actor) error {
 	return nil
 }
 
-func (r *ActorRepository) DeleteActor(id uint32) error {
+func (r *ActorRepository) DeleteActor(ctx context.Context, id uint32) error {
 	fn := "actorRepository.DeleteActor"
+	ctx = querier.WithMethod(ctx, fn)
 
 	stmt := `
-		DELETE FROM actors
+		DELETE FROM persons
 		WHERE id=$1;
 	`
 
-	res, err := r.db.Exec(stmt, id)
+	res, err := r.db.ExecContext(ctx, stmt, id)
 	if err != nil {
 		return fmt.Errorf("%s: %w", fn, err)
 	}
@@ -155,15 +164,16 @@ func (r *ActorRepository) DeleteActor(id uint32) error {
 	return nil
 }
 
-func (r *ActorRepository) DeleteActorFromFilm(actorID uint32, filmID uint32) error {
+func (r *ActorRepository) DeleteActorFromFilm(ctx context.Context, actorID uint32, filmID uint32) error {
 	fn := "actorRepository.DeleteActorFromFilm"
+	ctx = querier.WithMethod(ctx, fn)
 
 	stmt := `
-		DELETE FROM film_actor
-		WHERE film_id=$1 AND actor_id=$2;
+		DELETE FROM credits
+		WHERE film_id=$1 AND person_id=$2;
 	`
 
-	res, err := r.db.Exec(stmt, filmID, actorID)
+	res, err := r.db.ExecContext(ctx, stmt, filmID, actorID)
 	if err != nil {
 		return fmt.Errorf("%s: %w", fn, err)
 	}
@@ -180,76 +190,163 @@ func (r *ActorRepository) DeleteActorFromFilm(actorID uint32, filmID uint32) err
 	return nil
 }
 
-func (r *ActorRepository) GetActorsWithFilms(filter *pagination.ActorsFilter) ([]*domains.ActorWithFilms, error) {
+func (r *ActorRepository) DeleteFilmActors(ctx context.Context, filmID uint32) error {
+	fn := "actorRepository.DeleteFilmActors"
+	ctx = querier.WithMethod(ctx, fn)
+
+	stmt := `
+		DELETE FROM credits
+		WHERE film_id=$1;
+	`
+
+	_, err := r.db.ExecContext(ctx, stmt, filmID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", fn, err)
+	}
+
+	return nil
+}
+
+func (r *ActorRepository) GetActorsWithFilms(ctx context.Context, filter *pagination.ActorsFilter) ([]*domains.PersonWithCredits, error) {
 	fn := "actorRepository.GetActorsWithFilms"
+	ctx = querier.WithMethod(ctx, fn)
 	query := selectbuilder.
-		New(`SELECT a.id, a.full_name, a.gender, a.birthday, 
-			f.id, f.name, f.description, f.release_date, f.rating FROM actors AS a`).
-		Join("film_actor AS fa ON a.id=fa.actor_id").
-		Join("films AS f ON f.id=fa.film_id").
-		Where("LOWER(a.full_name) LIKE $1").
-		AddPagination(filter.Pagination).
-		Build()
-
-	res, err := r.db.Query(query, "%"+strings.ToLower(filter.FullNameContains)+"%")
+		New(`SELECT p.id, p.full_name, p.gender, p.birthday,
+			f.id, f.name, f.description, f.release_date, f.rating,
+			c.type, COALESCE(c.character_name, ''), c.billing_order FROM persons AS p`).
+		Join("credits AS c ON p.id=c.person_id").
+		Join("films AS f ON f.id=c.film_id").
+		Where("LOWER(p.full_name) LIKE ?", selectbuilder.Contains(strings.ToLower(filter.FullNameContains)))
+	if filter.CreditType != "" {
+		query.Where("c.type=?", filter.CreditType)
+	}
+
+	q, args := query.AddPagination(filter.Pagination).Build()
+
+	res, err := r.db.QueryContext(ctx, q, args...)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", fn, err)
 	}
+	defer res.Close()
 
-	actorsWithFilms := []*domains.ActorWithFilms{}
-	indexesOfActors := map[uint32]int{}
+	personsWithCredits := []*domains.PersonWithCredits{}
+	indexesOfPersons := map[uint32]int{}
 
 	for res.Next() {
-		actor := &domains.Actor{}
-		film := &domains.Film{}
-		err := res.Scan(&actor.ID, &actor.FullName, &actor.Gender, &actor.Birthday,
-			&film.ID, &film.Name, &film.Description, &film.ReleaseDate, &film.Rating)
+		person := &domains.Person{}
+		credit := &domains.FilmCredit{}
+		err := res.Scan(&person.ID, &person.FullName, &person.Gender, &person.Birthday,
+			&credit.ID, &credit.Name, &credit.Description, &credit.ReleaseDate, &credit.Rating,
+			&credit.Type, &credit.Character, &credit.BillingOrder)
 		if err != nil {
 			return nil, fmt.Errorf("%s: %w", fn, err)
 		}
-		if _, ok := indexesOfActors[actor.ID]; !ok {
-			actorsWithFilms = append(actorsWithFilms, &domains.ActorWithFilms{Actor: *actor, Films: []*domains.Film{}})
-			indexesOfActors[actor.ID] = len(actorsWithFilms) - 1
+		if _, ok := indexesOfPersons[person.ID]; !ok {
+			personsWithCredits = append(personsWithCredits, &domains.PersonWithCredits{Person: *person, Credits: []*domains.FilmCredit{}})
+			indexesOfPersons[person.ID] = len(personsWithCredits) - 1
 		}
-		films := &actorsWithFilms[indexesOfActors[actor.ID]].Films
-		*films = append(*films, film)
+		credits := &personsWithCredits[indexesOfPersons[person.ID]].Credits
+		*credits = append(*credits, credit)
+	}
+
+	if err := res.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", fn, err)
 	}
 
-	return actorsWithFilms, nil
+	return personsWithCredits, nil
 }
 
-func (r *ActorRepository) AddActorsToFilm(filmID uint32, actorsID []uint32) error {
+func (r *ActorRepository) GetActorByID(ctx context.Context, id uint32) (*domains.PersonWithCredits, error) {
+	fn := "actorRepository.GetActorByID"
+	ctx = querier.WithMethod(ctx, fn)
+
+	stmt := `
+		SELECT id, full_name, gender, birthday
+		FROM persons
+		WHERE id=$1;
+	`
+
+	person := &domains.PersonWithCredits{Credits: []*domains.FilmCredit{}}
+	row := r.db.QueryRowContext(ctx, stmt, id)
+	err := row.Scan(&person.ID, &person.FullName, &person.Gender, &person.Birthday)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("%s: %w", fn, ErrNotFound)
+		}
+		return nil, fmt.Errorf("%s: %w", fn, err)
+	}
+
+	stmt = `
+		SELECT f.id, f.name, f.description, f.release_date, f.rating,
+			c.type, COALESCE(c.character_name, ''), c.billing_order
+		FROM films AS f
+		JOIN credits AS c ON f.id=c.film_id
+		WHERE c.person_id=$1
+		ORDER BY f.release_date, c.type;
+	`
+
+	res, err := r.db.QueryContext(ctx, stmt, id)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", fn, err)
+	}
+	defer res.Close()
+
+	for res.Next() {
+		credit := &domains.FilmCredit{}
+		err = res.Scan(&credit.ID, &credit.Name, &credit.Description, &credit.ReleaseDate, &credit.Rating,
+			&credit.Type, &credit.Character, &credit.BillingOrder)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", fn, err)
+		}
+		person.Credits = append(person.Credits, credit)
+	}
+
+	if err := res.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", fn, err)
+	}
+
+	return person, nil
+}
+
+// AddActorsToFilm credits persons in the film. Empty character names are
+// stored as NULL.
+func (r *ActorRepository) AddActorsToFilm(ctx context.Context, filmID uint32, credits []domains.Credit) error {
 	fn := "actorRepository.AddActorsToFilm"
+	ctx = querier.WithMethod(ctx, fn)
 
-	if len(actorsID) == 0 {
+	if len(credits) == 0 {
 		return nil
 	}
 
-	var rowsBuilder strings.Builder
-	for _, id := range actorsID {
-		_, err := rowsBuilder.WriteString(fmt.Sprintf("(%d, %d),", id, filmID))
-		if err != nil {
-			return fmt.Errorf("%s: %w", fn, err)
+	rows := make([]string, 0, len(credits))
+	args := make([]any, 0, len(credits)*5)
+	for _, credit := range credits {
+		var character any
+		if credit.Character != "" {
+			character = credit.Character
 		}
+		n := len(args)
+		rows = append(rows, fmt.Sprintf("($%d, $%d, $%d, $%d, $%d)", n+1, n+2, n+3, n+4, n+5))
+		args = append(args, credit.PersonID, filmID, string(credit.Type), character, credit.BillingOrder)
 	}
-	rows := rowsBuilder.String()
-	rows = rows[:len(rows)-1]
 
 	stmt := fmt.Sprintf(`
-		INSERT INTO film_actor(actor_id, film_id) 
+		INSERT INTO credits(person_id, film_id, type, character_name, billing_order)
 		VALUES %s;
-	`, rows)
+	`, strings.Join(rows, ", "))
 
-	_, err := r.db.Exec(stmt)
+	_, err := r.db.ExecContext(ctx, stmt, args...)
 	if err != nil {
 		if err, ok := err.(*pq.Error); ok {
 			switch err.Constraint {
-			case "film_actor_pkey":
+			case "credits_pkey":
 				return fmt.Errorf("%s: %w", fn, ErrUniqueActors)
-			case "film_actor_actor_id_fkey":
+			case "credits_person_id_fkey":
 				return fmt.Errorf("%s: %w", fn, ErrNotFound)
-			case "film_actor_film_id_fkey":
+			case "credits_film_id_fkey":
 				return fmt.Errorf("%s: %w", fn, ErrNotFound)
+			case "credits_type_check":
+				return fmt.Errorf("%s: %w", fn, ErrInvalidCredit)
 			}
 		}
 		return fmt.Errorf("%s: %w", fn, err)
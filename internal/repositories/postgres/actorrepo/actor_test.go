@@ -1,6 +1,7 @@
 package actorrepo
 
 import (
+	"context"
 	"errors"
 	"film_library/internal/domains"
 	"film_library/pkg/pagination"
@@ -23,29 +24,29 @@ func TestActorRepoAdd(t *testing.T) {
 
 	repo := NewActorRepository(db)
 
-	type mockBehavior func(actor domains.Actor)
+	type mockBehavior func(actor domains.Person)
 
 	customError := fmt.Errorf("some error")
 	tests := []struct {
 		name  string
-		actor domains.Actor
+		actor domains.Person
 		mock  mockBehavior
 		err   error
 	}{
 		{
 			name:  "Correct",
-			actor: domains.Actor{FullName: "Robert Oppenheimer", Gender: "male", Birthday: domains.Time(time.Now())},
-			mock: func(actor domains.Actor) {
-				mock.ExpectExec("INSERT INTO actors").
+			actor: domains.Person{FullName: "Robert Oppenheimer", Gender: "male", Birthday: domains.Time(time.Now())},
+			mock: func(actor domains.Person) {
+				mock.ExpectExec("INSERT INTO persons").
 					WithArgs(actor.FullName, actor.Gender, time.Time(actor.Birthday)).
 					WillReturnResult(sqlmock.NewResult(1, 1))
 			},
 		},
 		{
 			name:  "Invalid gender",
-			actor: domains.Actor{FullName: "denis", Gender: "male2", Birthday: domains.Time(time.Now())},
-			mock: func(actor domains.Actor) {
-				mock.ExpectExec("INSERT INTO actors").
+			actor: domains.Person{FullName: "denis", Gender: "male2", Birthday: domains.Time(time.Now())},
+			mock: func(actor domains.Person) {
+				mock.ExpectExec("INSERT INTO persons").
 					WithArgs(actor.FullName, actor.Gender, time.Time(actor.Birthday)).
 					WillReturnError(&pq.Error{Code: pq.ErrorCode("23514")})
 			},
@@ -53,9 +54,9 @@ func TestActorRepoAdd(t *testing.T) {
 		},
 		{
 			name:  "Unknown error",
-			actor: domains.Actor{FullName: "123", Gender: "123", Birthday: domains.Time(time.Now())},
-			mock: func(actor domains.Actor) {
-				mock.ExpectExec("INSERT INTO actors").
+			actor: domains.Person{FullName: "123", Gender: "123", Birthday: domains.Time(time.Now())},
+			mock: func(actor domains.Person) {
+				mock.ExpectExec("INSERT INTO persons").
 					WithArgs(actor.FullName, actor.Gender, time.Time(actor.Birthday)).
 					WillReturnError(customError)
 			},
@@ -67,7 +68,7 @@ func TestActorRepoAdd(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			tc.mock(tc.actor)
 
-			err := repo.AddActor(tc.actor)
+			err := repo.AddActor(context.Background(), tc.actor)
 
 			if !errors.Is(err, tc.err) {
 				t.Errorf("expected: %s\ngot: %s", tc.err, err)
@@ -104,7 +105,7 @@ func TestActorRepoUpdateFullName(t *testing.T) {
 			id:       1,
 			fullName: "Robert",
 			mock: func(id uint32, fullName string) {
-				mock.ExpectExec("UPDATE actors").
+				mock.ExpectExec("UPDATE persons").
 					WithArgs(fullName, id).
 					WillReturnResult(sqlmock.NewResult(0, 1))
 			},
@@ -114,7 +115,7 @@ func TestActorRepoUpdateFullName(t *testing.T) {
 			id:       1,
 			fullName: "denis",
 			mock: func(id uint32, fullName string) {
-				mock.ExpectExec("UPDATE actors").
+				mock.ExpectExec("UPDATE persons").
 					WithArgs(fullName, id).
 					WillReturnResult(sqlmock.NewResult(0, 0))
 			},
@@ -125,7 +126,7 @@ func TestActorRepoUpdateFullName(t *testing.T) {
 			id:       1,
 			fullName: "aboba",
 			mock: func(id uint32, fullName string) {
-				mock.ExpectExec("UPDATE actors").
+				mock.ExpectExec("UPDATE persons").
 					WithArgs(fullName, id).
 					WillReturnError(customError)
 			},
@@ -137,7 +138,7 @@ func TestActorRepoUpdateFullName(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			tc.mock(tc.id, tc.fullName)
 
-			err := repo.UpdateActorFullName(tc.id, tc.fullName)
+			err := repo.UpdateActorFullName(context.Background(), tc.id, tc.fullName)
 
 			if !errors.Is(err, tc.err) {
 				t.Errorf("expected: %s\ngot: %s", tc.err, err)
@@ -174,7 +175,7 @@ func TestActorRepoUpdateGender(t *testing.T) {
 			id:     1,
 			gender: "female",
 			mock: func(id uint32, gender string) {
-				mock.ExpectExec("UPDATE actors").
+				mock.ExpectExec("UPDATE persons").
 					WithArgs(gender, id).
 					WillReturnResult(sqlmock.NewResult(0, 1))
 			},
@@ -184,7 +185,7 @@ func TestActorRepoUpdateGender(t *testing.T) {
 			id:     156,
 			gender: "male",
 			mock: func(id uint32, gender string) {
-				mock.ExpectExec("UPDATE actors").
+				mock.ExpectExec("UPDATE persons").
 					WithArgs(gender, id).
 					WillReturnResult(sqlmock.NewResult(0, 0))
 			},
@@ -195,7 +196,7 @@ func TestActorRepoUpdateGender(t *testing.T) {
 			id:     1,
 			gender: "aboba",
 			mock: func(id uint32, fullName string) {
-				mock.ExpectExec("UPDATE actors").
+				mock.ExpectExec("UPDATE persons").
 					WithArgs(fullName, id).
 					WillReturnError(&pq.Error{Code: pq.ErrorCode("23514")})
 			},
@@ -206,7 +207,7 @@ func TestActorRepoUpdateGender(t *testing.T) {
 			id:     2,
 			gender: "male",
 			mock: func(id uint32, fullName string) {
-				mock.ExpectExec("UPDATE actors").
+				mock.ExpectExec("UPDATE persons").
 					WithArgs(fullName, id).
 					WillReturnError(customError)
 			},
@@ -218,7 +219,7 @@ func TestActorRepoUpdateGender(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			tc.mock(tc.id, tc.gender)
 
-			err := repo.UpdateActorGender(tc.id, tc.gender)
+			err := repo.UpdateActorGender(context.Background(), tc.id, tc.gender)
 
 			if !errors.Is(err, tc.err) {
 				t.Errorf("expected: %s\ngot: %s", tc.err, err)
@@ -255,7 +256,7 @@ func TestActorRepoUpdateBirthday(t *testing.T) {
 			id:       1,
 			birthday: time.Now(),
 			mock: func(id uint32, birthday time.Time) {
-				mock.ExpectExec("UPDATE actors").
+				mock.ExpectExec("UPDATE persons").
 					WithArgs(birthday, id).
 					WillReturnResult(sqlmock.NewResult(0, 1))
 			},
@@ -265,7 +266,7 @@ func TestActorRepoUpdateBirthday(t *testing.T) {
 			id:       156,
 			birthday: time.Now(),
 			mock: func(id uint32, birthday time.Time) {
-				mock.ExpectExec("UPDATE actors").
+				mock.ExpectExec("UPDATE persons").
 					WithArgs(birthday, id).
 					WillReturnResult(sqlmock.NewResult(0, 0))
 			},
@@ -276,7 +277,7 @@ func TestActorRepoUpdateBirthday(t *testing.T) {
 			id:       2,
 			birthday: time.Now(),
 			mock: func(id uint32, birthday time.Time) {
-				mock.ExpectExec("UPDATE actors").
+				mock.ExpectExec("UPDATE persons").
 					WithArgs(birthday, id).
 					WillReturnError(customError)
 			},
@@ -288,7 +289,7 @@ func TestActorRepoUpdateBirthday(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			tc.mock(tc.id, tc.birthday)
 
-			err := repo.UpdateActorBirthday(tc.id, tc.birthday)
+			err := repo.UpdateActorBirthday(context.Background(), tc.id, tc.birthday)
 
 			if !errors.Is(err, tc.err) {
 				t.Errorf("expected: %s\ngot: %s", tc.err, err)
@@ -323,7 +324,7 @@ func TestActorRepoDelete(t *testing.T) {
 			name: "Correct",
 			id:   1,
 			mock: func(id uint32) {
-				mock.ExpectExec("DELETE FROM actors").
+				mock.ExpectExec("DELETE FROM persons").
 					WithArgs(id).
 					WillReturnResult(sqlmock.NewResult(0, 1))
 			},
@@ -332,7 +333,7 @@ func TestActorRepoDelete(t *testing.T) {
 			name: "Not found",
 			id:   156,
 			mock: func(id uint32) {
-				mock.ExpectExec("DELETE FROM actors").
+				mock.ExpectExec("DELETE FROM persons").
 					WithArgs(id).
 					WillReturnResult(sqlmock.NewResult(0, 0))
 			},
@@ -342,7 +343,7 @@ func TestActorRepoDelete(t *testing.T) {
 			name: "Not found",
 			id:   156,
 			mock: func(id uint32) {
-				mock.ExpectExec("DELETE FROM actors").
+				mock.ExpectExec("DELETE FROM persons").
 					WithArgs(id).
 					WillReturnError(customError)
 			},
@@ -354,7 +355,7 @@ func TestActorRepoDelete(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			tc.mock(tc.id)
 
-			err := repo.DeleteActor(tc.id)
+			err := repo.DeleteActor(context.Background(), tc.id)
 
 			if !errors.Is(err, tc.err) {
 				t.Errorf("expected: %s\ngot: %s", tc.err, err)
@@ -376,67 +377,84 @@ func TestActorRepoAddActors(t *testing.T) {
 
 	repo := NewActorRepository(db)
 
-	type mockBehavior func(filmID uint32, actorsID []uint32)
+	type mockBehavior func(filmID uint32, credits []domains.Credit)
 
 	customError := fmt.Errorf("some error")
 	tests := []struct {
-		name     string
-		filmID   uint32
-		actorsID []uint32
-		mock     mockBehavior
-		err      error
+		name    string
+		filmID  uint32
+		credits []domains.Credit
+		mock    mockBehavior
+		err     error
 	}{
 		{
-			name:     "Correct",
-			filmID:   1,
-			actorsID: []uint32{1, 2, 3, 4},
-			mock: func(filmID uint32, actorsID []uint32) {
-				mock.ExpectExec(regexp.QuoteMeta("INSERT INTO film_actor(actor_id, film_id) VALUES (1, 1),(2, 1),(3, 1),(4, 1);")).
-					WillReturnResult(sqlmock.NewResult(0, 3))
+			name:   "Correct",
+			filmID: 1,
+			credits: []domains.Credit{
+				{PersonID: 1, Type: domains.CreditActor, Character: "Forrest Gump", BillingOrder: 1},
+				{PersonID: 2, Type: domains.CreditDirector},
+			},
+			mock: func(filmID uint32, credits []domains.Credit) {
+				mock.ExpectExec(regexp.QuoteMeta("INSERT INTO credits(person_id, film_id, type, character_name, billing_order) VALUES ($1, $2, $3, $4, $5), ($6, $7, $8, $9, $10);")).
+					WithArgs(1, filmID, "actor", "Forrest Gump", 1, 2, filmID, "director", nil, 0).
+					WillReturnResult(sqlmock.NewResult(0, 2))
 			},
 		},
 		{
-			name:     "No actors",
-			filmID:   1,
-			actorsID: []uint32{},
-			mock:     func(filmID uint32, actorsID []uint32) {},
+			name:    "No credits",
+			filmID:  1,
+			credits: []domains.Credit{},
+			mock:    func(filmID uint32, credits []domains.Credit) {},
 		},
 		{
-			name:     "Not unique actors",
-			filmID:   1,
-			actorsID: []uint32{2, 2},
-			mock: func(filmID uint32, actorsID []uint32) {
-				mock.ExpectExec(regexp.QuoteMeta("INSERT INTO film_actor(actor_id, film_id) VALUES (2, 1),(2, 1);")).
-					WillReturnError(&pq.Error{Code: pq.ErrorCode("23505"), Constraint: "film_actor_pkey"})
+			name:   "Not unique credits",
+			filmID: 1,
+			credits: []domains.Credit{
+				{PersonID: 2, Type: domains.CreditActor},
+				{PersonID: 2, Type: domains.CreditActor},
+			},
+			mock: func(filmID uint32, credits []domains.Credit) {
+				mock.ExpectExec("INSERT INTO credits").
+					WillReturnError(&pq.Error{Code: pq.ErrorCode("23505"), Constraint: "credits_pkey"})
 			},
 			err: ErrUniqueActors,
 		},
 		{
-			name:     "Actor not found",
-			filmID:   1,
-			actorsID: []uint32{1024},
-			mock: func(filmID uint32, actorsID []uint32) {
-				mock.ExpectExec(regexp.QuoteMeta("INSERT INTO film_actor(actor_id, film_id) VALUES (1024, 1);")).
-					WillReturnError(&pq.Error{Code: pq.ErrorCode("23503"), Constraint: "film_actor_actor_id_fkey"})
+			name:    "Person not found",
+			filmID:  1,
+			credits: []domains.Credit{{PersonID: 1024, Type: domains.CreditActor}},
+			mock: func(filmID uint32, credits []domains.Credit) {
+				mock.ExpectExec("INSERT INTO credits").
+					WillReturnError(&pq.Error{Code: pq.ErrorCode("23503"), Constraint: "credits_person_id_fkey"})
 			},
 			err: ErrNotFound,
 		},
 		{
-			name:     "Film not found",
-			filmID:   1,
-			actorsID: []uint32{1024},
-			mock: func(filmID uint32, actorsID []uint32) {
-				mock.ExpectExec(regexp.QuoteMeta("INSERT INTO film_actor(actor_id, film_id) VALUES (1024, 1);")).
-					WillReturnError(&pq.Error{Code: pq.ErrorCode("23503"), Constraint: "film_actor_film_id_fkey"})
+			name:    "Film not found",
+			filmID:  1,
+			credits: []domains.Credit{{PersonID: 1024, Type: domains.CreditActor}},
+			mock: func(filmID uint32, credits []domains.Credit) {
+				mock.ExpectExec("INSERT INTO credits").
+					WillReturnError(&pq.Error{Code: pq.ErrorCode("23503"), Constraint: "credits_film_id_fkey"})
 			},
 			err: ErrNotFound,
 		},
 		{
-			name:     "Unknown error",
-			filmID:   2,
-			actorsID: []uint32{2},
-			mock: func(filmID uint32, actorsID []uint32) {
-				mock.ExpectExec("INSERT INTO film_actor").
+			name:    "Invalid credit type",
+			filmID:  1,
+			credits: []domains.Credit{{PersonID: 1, Type: "stuntman"}},
+			mock: func(filmID uint32, credits []domains.Credit) {
+				mock.ExpectExec("INSERT INTO credits").
+					WillReturnError(&pq.Error{Code: pq.ErrorCode("23514"), Constraint: "credits_type_check"})
+			},
+			err: ErrInvalidCredit,
+		},
+		{
+			name:    "Unknown error",
+			filmID:  2,
+			credits: []domains.Credit{{PersonID: 2, Type: domains.CreditActor}},
+			mock: func(filmID uint32, credits []domains.Credit) {
+				mock.ExpectExec("INSERT INTO credits").
 					WillReturnError(customError)
 			},
 			err: customError,
@@ -445,9 +463,9 @@ func TestActorRepoAddActors(t *testing.T) {
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			tc.mock(tc.filmID, tc.actorsID)
+			tc.mock(tc.filmID, tc.credits)
 
-			err := repo.AddActorsToFilm(tc.filmID, tc.actorsID)
+			err := repo.AddActorsToFilm(context.Background(), tc.filmID, tc.credits)
 
 			if !errors.Is(err, tc.err) {
 				t.Errorf("expected: %s\ngot: %s", tc.err, err)
@@ -471,12 +489,15 @@ func TestActorRepoGetActorsWithFilms(t *testing.T) {
 
 	type mockBehavior func(filter *pagination.ActorsFilter)
 
+	creditColumns := []string{"id", "full_name", "gender", "birthday",
+		"id", "name", "description", "release_date", "rating", "type", "character_name", "billing_order"}
+
 	// customError := fmt.Errorf("some error")
 	tests := []struct {
 		name            string
 		filter          *pagination.ActorsFilter
 		mock            mockBehavior
-		actorsWithFilms []*domains.ActorWithFilms
+		actorsWithFilms []*domains.PersonWithCredits
 		err             error
 	}{
 		{
@@ -486,23 +507,44 @@ func TestActorRepoGetActorsWithFilms(t *testing.T) {
 				FullNameContains: "Rob",
 			},
 			mock: func(filter *pagination.ActorsFilter) {
-				rows := sqlmock.NewRows([]string{"id", "full_name", "gender", "birthday", "id", "name", "description", "release_date", "rating"}).
-					AddRow(1, "Roby", "male", time.Now(), 1, "Oppenheimer", "", time.Now(), 10).
-					AddRow(1, "Roby", "male", time.Now(), 10, "Abobaheimer", "", time.Now(), 9).
-					AddRow(2, "Aboba", "female", time.Now(), 10, "Abobaheimer", "", time.Now(), 9)
-				mock.ExpectQuery(`SELECT a.id, a.full_name, a.gender, a.birthday, 
-					f.id, f.name, f.description, f.release_date, f.rating FROM actors AS a`).
-					WithArgs(strings.ToLower("%" + filter.FullNameContains + "%")).
+				rows := sqlmock.NewRows(creditColumns).
+					AddRow(1, "Roby", "male", time.Now(), 1, "Oppenheimer", "", time.Now(), 10, "actor", "Roby", 1).
+					AddRow(1, "Roby", "male", time.Now(), 10, "Abobaheimer", "", time.Now(), 9, "director", "", 0).
+					AddRow(2, "Aboba", "female", time.Now(), 10, "Abobaheimer", "", time.Now(), 9, "actor", "", 2)
+				mock.ExpectQuery(`SELECT p.id, p.full_name, p.gender, p.birthday,
+					f.id, f.name, f.description, f.release_date, f.rating,
+					c.type, (.+) FROM persons AS p`).
+					WithArgs(strings.ToLower("%"+filter.FullNameContains+"%"), 10, 0).
 					WillReturnRows(rows)
 			},
-			actorsWithFilms: []*domains.ActorWithFilms{
+			actorsWithFilms: []*domains.PersonWithCredits{
 				{
-					Actor: domains.Actor{ID: 1},
-					Films: []*domains.Film{{ID: 1}, {ID: 10}},
+					Person:  domains.Person{ID: 1},
+					Credits: []*domains.FilmCredit{{Film: domains.Film{ID: 1}}, {Film: domains.Film{ID: 10}}},
 				},
 				{
-					Actor: domains.Actor{ID: 2},
-					Films: []*domains.Film{{ID: 10}},
+					Person:  domains.Person{ID: 2},
+					Credits: []*domains.FilmCredit{{Film: domains.Film{ID: 10}}},
+				},
+			},
+		},
+		{
+			name: "Credit type",
+			filter: &pagination.ActorsFilter{
+				Pagination: pagination.New(1, 10),
+				CreditType: "director",
+			},
+			mock: func(filter *pagination.ActorsFilter) {
+				rows := sqlmock.NewRows(creditColumns).
+					AddRow(1, "Roby", "male", time.Now(), 10, "Abobaheimer", "", time.Now(), 9, "director", "", 0)
+				mock.ExpectQuery(`SELECT (.+) FROM persons AS p (.+) WHERE LOWER\(p.full_name\) LIKE \$1 AND c.type=\$2`).
+					WithArgs("%%", "director", 10, 0).
+					WillReturnRows(rows)
+			},
+			actorsWithFilms: []*domains.PersonWithCredits{
+				{
+					Person:  domains.Person{ID: 1},
+					Credits: []*domains.FilmCredit{{Film: domains.Film{ID: 10}, Type: domains.CreditDirector}},
 				},
 			},
 		},
@@ -512,7 +554,7 @@ func TestActorRepoGetActorsWithFilms(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			tc.mock(tc.filter)
 
-			got, err := repo.GetActorsWithFilms(tc.filter)
+			got, err := repo.GetActorsWithFilms(context.Background(), tc.filter)
 
 			if tc.err != nil {
 				if !errors.Is(err, tc.err) {
@@ -523,7 +565,7 @@ func TestActorRepoGetActorsWithFilms(t *testing.T) {
 					t.Errorf("expected: %#v\ngot: %#v", len(tc.actorsWithFilms), len(got))
 				}
 				for i := 0; i < len(got); i++ {
-					if got[i].ID != tc.actorsWithFilms[i].ID {
+					if got[i].ID != tc.actorsWithFilms[i].ID || len(got[i].Credits) != len(tc.actorsWithFilms[i].Credits) {
 						t.Errorf("expected: %#v\ngot: %#v", tc.actorsWithFilms, got)
 					}
 				}
@@ -535,3 +577,109 @@ func TestActorRepoGetActorsWithFilms(t *testing.T) {
 		})
 	}
 }
+
+func TestActorRepoGetActorByID(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+	defer db.Close()
+
+	repo := NewActorRepository(db)
+
+	type mockBehavior func(id uint32)
+
+	customError := fmt.Errorf("some error")
+	tests := []struct {
+		name  string
+		id    uint32
+		mock  mockBehavior
+		actor *domains.PersonWithCredits
+		err   error
+	}{
+		{
+			name: "Correct",
+			id:   1,
+			mock: func(id uint32) {
+				rows := sqlmock.NewRows([]string{"id", "full_name", "gender", "birthday"}).
+					AddRow(1, "Cillian Murphy", "male", time.Now())
+				mock.ExpectQuery("SELECT (.+) FROM persons WHERE (.+)").
+					WithArgs(id).
+					WillReturnRows(rows)
+
+				films := sqlmock.NewRows([]string{"id", "name", "description", "release_date", "rating", "type", "character_name", "billing_order"}).
+					AddRow(11, "Oppenheimer", "", time.Now(), 10, "actor", "J. Robert Oppenheimer", 1)
+				mock.ExpectQuery("SELECT (.+) FROM films AS f JOIN credits AS c (.+)").
+					WithArgs(id).
+					WillReturnRows(films)
+			},
+			actor: &domains.PersonWithCredits{
+				Person:  domains.Person{ID: 1},
+				Credits: []*domains.FilmCredit{{Film: domains.Film{ID: 11}}},
+			},
+		},
+		{
+			name: "Not found",
+			id:   1,
+			mock: func(id uint32) {
+				rows := sqlmock.NewRows([]string{"id", "full_name", "gender", "birthday"})
+				mock.ExpectQuery("SELECT (.+) FROM persons WHERE (.+)").
+					WithArgs(id).
+					WillReturnRows(rows)
+			},
+			err: ErrNotFound,
+		},
+		{
+			name: "Unknown error",
+			id:   1,
+			mock: func(id uint32) {
+				mock.ExpectQuery("SELECT (.+) FROM persons WHERE (.+)").
+					WithArgs(id).
+					WillReturnError(customError)
+			},
+			err: customError,
+		},
+		{
+			name: "Filmography cut short",
+			id:   1,
+			mock: func(id uint32) {
+				rows := sqlmock.NewRows([]string{"id", "full_name", "gender", "birthday"}).
+					AddRow(1, "Cillian Murphy", "male", time.Now())
+				mock.ExpectQuery("SELECT (.+) FROM persons WHERE (.+)").
+					WithArgs(id).
+					WillReturnRows(rows)
+
+				films := sqlmock.NewRows([]string{"id", "name", "description", "release_date", "rating", "type", "character_name", "billing_order"}).
+					AddRow(11, "Oppenheimer", "", time.Now(), 10, "actor", "J. Robert Oppenheimer", 1).
+					AddRow(12, "Inception", "", time.Now(), 9, "actor", "Robert Fischer", 5).
+					RowError(1, customError)
+				mock.ExpectQuery("SELECT (.+) FROM films AS f JOIN credits AS c (.+)").
+					WithArgs(id).
+					WillReturnRows(films)
+			},
+			err: customError,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mock(tc.id)
+
+			got, err := repo.GetActorByID(context.Background(), tc.id)
+
+			if tc.err != nil {
+				if !errors.Is(err, tc.err) {
+					t.Errorf("expected: %s\ngot: %s", tc.err, err)
+				}
+			} else {
+				if got == nil || got.ID != tc.actor.ID || len(got.Credits) != len(tc.actor.Credits) {
+					t.Errorf("expected: %#v\ngot: %#v", tc.actor, got)
+				}
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
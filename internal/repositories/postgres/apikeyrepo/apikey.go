@@ -0,0 +1,196 @@
+package apikeyrepo
+
+import (
+	"context"
+	"database/sql"
+	"film_library/internal/domains"
+	"film_library/pkg/pagination"
+	"film_library/pkg/sqltools/querier"
+	selectbuilder "film_library/pkg/sqltools/select_builder"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+var (
+	ErrNotFound = fmt.Errorf("api key not found")
+)
+
+// lastUsedPrecision limits last_used_at updates to one write per key per
+// minute, however often the key is used.
+const lastUsedPrecision = time.Minute
+
+type APIKeyRepository struct {
+	db querier.Querier
+}
+
+func NewAPIKeyRepository(db querier.Querier) *APIKeyRepository {
+	return &APIKeyRepository{
+		db: db,
+	}
+}
+
+func (r *APIKeyRepository) AddAPIKey(ctx context.Context, key domains.APIKey) (*domains.APIKey, error) {
+	fn := "apiKeyRepository.AddAPIKey"
+	ctx = querier.WithMethod(ctx, fn)
+
+	stmt := `
+		INSERT INTO api_keys(name, prefix, key_hash, permissions, created_by, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, created_at;
+	`
+
+	row := r.db.QueryRowContext(ctx, stmt, key.Name, key.Prefix, key.Hash,
+		pq.Array(permissionsToStrings(key.Permissions)), key.CreatedBy, key.ExpiresAt)
+	err := row.Scan(&key.ID, &key.CreatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", fn, err)
+	}
+
+	return &key, nil
+}
+
+// GetAPIKeyByHash returns the key with the role and state of its creator.
+func (r *APIKeyRepository) GetAPIKeyByHash(ctx context.Context, hash string) (*domains.APIKey, error) {
+	fn := "apiKeyRepository.GetAPIKeyByHash"
+	ctx = querier.WithMethod(ctx, fn)
+
+	stmt := `
+		SELECT k.id, k.name, k.prefix, k.key_hash, k.permissions, k.created_by, k.created_at, k.expires_at, k.last_used_at,
+			u.role, u.disabled
+		FROM api_keys k
+		JOIN users u ON u.id=k.created_by
+		WHERE k.key_hash=$1
+	`
+
+	key := &domains.APIKey{}
+	err := scanAPIKey(r.db.QueryRowContext(ctx, stmt, hash), key, &key.CreatorRole, &key.CreatorDisabled)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("%s: %w", fn, ErrNotFound)
+		}
+		return nil, fmt.Errorf("%s: %w", fn, err)
+	}
+
+	return key, nil
+}
+
+func (r *APIKeyRepository) GetAPIKeys(ctx context.Context, page *pagination.Pagination) ([]*domains.APIKey, error) {
+	fn := "apiKeyRepository.GetAPIKeys"
+	ctx = querier.WithMethod(ctx, fn)
+
+	q, args := selectbuilder.New(`SELECT id, name, prefix, key_hash, permissions, created_by, created_at, expires_at, last_used_at
+		FROM api_keys`).
+		SortColumns(map[string]string{"id": "id"}).
+		OrderBy("id", "asc").
+		AddPagination(page).
+		Build()
+
+	res, err := r.db.QueryContext(ctx, q, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", fn, err)
+	}
+	defer res.Close()
+
+	keys := []*domains.APIKey{}
+	for res.Next() {
+		key := &domains.APIKey{}
+		err := scanAPIKey(res, key)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", fn, err)
+		}
+		keys = append(keys, key)
+	}
+
+	if err := res.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", fn, err)
+	}
+
+	return keys, nil
+}
+
+func (r *APIKeyRepository) TouchAPIKey(ctx context.Context, id uint32) error {
+	fn := "apiKeyRepository.TouchAPIKey"
+	ctx = querier.WithMethod(ctx, fn)
+
+	stmt := `
+		UPDATE api_keys
+		SET last_used_at=now()
+		WHERE id=$1 AND (last_used_at IS NULL OR last_used_at < now() - $2::interval)
+	`
+
+	_, err := r.db.ExecContext(ctx, stmt, id, lastUsedPrecision.String())
+	if err != nil {
+		return fmt.Errorf("%s: %w", fn, err)
+	}
+
+	return nil
+}
+
+func (r *APIKeyRepository) DeleteAPIKey(ctx context.Context, id uint32) error {
+	fn := "apiKeyRepository.DeleteAPIKey"
+	ctx = querier.WithMethod(ctx, fn)
+
+	stmt := `
+		DELETE FROM api_keys
+		WHERE id=$1
+	`
+
+	res, err := r.db.ExecContext(ctx, stmt, id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", fn, err)
+	}
+
+	rowsAff, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", fn, err)
+	}
+
+	if rowsAff == 0 {
+		return fmt.Errorf("%s: %w", fn, ErrNotFound)
+	}
+
+	return nil
+}
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+// scanAPIKey scans the columns of api_keys into key, followed by extra.
+func scanAPIKey(row scanner, key *domains.APIKey, extra ...any) error {
+	var (
+		permissions []string
+		expiresAt   sql.NullTime
+		lastUsedAt  sql.NullTime
+	)
+
+	dest := []any{&key.ID, &key.Name, &key.Prefix, &key.Hash, pq.Array(&permissions),
+		&key.CreatedBy, &key.CreatedAt, &expiresAt, &lastUsedAt}
+	err := row.Scan(append(dest, extra...)...)
+	if err != nil {
+		return err
+	}
+
+	key.Permissions = make([]domains.Permission, 0, len(permissions))
+	for _, p := range permissions {
+		key.Permissions = append(key.Permissions, domains.Permission(p))
+	}
+	if expiresAt.Valid {
+		key.ExpiresAt = &expiresAt.Time
+	}
+	if lastUsedAt.Valid {
+		key.LastUsedAt = &lastUsedAt.Time
+	}
+
+	return nil
+}
+
+func permissionsToStrings(permissions []domains.Permission) []string {
+	s := make([]string, 0, len(permissions))
+	for _, p := range permissions {
+		s = append(s, string(p))
+	}
+	return s
+}
@@ -1,9 +1,11 @@
 package filmrepo
 
 import (
+	"context"
 	"database/sql"
 	"film_library/internal/domains"
 	"film_library/pkg/pagination"
+	"film_library/pkg/sqltools/querier"
 	selectbuilder "film_library/pkg/sqltools/select_builder"
 	"fmt"
 	"strings"
@@ -19,18 +21,30 @@ var (
 	ErrAlreadyExists     = fmt.Errorf("film already exists")
 )
 
+// filmSelectColumns are the columns of films AS f expected by scanFilm.
+const filmSelectColumns = `f.id, f.name, f.description, f.release_date, f.rating,
+	f.rating_count, f.rating_sum, f.rating_score`
+
+var sortColumns = map[string]string{
+	"name":         "f.name",
+	"rating":       "f.rating",
+	"release_date": "f.release_date",
+	"score":        "f.rating_score",
+}
+
 type FilmRepository struct {
-	db *sql.DB
+	db querier.Querier
 }
 
-func NewFilmRepository(db *sql.DB) *FilmRepository {
+func NewFilmRepository(db querier.Querier) *FilmRepository {
 	return &FilmRepository{
 		db: db,
 	}
 }
 
-func (r *FilmRepository) AddFilm(film domains.Film) (uint32, error) {
+func (r *FilmRepository) AddFilm(ctx context.Context, film domains.Film) (uint32, error) {
 	fn := "filmRepository.AddFilm"
+	ctx = querier.WithMethod(ctx, fn)
 
 	stmt := `
 		INSERT INTO films(name, description, release_date, rating)
@@ -39,7 +53,7 @@ func (r *FilmRepository) AddFilm(film domains.Film) (uint32, error) {
 	`
 
 	var filmID int
-	row := r.db.QueryRow(stmt, film.Name, film.Description, time.Time(film.ReleaseDate), film.Rating)
+	row := r.db.QueryRowContext(ctx, stmt, film.Name, film.Description, time.Time(film.ReleaseDate), film.Rating)
 	err := row.Scan(&filmID)
 	if err != nil {
 		if err, ok := err.(*pq.Error); ok {
@@ -58,14 +72,14 @@ func (r *FilmRepository) AddFilm(film domains.Film) (uint32, error) {
 	return uint32(filmID), nil
 }
 
-func (r *FilmRepository) updateField(id uint32, field string, value any) error {
+func (r *FilmRepository) updateField(ctx context.Context, id uint32, field string, value any) error {
 	stmt := fmt.Sprintf(`
 		UPDATE films
 		SET %s=$1
 		WHERE id=$2
 	`, field)
 
-	res, err := r.db.Exec(stmt, value, id)
+	res, err := r.db.ExecContext(ctx, stmt, value, id)
 	if err != nil {
 		return err
 	}
@@ -82,9 +96,10 @@ func (r *FilmRepository) updateField(id uint32, field string, value any) error {
 	return nil
 }
 
-func (r *FilmRepository) UpdateFilmName(id uint32, name string) error {
+func (r *FilmRepository) UpdateFilmName(ctx context.Context, id uint32, name string) error {
 	fn := "filmRepository.UpdateFilmName"
-	if err := r.updateField(id, "name", name); err != nil {
+	ctx = querier.WithMethod(ctx, fn)
+	if err := r.updateField(ctx, id, "name", name); err != nil {
 		if err, ok := err.(*pq.Error); ok {
 			switch err.Constraint {
 			case "films_name_key":
@@ -98,25 +113,28 @@ func (r *FilmRepository) UpdateFilmName(id uint32, name string) error {
 	return nil
 }
 
-func (r *FilmRepository) UpdateFilmDescription(id uint32, description string) error {
+func (r *FilmRepository) UpdateFilmDescription(ctx context.Context, id uint32, description string) error {
 	fn := "filmRepository.UpdateFilmDescription"
-	if err := r.updateField(id, "description", description); err != nil {
+	ctx = querier.WithMethod(ctx, fn)
+	if err := r.updateField(ctx, id, "description", description); err != nil {
 		return fmt.Errorf("%s: %w", fn, err)
 	}
 	return nil
 }
 
-func (r *FilmRepository) UpdateFilmReleaseDate(id uint32, releaseDate time.Time) error {
+func (r *FilmRepository) UpdateFilmReleaseDate(ctx context.Context, id uint32, releaseDate time.Time) error {
 	fn := "filmRepository.UpdateFilmReleaseDate"
-	if err := r.updateField(id, "release_date", releaseDate); err != nil {
+	ctx = querier.WithMethod(ctx, fn)
+	if err := r.updateField(ctx, id, "release_date", releaseDate); err != nil {
 		return fmt.Errorf("%s: %w", fn, err)
 	}
 	return nil
 }
 
-func (r *FilmRepository) UpdateFilmRating(id uint32, rating int) error {
-	fn := "filmRepository.UpdateFilmReleaseDate"
-	if err := r.updateField(id, "rating", rating); err != nil {
+func (r *FilmRepository) UpdateFilmRating(ctx context.Context, id uint32, rating int) error {
+	fn := "filmRepository.UpdateFilmRating"
+	ctx = querier.WithMethod(ctx, fn)
+	if err := r.updateField(ctx, id, "rating", rating); err != nil {
 		if err, ok := err.(*pq.Error); ok && err.Code == pq.ErrorCode("23514") {
 			return fmt.Errorf("%s: %w", fn, ErrInvalidRating)
 		}
@@ -125,15 +143,16 @@ func (r *FilmRepository) UpdateFilmRating(id uint32, rating int) error {
 	return nil
 }
 
-func (r *FilmRepository) UpdateFilm(id uint32, film domains.Film) error {
-	fn := "actorRepository.UpdateFilm"
+func (r *FilmRepository) UpdateFilm(ctx context.Context, id uint32, film domains.Film) error {
+	fn := "filmRepository.UpdateFilm"
+	ctx = querier.WithMethod(ctx, fn)
 
 	stmt := `
 		UPDATE films
 		SET (name, description, release_date, rating) = ($1, $2, $3, $4)
 		WHERE id=$5;
 	`
-	res, err := r.db.Exec(stmt, film.Name, film.Description, time.Time(film.ReleaseDate), film.Rating, id)
+	res, err := r.db.ExecContext(ctx, stmt, film.Name, film.Description, time.Time(film.ReleaseDate), film.Rating, id)
 	if err != nil {
 		return fmt.Errorf("%s: %w", fn, err)
 	}
@@ -150,15 +169,16 @@ func (r *FilmRepository) UpdateFilm(id uint32, film domains.Film) error {
 	return nil
 }
 
-func (r *FilmRepository) DeleteFilm(id uint32) error {
+func (r *FilmRepository) DeleteFilm(ctx context.Context, id uint32) error {
 	fn := "filmRepository.DeleteFilm"
+	ctx = querier.WithMethod(ctx, fn)
 
 	stmt := `
 		DELETE FROM films
 		WHERE id=$1
 	`
 
-	res, err := r.db.Exec(stmt, id)
+	res, err := r.db.ExecContext(ctx, stmt, id)
 	if err != nil {
 		return fmt.Errorf("%s: %w", fn, err)
 	}
@@ -175,35 +195,181 @@ func (r *FilmRepository) DeleteFilm(id uint32) error {
 	return nil
 }
 
-func (r *FilmRepository) GetFilms(filter *pagination.FilmFilter) ([]*domains.Film, error) {
+func (r *FilmRepository) GetFilms(ctx context.Context, filter *pagination.FilmFilter) ([]*domains.Film, error) {
 	fn := "filmRepository.GetFilms"
+	ctx = querier.WithMethod(ctx, fn)
 
-	query := selectbuilder.New("SELECT DISTINCT f.id, f.name, f.description, f.release_date, f.rating FROM films AS f")
-	if filter.ActorNameContains != "" {
-		query.Join("film_actor AS fa ON f.id=fa.film_id").
-			Join("actors AS a ON a.id=fa.actor_id").
-			Where("LOWER(a.full_name) LIKE '%s'", "%"+strings.ToLower(filter.ActorNameContains)+"%")
-	}
+	q, args := filterFilms(selectbuilder.New(`SELECT DISTINCT `+filmSelectColumns+` FROM films AS f`), filter, sortColumns).Build()
 
-	q := query.Where("LOWER(f.name) LIKE '%s'", "%"+strings.ToLower(filter.NameContains)+"%").
-		OrderBy(filter.OrderBy, filter.Direction).
-		AddPagination(filter.Pagination).
-		Build()
-
-	res, err := r.db.Query(q)
+	res, err := r.db.QueryContext(ctx, q, args...)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", fn, err)
 	}
+	defer res.Close()
 
 	films := []*domains.Film{}
 	for res.Next() {
 		film := &domains.Film{}
-		err = res.Scan(&film.ID, &film.Name, &film.Description, &film.ReleaseDate, &film.Rating)
+		err = scanFilm(res, film)
 		if err != nil {
 			return nil, fmt.Errorf("%s: %w", fn, err)
 		}
 		films = append(films, film)
 	}
 
+	if err := res.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", fn, err)
+	}
+
+	if err := r.addGenres(ctx, films); err != nil {
+		return nil, fmt.Errorf("%s: %w", fn, err)
+	}
+
+	if err := r.addUserFlags(ctx, films, filter.UserID); err != nil {
+		return nil, fmt.Errorf("%s: %w", fn, err)
+	}
+
 	return films, nil
 }
+
+// filterFilms adds the conditions, the order and the page of filter to query,
+// which selects from films AS f.
+func filterFilms(query *selectbuilder.SelectQueryBuilder, filter *pagination.FilmFilter, columns map[string]string) *selectbuilder.SelectQueryBuilder {
+	if filter.ActorNameContains != "" {
+		query.Join("credits AS c ON f.id=c.film_id AND c.type='actor'").
+			Join("persons AS p ON p.id=c.person_id").
+			Where("LOWER(p.full_name) LIKE ?", selectbuilder.Contains(strings.ToLower(filter.ActorNameContains)))
+	}
+
+	if len(filter.Genres) != 0 {
+		genres := `f.id IN (SELECT fg.film_id FROM film_genre AS fg JOIN genres AS g ON g.id=fg.genre_id
+			WHERE LOWER(g.name)=ANY(?)`
+		if filter.GenreMatch == pagination.GenreMatchAll {
+			query.Where(genres+" GROUP BY fg.film_id HAVING COUNT(*)=?)", pq.Array(filter.Genres), len(filter.Genres))
+		} else {
+			query.Where(genres+")", pq.Array(filter.Genres))
+		}
+	}
+
+	return query.Where("LOWER(f.name) LIKE ?", selectbuilder.Contains(strings.ToLower(filter.NameContains))).
+		SortColumns(columns).
+		OrderBy(filter.OrderBy, filter.Direction).
+		AddPagination(filter.Pagination)
+}
+
+func (r *FilmRepository) GetFilmByID(ctx context.Context, id uint32) (*domains.FilmWithCredits, error) {
+	fn := "filmRepository.GetFilmByID"
+	ctx = querier.WithMethod(ctx, fn)
+
+	stmt := `
+		SELECT id, name, description, release_date, rating, rating_count, rating_sum, rating_score
+		FROM films
+		WHERE id=$1;
+	`
+
+	film := &domains.FilmWithCredits{Credits: []*domains.PersonCredit{}}
+	row := r.db.QueryRowContext(ctx, stmt, id)
+	err := scanFilm(row, &film.Film)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("%s: %w", fn, ErrNotFound)
+		}
+		return nil, fmt.Errorf("%s: %w", fn, err)
+	}
+
+	stmt = `
+		SELECT p.id, p.full_name, p.gender, p.birthday, c.type, COALESCE(c.character_name, ''), c.billing_order
+		FROM persons AS p
+		JOIN credits AS c ON p.id=c.person_id
+		WHERE c.film_id=$1
+		ORDER BY c.billing_order, p.id;
+	`
+
+	res, err := r.db.QueryContext(ctx, stmt, id)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", fn, err)
+	}
+	defer res.Close()
+
+	for res.Next() {
+		credit := &domains.PersonCredit{}
+		err = res.Scan(&credit.ID, &credit.FullName, &credit.Gender, &credit.Birthday,
+			&credit.Type, &credit.Character, &credit.BillingOrder)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", fn, err)
+		}
+		film.Credits = append(film.Credits, credit)
+	}
+
+	if err := res.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", fn, err)
+	}
+
+	if err := r.addGenres(ctx, []*domains.Film{&film.Film}); err != nil {
+		return nil, fmt.Errorf("%s: %w", fn, err)
+	}
+
+	return film, nil
+}
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+// scanFilm scans the film columns followed by rating_count, rating_sum,
+// rating_score and the columns scanned into extra.
+func scanFilm(row scanner, film *domains.Film, extra ...any) error {
+	var (
+		count, sum int
+		bayesian   float64
+	)
+	dest := []any{&film.ID, &film.Name, &film.Description, &film.ReleaseDate, &film.Rating, &count, &sum, &bayesian}
+	err := row.Scan(append(dest, extra...)...)
+	if err != nil {
+		return err
+	}
+	film.Score = domains.NewFilmScore(count, sum, bayesian)
+	return nil
+}
+
+// addGenres loads the genres of films with a single query.
+func (r *FilmRepository) addGenres(ctx context.Context, films []*domains.Film) error {
+	if len(films) == 0 {
+		return nil
+	}
+
+	filmsID := make([]int64, 0, len(films))
+	indexesOfFilms := make(map[uint32]int, len(films))
+	for i, film := range films {
+		film.Genres = []*domains.Genre{}
+		filmsID = append(filmsID, int64(film.ID))
+		indexesOfFilms[film.ID] = i
+	}
+
+	stmt := `
+		SELECT fg.film_id, g.id, g.name
+		FROM genres AS g
+		JOIN film_genre AS fg ON g.id=fg.genre_id
+		WHERE fg.film_id=ANY($1)
+		ORDER BY g.name;
+	`
+
+	res, err := r.db.QueryContext(ctx, stmt, pq.Array(filmsID))
+	if err != nil {
+		return err
+	}
+	defer res.Close()
+
+	for res.Next() {
+		var filmID uint32
+		genre := &domains.Genre{}
+		err := res.Scan(&filmID, &genre.ID, &genre.Name)
+		if err != nil {
+			return err
+		}
+		film := films[indexesOfFilms[filmID]]
+		film.Genres = append(film.Genres, genre)
+	}
+
+	return res.Err()
+}
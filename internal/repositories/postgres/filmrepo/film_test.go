@@ -1,6 +1,7 @@
 package filmrepo
 
 import (
+	"context"
 	"errors"
 	"film_library/internal/domains"
 	"film_library/pkg/pagination"
@@ -88,7 +89,7 @@ func TestFilmRepoAdd(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			tc.mock(tc.film)
 
-			got, err := repo.AddFilm(tc.film)
+			got, err := repo.AddFilm(context.Background(), tc.film)
 
 			if tc.err != nil {
 				if !errors.Is(err, tc.err) {
@@ -107,6 +108,8 @@ func TestFilmRepoAdd(t *testing.T) {
 	}
 }
 
+var filmColumns = []string{"id", "name", "description", "release_date", "rating", "rating_count", "rating_sum", "rating_score"}
+
 func TestFilmRepoGetFilms(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	if err != nil {
@@ -134,12 +137,69 @@ func TestFilmRepoGetFilms(t *testing.T) {
 				ActorNameContains: "rob",
 			},
 			mock: func(filter *pagination.FilmFilter) {
-				rows := sqlmock.NewRows([]string{"id", "name", "description", "release_date", "rating"}).
-					AddRow(1, "Oppenheimer", "", time.Now(), 10)
-				mock.ExpectQuery("SELECT DISTINCT f.id, f.name, f.description, f.release_date, f.rating FROM films AS f").
+				rows := sqlmock.NewRows(filmColumns).
+					AddRow(1, "Oppenheimer", "", time.Now(), 10, 4, 34, 7.14)
+				mock.ExpectQuery("SELECT DISTINCT f.id, f.name, f.description, f.release_date, f.rating,").
+					WithArgs("%rob%", "%oppen%", 10, 0).
+					WillReturnRows(rows)
+
+				genres := sqlmock.NewRows([]string{"film_id", "id", "name"}).AddRow(1, 1, "drama")
+				mock.ExpectQuery("SELECT (.+) FROM genres AS g JOIN film_genre AS fg (.+)").
+					WillReturnRows(genres)
+			},
+			films: []*domains.Film{{ID: 1, Name: "Oppenheimer", ReleaseDate: domains.Time(time.Now()), Rating: 10,
+				Genres: []*domains.Genre{{ID: 1, Name: "drama"}},
+				Score:  &domains.FilmScore{Mean: 8.5, Count: 4, Bayesian: 7.14}}},
+		},
+		{
+			name: "Any genre",
+			filter: &pagination.FilmFilter{
+				Pagination: pagination.New(1, 10),
+				Genres:     []string{"drama", "war"},
+				GenreMatch: pagination.GenreMatchAny,
+			},
+			mock: func(filter *pagination.FilmFilter) {
+				rows := sqlmock.NewRows(filmColumns)
+				mock.ExpectQuery(`SELECT (.+) FROM films AS f WHERE f.id IN \(SELECT fg.film_id (.+) WHERE LOWER\(g.name\)=ANY\(\$1\)\) AND LOWER\(f.name\) LIKE \$2`).
+					WithArgs(pq.Array(filter.Genres), "%%", 10, 0).
+					WillReturnRows(rows)
+			},
+			films: []*domains.Film{},
+		},
+		{
+			name: "All genres",
+			filter: &pagination.FilmFilter{
+				Pagination: pagination.New(1, 10),
+				Genres:     []string{"drama", "war"},
+				GenreMatch: pagination.GenreMatchAll,
+			},
+			mock: func(filter *pagination.FilmFilter) {
+				rows := sqlmock.NewRows(filmColumns)
+				mock.ExpectQuery(`SELECT (.+) FROM films AS f WHERE f.id IN \(SELECT fg.film_id (.+) GROUP BY fg.film_id HAVING COUNT\(\*\)=\$2\)`).
+					WithArgs(pq.Array(filter.Genres), 2, "%%", 10, 0).
+					WillReturnRows(rows)
+			},
+			films: []*domains.Film{},
+		},
+		{
+			name: "Sort by score",
+			filter: &pagination.FilmFilter{
+				Pagination: pagination.New(1, 10),
+				OrderBy:    "score",
+				Direction:  "desc",
+			},
+			mock: func(filter *pagination.FilmFilter) {
+				rows := sqlmock.NewRows(filmColumns).
+					AddRow(2, "Inception", "", time.Now(), 9, 0, 0, 0)
+				mock.ExpectQuery(`SELECT (.+) FROM films AS f WHERE LOWER\(f.name\) LIKE \$1 ORDER BY f.rating_score desc`).
+					WithArgs("%%", 10, 0).
 					WillReturnRows(rows)
+
+				genres := sqlmock.NewRows([]string{"film_id", "id", "name"})
+				mock.ExpectQuery("SELECT (.+) FROM genres AS g JOIN film_genre AS fg (.+)").
+					WillReturnRows(genres)
 			},
-			films: []*domains.Film{{ID: 1, Name: "Oppenheimer", ReleaseDate: domains.Time(time.Now()), Rating: 10}},
+			films: []*domains.Film{{ID: 2, Score: &domains.FilmScore{}}},
 		},
 	}
 
@@ -147,7 +207,7 @@ func TestFilmRepoGetFilms(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			tc.mock(tc.filter)
 
-			got, err := repo.GetFilms(tc.filter)
+			got, err := repo.GetFilms(context.Background(), tc.filter)
 
 			if tc.err != nil {
 				if !errors.Is(err, tc.err) {
@@ -158,7 +218,8 @@ func TestFilmRepoGetFilms(t *testing.T) {
 					t.Errorf("expected: %#v\ngot: %#v", len(tc.films), len(got))
 				}
 				for i := 0; i < len(got); i++ {
-					if got[i].ID != tc.films[i].ID {
+					if got[i].ID != tc.films[i].ID || len(got[i].Genres) != len(tc.films[i].Genres) ||
+						*got[i].Score != *tc.films[i].Score {
 						t.Errorf("expected: %#v\ngot: %#v", tc.films, got)
 					}
 				}
@@ -174,3 +235,95 @@ func TestFilmRepoGetFilms(t *testing.T) {
 		})
 	}
 }
+
+func TestFilmRepoGetFilmByID(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+	defer db.Close()
+
+	repo := NewFilmRepository(db)
+
+	type mockBehavior func(id uint32)
+
+	customError := fmt.Errorf("some error")
+	tests := []struct {
+		name string
+		id   uint32
+		mock mockBehavior
+		film *domains.FilmWithCredits
+		err  error
+	}{
+		{
+			name: "Correct",
+			id:   1,
+			mock: func(id uint32) {
+				rows := sqlmock.NewRows(filmColumns).
+					AddRow(1, "Oppenheimer", "", time.Now(), 10, 0, 0, 0)
+				mock.ExpectQuery("SELECT (.+) FROM films WHERE (.+)").
+					WithArgs(id).
+					WillReturnRows(rows)
+
+				credits := sqlmock.NewRows([]string{"id", "full_name", "gender", "birthday", "type", "character_name", "billing_order"}).
+					AddRow(1, "Cillian Murphy", "male", time.Now(), "actor", "J. Robert Oppenheimer", 1).
+					AddRow(2, "Emily Blunt", "female", time.Now(), "actor", "Kitty Oppenheimer", 2).
+					AddRow(3, "Christopher Nolan", "male", time.Now(), "director", "", 0)
+				mock.ExpectQuery("SELECT (.+) FROM persons AS p JOIN credits AS c (.+)").
+					WithArgs(id).
+					WillReturnRows(credits)
+
+				genres := sqlmock.NewRows([]string{"film_id", "id", "name"})
+				mock.ExpectQuery("SELECT (.+) FROM genres AS g JOIN film_genre AS fg (.+)").
+					WillReturnRows(genres)
+			},
+			film: &domains.FilmWithCredits{
+				Film:    domains.Film{ID: 1},
+				Credits: []*domains.PersonCredit{{Person: domains.Person{ID: 1}}, {Person: domains.Person{ID: 2}}, {Person: domains.Person{ID: 3}}},
+			},
+		},
+		{
+			name: "Not found",
+			id:   1,
+			mock: func(id uint32) {
+				rows := sqlmock.NewRows(filmColumns)
+				mock.ExpectQuery("SELECT (.+) FROM films WHERE (.+)").
+					WithArgs(id).
+					WillReturnRows(rows)
+			},
+			err: ErrNotFound,
+		},
+		{
+			name: "Unknown error",
+			id:   1,
+			mock: func(id uint32) {
+				mock.ExpectQuery("SELECT (.+) FROM films WHERE (.+)").
+					WithArgs(id).
+					WillReturnError(customError)
+			},
+			err: customError,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mock(tc.id)
+
+			got, err := repo.GetFilmByID(context.Background(), tc.id)
+
+			if tc.err != nil {
+				if !errors.Is(err, tc.err) {
+					t.Errorf("expected: %s\ngot: %s", tc.err, err)
+				}
+			} else {
+				if got == nil || got.ID != tc.film.ID || len(got.Credits) != len(tc.film.Credits) {
+					t.Errorf("expected: %#v\ngot: %#v", tc.film, got)
+				}
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
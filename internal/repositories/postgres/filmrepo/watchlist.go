@@ -0,0 +1,277 @@
+package filmrepo
+
+import (
+	"context"
+	"film_library/internal/domains"
+	"film_library/pkg/pagination"
+	"film_library/pkg/sqltools/querier"
+	selectbuilder "film_library/pkg/sqltools/select_builder"
+	"fmt"
+	"maps"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+var (
+	ErrNotInWatchlist      = fmt.Errorf("film not in watchlist")
+	ErrNotWatched          = fmt.Errorf("film not watched")
+	ErrInvalidRewatchCount = fmt.Errorf("invalid rewatch count")
+)
+
+// The watchlist and the watched history are also sorted by the time films
+// were added or watched.
+var (
+	watchlistSortColumns = withSortColumn(pagination.WatchlistSortAddedAt, "wl.added_at")
+	watchedSortColumns   = withSortColumn(pagination.WatchedSortWatchedAt, "wf.watched_at")
+)
+
+func withSortColumn(key, column string) map[string]string {
+	columns := maps.Clone(sortColumns)
+	columns[key] = column
+	return columns
+}
+
+// AddToWatchlist adds the film to the watchlist of the user. Adding a film
+// that is already there keeps the time it was first added.
+func (r *FilmRepository) AddToWatchlist(ctx context.Context, userID, filmID uint32) error {
+	fn := "filmRepository.AddToWatchlist"
+	ctx = querier.WithMethod(ctx, fn)
+
+	stmt := `
+		INSERT INTO watchlist(user_id, film_id)
+		VALUES ($1, $2)
+		ON CONFLICT (user_id, film_id) DO NOTHING;
+	`
+
+	_, err := r.db.ExecContext(ctx, stmt, userID, filmID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", fn, mapWatchError(err))
+	}
+
+	return nil
+}
+
+func (r *FilmRepository) DeleteFromWatchlist(ctx context.Context, userID, filmID uint32) error {
+	fn := "filmRepository.DeleteFromWatchlist"
+	ctx = querier.WithMethod(ctx, fn)
+
+	stmt := `
+		DELETE FROM watchlist
+		WHERE user_id=$1 AND film_id=$2;
+	`
+
+	res, err := r.db.ExecContext(ctx, stmt, userID, filmID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", fn, err)
+	}
+
+	rowsAff, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", fn, err)
+	}
+
+	if rowsAff == 0 {
+		return fmt.Errorf("%s: %w", fn, ErrNotInWatchlist)
+	}
+
+	return nil
+}
+
+// GetWatchlist returns the films in the watchlist of filter.UserID.
+func (r *FilmRepository) GetWatchlist(ctx context.Context, filter *pagination.FilmFilter) ([]*domains.WatchlistEntry, error) {
+	fn := "filmRepository.GetWatchlist"
+	ctx = querier.WithMethod(ctx, fn)
+
+	query := selectbuilder.New(`SELECT DISTINCT `+filmSelectColumns+`, wl.added_at FROM films AS f`).
+		Join("watchlist AS wl ON wl.film_id=f.id").
+		Where("wl.user_id=?", filter.UserID)
+	q, args := filterFilms(query, filter, watchlistSortColumns).Build()
+
+	res, err := r.db.QueryContext(ctx, q, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", fn, err)
+	}
+	defer res.Close()
+
+	entries := []*domains.WatchlistEntry{}
+	films := []*domains.Film{}
+	for res.Next() {
+		entry := &domains.WatchlistEntry{}
+		err = scanFilm(res, &entry.Film, &entry.AddedAt)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", fn, err)
+		}
+		entries = append(entries, entry)
+		films = append(films, &entry.Film)
+	}
+
+	if err := res.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", fn, err)
+	}
+
+	if err := r.addGenres(ctx, films); err != nil {
+		return nil, fmt.Errorf("%s: %w", fn, err)
+	}
+
+	if err := r.addUserFlags(ctx, films, filter.UserID); err != nil {
+		return nil, fmt.Errorf("%s: %w", fn, err)
+	}
+
+	return entries, nil
+}
+
+// SetWatched records that the user watched the film on watchedAt. A nil
+// rewatchCount is 0 for a film watched for the first time and keeps the
+// stored count otherwise.
+func (r *FilmRepository) SetWatched(ctx context.Context, userID, filmID uint32, watchedAt time.Time, rewatchCount *int) error {
+	fn := "filmRepository.SetWatched"
+	ctx = querier.WithMethod(ctx, fn)
+
+	stmt := `
+		INSERT INTO watched_films(user_id, film_id, watched_at, rewatch_count)
+		VALUES ($1, $2, $3, COALESCE($4, 0))
+		ON CONFLICT (user_id, film_id) DO UPDATE
+		SET watched_at=EXCLUDED.watched_at,
+			rewatch_count=COALESCE($4, watched_films.rewatch_count);
+	`
+
+	_, err := r.db.ExecContext(ctx, stmt, userID, filmID, watchedAt, rewatchCount)
+	if err != nil {
+		return fmt.Errorf("%s: %w", fn, mapWatchError(err))
+	}
+
+	return nil
+}
+
+func (r *FilmRepository) DeleteWatched(ctx context.Context, userID, filmID uint32) error {
+	fn := "filmRepository.DeleteWatched"
+	ctx = querier.WithMethod(ctx, fn)
+
+	stmt := `
+		DELETE FROM watched_films
+		WHERE user_id=$1 AND film_id=$2;
+	`
+
+	res, err := r.db.ExecContext(ctx, stmt, userID, filmID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", fn, err)
+	}
+
+	rowsAff, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", fn, err)
+	}
+
+	if rowsAff == 0 {
+		return fmt.Errorf("%s: %w", fn, ErrNotWatched)
+	}
+
+	return nil
+}
+
+// GetWatchedFilms returns the watched history of filter.UserID.
+func (r *FilmRepository) GetWatchedFilms(ctx context.Context, filter *pagination.FilmFilter) ([]*domains.WatchedEntry, error) {
+	fn := "filmRepository.GetWatchedFilms"
+	ctx = querier.WithMethod(ctx, fn)
+
+	query := selectbuilder.New(`SELECT DISTINCT `+filmSelectColumns+`, wf.watched_at, wf.rewatch_count FROM films AS f`).
+		Join("watched_films AS wf ON wf.film_id=f.id").
+		Where("wf.user_id=?", filter.UserID)
+	q, args := filterFilms(query, filter, watchedSortColumns).Build()
+
+	res, err := r.db.QueryContext(ctx, q, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", fn, err)
+	}
+	defer res.Close()
+
+	entries := []*domains.WatchedEntry{}
+	films := []*domains.Film{}
+	for res.Next() {
+		entry := &domains.WatchedEntry{}
+		err = scanFilm(res, &entry.Film, &entry.WatchedAt, &entry.RewatchCount)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", fn, err)
+		}
+		entries = append(entries, entry)
+		films = append(films, &entry.Film)
+	}
+
+	if err := res.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", fn, err)
+	}
+
+	if err := r.addGenres(ctx, films); err != nil {
+		return nil, fmt.Errorf("%s: %w", fn, err)
+	}
+
+	if err := r.addUserFlags(ctx, films, filter.UserID); err != nil {
+		return nil, fmt.Errorf("%s: %w", fn, err)
+	}
+
+	return entries, nil
+}
+
+// addUserFlags marks which of films the user has in the watchlist or has
+// watched, with a single query. Films are left unmarked for userID 0.
+func (r *FilmRepository) addUserFlags(ctx context.Context, films []*domains.Film, userID uint32) error {
+	if len(films) == 0 || userID == 0 {
+		return nil
+	}
+
+	filmsID := make([]int64, 0, len(films))
+	indexesOfFilms := make(map[uint32]int, len(films))
+	for i, film := range films {
+		inWatchlist, watched := false, false
+		film.InWatchlist, film.Watched = &inWatchlist, &watched
+		filmsID = append(filmsID, int64(film.ID))
+		indexesOfFilms[film.ID] = i
+	}
+
+	stmt := `
+		SELECT film_id, 'watchlist'
+		FROM watchlist
+		WHERE user_id=$1 AND film_id=ANY($2)
+		UNION ALL
+		SELECT film_id, 'watched'
+		FROM watched_films
+		WHERE user_id=$1 AND film_id=ANY($2);
+	`
+
+	res, err := r.db.QueryContext(ctx, stmt, userID, pq.Array(filmsID))
+	if err != nil {
+		return err
+	}
+	defer res.Close()
+
+	for res.Next() {
+		var (
+			filmID uint32
+			list   string
+		)
+		if err := res.Scan(&filmID, &list); err != nil {
+			return err
+		}
+		film := films[indexesOfFilms[filmID]]
+		if list == "watchlist" {
+			*film.InWatchlist = true
+		} else {
+			*film.Watched = true
+		}
+	}
+
+	return res.Err()
+}
+
+func mapWatchError(err error) error {
+	if err, ok := err.(*pq.Error); ok {
+		switch err.Constraint {
+		case "watchlist_film_id_fkey", "watched_films_film_id_fkey":
+			return ErrNotFound
+		case "watched_films_rewatch_count_check":
+			return ErrInvalidRewatchCount
+		}
+	}
+	return err
+}
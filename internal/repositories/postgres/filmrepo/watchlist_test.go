@@ -0,0 +1,255 @@
+package filmrepo
+
+import (
+	"context"
+	"errors"
+	"film_library/internal/domains"
+	"film_library/pkg/pagination"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+)
+
+func TestFilmRepoGetWatchlist(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+	defer db.Close()
+
+	repo := NewFilmRepository(db)
+
+	type mockBehavior func(filter *pagination.FilmFilter)
+
+	addedAt := time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC)
+	releaseDate := time.Date(2023, 7, 21, 0, 0, 0, 0, time.UTC)
+	inWatchlist, watched := true, true
+	notWatched := false
+
+	tests := []struct {
+		name    string
+		filter  *pagination.FilmFilter
+		mock    mockBehavior
+		entries []*domains.WatchlistEntry
+	}{
+		{
+			name: "Correct",
+			filter: &pagination.FilmFilter{
+				Pagination:   pagination.New(1, 10),
+				NameContains: "oppen",
+				OrderBy:      "name",
+				Direction:    "asc",
+				UserID:       2,
+			},
+			mock: func(filter *pagination.FilmFilter) {
+				rows := sqlmock.NewRows(append(filmColumns, "added_at")).
+					AddRow(1, "Oppenheimer", "", releaseDate, 10, 0, 0, 0, addedAt).
+					AddRow(2, "Barbie", "", releaseDate, 7, 0, 0, 0, addedAt)
+				mock.ExpectQuery(`SELECT DISTINCT (.+), wl.added_at FROM films AS f JOIN watchlist AS wl ON wl.film_id=f.id `+
+					`WHERE wl.user_id=\$1 AND LOWER\(f.name\) LIKE \$2 ORDER BY f.name asc`).
+					WithArgs(filter.UserID, "%oppen%", 10, 0).
+					WillReturnRows(rows)
+
+				genres := sqlmock.NewRows([]string{"film_id", "id", "name"})
+				mock.ExpectQuery("SELECT (.+) FROM genres AS g JOIN film_genre AS fg (.+)").
+					WillReturnRows(genres)
+
+				flags := sqlmock.NewRows([]string{"film_id", "list"}).
+					AddRow(1, "watchlist").
+					AddRow(2, "watchlist").
+					AddRow(1, "watched")
+				mock.ExpectQuery(`SELECT film_id, 'watchlist' FROM watchlist (.+) UNION ALL SELECT film_id, 'watched' FROM watched_films`).
+					WithArgs(filter.UserID, pq.Array([]int64{1, 2})).
+					WillReturnRows(flags)
+			},
+			entries: []*domains.WatchlistEntry{
+				{
+					Film: domains.Film{ID: 1, Name: "Oppenheimer", ReleaseDate: domains.Time(releaseDate), Rating: 10,
+						Score: domains.NewFilmScore(0, 0, 0), Genres: []*domains.Genre{}, InWatchlist: &inWatchlist, Watched: &watched},
+					AddedAt: addedAt,
+				},
+				{
+					Film: domains.Film{ID: 2, Name: "Barbie", ReleaseDate: domains.Time(releaseDate), Rating: 7,
+						Score: domains.NewFilmScore(0, 0, 0), Genres: []*domains.Genre{}, InWatchlist: &inWatchlist, Watched: &notWatched},
+					AddedAt: addedAt,
+				},
+			},
+		},
+		{
+			name: "Sorted by added date",
+			filter: &pagination.FilmFilter{
+				Pagination: pagination.New(1, 10),
+				OrderBy:    pagination.WatchlistSortAddedAt,
+				Direction:  "desc",
+				UserID:     2,
+			},
+			mock: func(filter *pagination.FilmFilter) {
+				mock.ExpectQuery(`FROM films AS f JOIN watchlist AS wl ON wl.film_id=f.id WHERE wl.user_id=\$1 `+
+					`AND LOWER\(f.name\) LIKE \$2 ORDER BY wl.added_at desc`).
+					WithArgs(filter.UserID, "%%", 10, 0).
+					WillReturnRows(sqlmock.NewRows(append(filmColumns, "added_at")))
+			},
+			entries: []*domains.WatchlistEntry{},
+		},
+		{
+			name:   "Empty",
+			filter: &pagination.FilmFilter{Pagination: pagination.New(1, 10), UserID: 2},
+			mock: func(filter *pagination.FilmFilter) {
+				mock.ExpectQuery(`FROM films AS f JOIN watchlist AS wl ON wl.film_id=f.id WHERE wl.user_id=\$1`).
+					WithArgs(filter.UserID, "%%", 10, 0).
+					WillReturnRows(sqlmock.NewRows(append(filmColumns, "added_at")))
+			},
+			entries: []*domains.WatchlistEntry{},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mock(tc.filter)
+
+			entries, err := repo.GetWatchlist(context.Background(), tc.filter)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if !reflect.DeepEqual(entries, tc.entries) {
+				t.Errorf("expected: %#v\ngot: %#v", tc.entries, entries)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestFilmRepoSetWatched(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+	defer db.Close()
+
+	repo := NewFilmRepository(db)
+
+	type mockBehavior func(userID, filmID uint32, watchedAt time.Time, rewatchCount *int)
+
+	watchedAt := time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)
+	rewatchCount := 2
+	customError := fmt.Errorf("some error")
+
+	tests := []struct {
+		name         string
+		filmID       uint32
+		rewatchCount *int
+		mock         mockBehavior
+		err          error
+	}{
+		{
+			name:         "Correct",
+			filmID:       1,
+			rewatchCount: &rewatchCount,
+			mock: func(userID, filmID uint32, watchedAt time.Time, rewatchCount *int) {
+				mock.ExpectExec(`INSERT INTO watched_films(.+) ON CONFLICT \(user_id, film_id\) DO UPDATE`).
+					WithArgs(userID, filmID, watchedAt, rewatchCount).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		{
+			name:   "Keep rewatch count",
+			filmID: 1,
+			mock: func(userID, filmID uint32, watchedAt time.Time, rewatchCount *int) {
+				mock.ExpectExec("INSERT INTO watched_films").
+					WithArgs(userID, filmID, watchedAt, nil).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		{
+			name:   "Film not found",
+			filmID: 1024,
+			mock: func(userID, filmID uint32, watchedAt time.Time, rewatchCount *int) {
+				mock.ExpectExec("INSERT INTO watched_films").
+					WithArgs(userID, filmID, watchedAt, nil).
+					WillReturnError(&pq.Error{Code: pq.ErrorCode("23503"), Constraint: "watched_films_film_id_fkey"})
+			},
+			err: ErrNotFound,
+		},
+		{
+			name:   "Unknown error",
+			filmID: 1,
+			mock: func(userID, filmID uint32, watchedAt time.Time, rewatchCount *int) {
+				mock.ExpectExec("INSERT INTO watched_films").
+					WithArgs(userID, filmID, watchedAt, nil).
+					WillReturnError(customError)
+			},
+			err: customError,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mock(2, tc.filmID, watchedAt, tc.rewatchCount)
+
+			err := repo.SetWatched(context.Background(), 2, tc.filmID, watchedAt, tc.rewatchCount)
+
+			if !errors.Is(err, tc.err) {
+				t.Errorf("expected: %s\ngot: %s", tc.err, err)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestFilmRepoDeleteFromWatchlist(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+	defer db.Close()
+
+	repo := NewFilmRepository(db)
+
+	tests := []struct {
+		name     string
+		filmID   uint32
+		affected int64
+		err      error
+	}{
+		{
+			name:     "Correct",
+			filmID:   1,
+			affected: 1,
+		},
+		{
+			name:     "Not in watchlist",
+			filmID:   1024,
+			affected: 0,
+			err:      ErrNotInWatchlist,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mock.ExpectExec("DELETE FROM watchlist WHERE user_id=(.+) AND film_id=(.+)").
+				WithArgs(2, tc.filmID).
+				WillReturnResult(sqlmock.NewResult(0, tc.affected))
+
+			err := repo.DeleteFromWatchlist(context.Background(), 2, tc.filmID)
+
+			if !errors.Is(err, tc.err) {
+				t.Errorf("expected: %s\ngot: %s", tc.err, err)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,203 @@
+package genrerepo
+
+import (
+	"context"
+	"film_library/internal/domains"
+	"film_library/pkg/sqltools/querier"
+	"fmt"
+	"strings"
+
+	"github.com/lib/pq"
+)
+
+var (
+	ErrNotFound          = fmt.Errorf("genre not found")
+	ErrAlreadyExists     = fmt.Errorf("genre already exists")
+	ErrInvalidNameLength = fmt.Errorf("invalid genre name length")
+	ErrUniqueGenres      = fmt.Errorf("genres must be unique")
+	ErrFilmNotFound      = fmt.Errorf("film not found")
+)
+
+type GenreRepository struct {
+	db querier.Querier
+}
+
+func NewGenreRepository(db querier.Querier) *GenreRepository {
+	return &GenreRepository{
+		db: db,
+	}
+}
+
+func (r *GenreRepository) AddGenre(ctx context.Context, genre domains.Genre) (uint32, error) {
+	fn := "genreRepository.AddGenre"
+	ctx = querier.WithMethod(ctx, fn)
+
+	stmt := `
+		INSERT INTO genres(name)
+		VALUES ($1)
+		RETURNING id;
+	`
+
+	var genreID int
+	row := r.db.QueryRowContext(ctx, stmt, genre.Name)
+	err := row.Scan(&genreID)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", fn, mapError(err))
+	}
+
+	return uint32(genreID), nil
+}
+
+func (r *GenreRepository) GetGenres(ctx context.Context) ([]*domains.Genre, error) {
+	fn := "genreRepository.GetGenres"
+	ctx = querier.WithMethod(ctx, fn)
+
+	stmt := `
+		SELECT id, name
+		FROM genres
+		ORDER BY name;
+	`
+
+	res, err := r.db.QueryContext(ctx, stmt)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", fn, err)
+	}
+	defer res.Close()
+
+	genres := []*domains.Genre{}
+	for res.Next() {
+		genre := &domains.Genre{}
+		err := res.Scan(&genre.ID, &genre.Name)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", fn, err)
+		}
+		genres = append(genres, genre)
+	}
+
+	if err := res.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", fn, err)
+	}
+
+	return genres, nil
+}
+
+func (r *GenreRepository) UpdateGenre(ctx context.Context, id uint32, genre domains.Genre) error {
+	fn := "genreRepository.UpdateGenre"
+	ctx = querier.WithMethod(ctx, fn)
+
+	stmt := `
+		UPDATE genres
+		SET name=$1
+		WHERE id=$2;
+	`
+
+	res, err := r.db.ExecContext(ctx, stmt, genre.Name, id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", fn, mapError(err))
+	}
+
+	rowsAff, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", fn, err)
+	}
+
+	if rowsAff == 0 {
+		return fmt.Errorf("%s: %w", fn, ErrNotFound)
+	}
+
+	return nil
+}
+
+func (r *GenreRepository) DeleteGenre(ctx context.Context, id uint32) error {
+	fn := "genreRepository.DeleteGenre"
+	ctx = querier.WithMethod(ctx, fn)
+
+	stmt := `
+		DELETE FROM genres
+		WHERE id=$1;
+	`
+
+	res, err := r.db.ExecContext(ctx, stmt, id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", fn, err)
+	}
+
+	rowsAff, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", fn, err)
+	}
+
+	if rowsAff == 0 {
+		return fmt.Errorf("%s: %w", fn, ErrNotFound)
+	}
+
+	return nil
+}
+
+func (r *GenreRepository) AddGenresToFilm(ctx context.Context, filmID uint32, genresID []uint32) error {
+	fn := "genreRepository.AddGenresToFilm"
+	ctx = querier.WithMethod(ctx, fn)
+
+	if len(genresID) == 0 {
+		return nil
+	}
+
+	rows := make([]string, 0, len(genresID))
+	args := make([]any, 0, len(genresID)*2)
+	for _, id := range genresID {
+		n := len(args)
+		rows = append(rows, fmt.Sprintf("($%d, $%d)", n+1, n+2))
+		args = append(args, filmID, id)
+	}
+
+	stmt := fmt.Sprintf(`
+		INSERT INTO film_genre(film_id, genre_id)
+		VALUES %s;
+	`, strings.Join(rows, ", "))
+
+	_, err := r.db.ExecContext(ctx, stmt, args...)
+	if err != nil {
+		if err, ok := err.(*pq.Error); ok {
+			switch err.Constraint {
+			case "film_genre_pkey":
+				return fmt.Errorf("%s: %w", fn, ErrUniqueGenres)
+			case "film_genre_genre_id_fkey":
+				return fmt.Errorf("%s: %w", fn, ErrNotFound)
+			case "film_genre_film_id_fkey":
+				return fmt.Errorf("%s: %w", fn, ErrFilmNotFound)
+			}
+		}
+		return fmt.Errorf("%s: %w", fn, err)
+	}
+
+	return nil
+}
+
+func (r *GenreRepository) DeleteFilmGenres(ctx context.Context, filmID uint32) error {
+	fn := "genreRepository.DeleteFilmGenres"
+	ctx = querier.WithMethod(ctx, fn)
+
+	stmt := `
+		DELETE FROM film_genre
+		WHERE film_id=$1;
+	`
+
+	_, err := r.db.ExecContext(ctx, stmt, filmID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", fn, err)
+	}
+
+	return nil
+}
+
+func mapError(err error) error {
+	if err, ok := err.(*pq.Error); ok {
+		switch err.Constraint {
+		case "genres_name_lower_key":
+			return ErrAlreadyExists
+		case "genres_name_check":
+			return ErrInvalidNameLength
+		}
+	}
+	return err
+}
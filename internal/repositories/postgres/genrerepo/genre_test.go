@@ -0,0 +1,111 @@
+package genrerepo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+)
+
+func TestGenreRepoAddGenresToFilm(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+	defer db.Close()
+
+	repo := NewGenreRepository(db)
+
+	type mockBehavior func(filmID uint32, genresID []uint32)
+
+	insert := regexp.QuoteMeta("INSERT INTO film_genre(film_id, genre_id) VALUES ($1, $2), ($3, $4);")
+	customError := fmt.Errorf("some error")
+	tests := []struct {
+		name     string
+		filmID   uint32
+		genresID []uint32
+		mock     mockBehavior
+		err      error
+	}{
+		{
+			name:     "Correct",
+			filmID:   1,
+			genresID: []uint32{2, 3},
+			mock: func(filmID uint32, genresID []uint32) {
+				mock.ExpectExec(insert).
+					WithArgs(filmID, genresID[0], filmID, genresID[1]).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+			},
+		},
+		{
+			name:     "No genres",
+			filmID:   1,
+			genresID: []uint32{},
+			mock:     func(filmID uint32, genresID []uint32) {},
+		},
+		{
+			name:     "Genre not found",
+			filmID:   1,
+			genresID: []uint32{2, 1024},
+			mock: func(filmID uint32, genresID []uint32) {
+				mock.ExpectExec(insert).
+					WithArgs(filmID, genresID[0], filmID, genresID[1]).
+					WillReturnError(&pq.Error{Constraint: "film_genre_genre_id_fkey"})
+			},
+			err: ErrNotFound,
+		},
+		{
+			name:     "Film not found",
+			filmID:   1024,
+			genresID: []uint32{2, 3},
+			mock: func(filmID uint32, genresID []uint32) {
+				mock.ExpectExec(insert).
+					WithArgs(filmID, genresID[0], filmID, genresID[1]).
+					WillReturnError(&pq.Error{Constraint: "film_genre_film_id_fkey"})
+			},
+			err: ErrFilmNotFound,
+		},
+		{
+			name:     "Duplicate genres",
+			filmID:   1,
+			genresID: []uint32{2, 2},
+			mock: func(filmID uint32, genresID []uint32) {
+				mock.ExpectExec(insert).
+					WithArgs(filmID, genresID[0], filmID, genresID[1]).
+					WillReturnError(&pq.Error{Constraint: "film_genre_pkey"})
+			},
+			err: ErrUniqueGenres,
+		},
+		{
+			name:     "Unknown error",
+			filmID:   1,
+			genresID: []uint32{2, 3},
+			mock: func(filmID uint32, genresID []uint32) {
+				mock.ExpectExec(insert).
+					WithArgs(filmID, genresID[0], filmID, genresID[1]).
+					WillReturnError(customError)
+			},
+			err: customError,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mock(tc.filmID, tc.genresID)
+
+			err := repo.AddGenresToFilm(context.Background(), tc.filmID, tc.genresID)
+
+			if !errors.Is(err, tc.err) {
+				t.Errorf("expected: %s\ngot: %s", tc.err, err)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
@@ -1,60 +1,168 @@
 package postgres
 
 import (
+	"context"
 	"database/sql"
 	"film_library/internal/config"
 	"film_library/internal/domains"
 	"film_library/internal/repositories/postgres/actorrepo"
+	"film_library/internal/repositories/postgres/apikeyrepo"
 	"film_library/internal/repositories/postgres/filmrepo"
+	"film_library/internal/repositories/postgres/genrerepo"
+	"film_library/internal/repositories/postgres/ratingrepo"
+	"film_library/internal/repositories/postgres/reviewrepo"
+	"film_library/internal/repositories/postgres/tokenrepo"
 	"film_library/internal/repositories/postgres/userrepo"
 	"film_library/pkg/pagination"
+	"film_library/pkg/sqltools/querier"
 	"fmt"
 	"time"
 
 	_ "github.com/lib/pq"
 )
 
+//go:generate mockgen -source=postgres.go -destination=mocks/mock.go
+
 type UserRepo interface {
-	AddUser(user domains.User) error
-	GetUserByLoign(login string) (*domains.User, error)
+	AddUser(ctx context.Context, user domains.User) (uint32, error)
+	GetUserByLoign(ctx context.Context, login string) (*domains.User, error)
+	GetUserByID(ctx context.Context, id uint32) (*domains.User, error)
+	GetUsers(ctx context.Context, page *pagination.Pagination) ([]*domains.User, error)
+	LockEnabledUsers(ctx context.Context, roles []string) ([]uint32, error)
+	UpdateUserRole(ctx context.Context, id uint32, role string) error
+	UpdateUserDisabled(ctx context.Context, id uint32, disabled bool) error
+	UpdateUserPassword(ctx context.Context, id uint32, hash string) error
+	BumpTokenVersion(ctx context.Context, id uint32) error
+	UpdateUserDisplayName(ctx context.Context, id uint32, displayName string) error
+	TouchUserLogin(ctx context.Context, id uint32) error
+	DeleteUser(ctx context.Context, id uint32) error
 }
 
 type ActorRepo interface {
-	AddActor(actor domains.Actor) error
-	AddActorsToFilm(filmID uint32, actorsID []uint32) error
-	UpdateActorFullName(id uint32, fullName string) error
-	UpdateActorGender(id uint32, gender string) error
-	UpdateActorBirthday(id uint32, birthday time.Time) error
-	UpdateActor(id uint32, actor domains.Actor) error
-	DeleteActor(id uint32) error
-	DeleteActorFromFilm(actorID uint32, filmID uint32) error
-	GetActorsWithFilms(filter *pagination.ActorsFilter) ([]*domains.ActorWithFilms, error)
+	AddActor(ctx context.Context, actor domains.Person) error
+	AddActorsToFilm(ctx context.Context, filmID uint32, credits []domains.Credit) error
+	UpdateActorFullName(ctx context.Context, id uint32, fullName string) error
+	UpdateActorGender(ctx context.Context, id uint32, gender string) error
+	UpdateActorBirthday(ctx context.Context, id uint32, birthday time.Time) error
+	UpdateActor(ctx context.Context, id uint32, actor domains.Person) error
+	DeleteActor(ctx context.Context, id uint32) error
+	DeleteActorFromFilm(ctx context.Context, actorID uint32, filmID uint32) error
+	DeleteFilmActors(ctx context.Context, filmID uint32) error
+	GetActorsWithFilms(ctx context.Context, filter *pagination.ActorsFilter) ([]*domains.PersonWithCredits, error)
+	GetActorByID(ctx context.Context, id uint32) (*domains.PersonWithCredits, error)
 }
 
 type FilmRepo interface {
-	AddFilm(film domains.Film) (uint32, error)
-	UpdateFilmName(id uint32, name string) error
-	UpdateFilmDescription(id uint32, descrtion string) error
-	UpdateFilmReleaseDate(id uint32, releaseDate time.Time) error
-	UpdateFilmRating(id uint32, rating int) error
-	UpdateFilm(id uint32, film domains.Film) error
-	DeleteFilm(id uint32) error
-	GetFilms(filter *pagination.FilmFilter) ([]*domains.Film, error)
+	AddFilm(ctx context.Context, film domains.Film) (uint32, error)
+	UpdateFilmName(ctx context.Context, id uint32, name string) error
+	UpdateFilmDescription(ctx context.Context, id uint32, descrtion string) error
+	UpdateFilmReleaseDate(ctx context.Context, id uint32, releaseDate time.Time) error
+	UpdateFilmRating(ctx context.Context, id uint32, rating int) error
+	UpdateFilm(ctx context.Context, id uint32, film domains.Film) error
+	DeleteFilm(ctx context.Context, id uint32) error
+	GetFilms(ctx context.Context, filter *pagination.FilmFilter) ([]*domains.Film, error)
+	GetFilmByID(ctx context.Context, id uint32) (*domains.FilmWithCredits, error)
+}
+
+type WatchlistRepo interface {
+	AddToWatchlist(ctx context.Context, userID, filmID uint32) error
+	DeleteFromWatchlist(ctx context.Context, userID, filmID uint32) error
+	GetWatchlist(ctx context.Context, filter *pagination.FilmFilter) ([]*domains.WatchlistEntry, error)
+	SetWatched(ctx context.Context, userID, filmID uint32, watchedAt time.Time, rewatchCount *int) error
+	DeleteWatched(ctx context.Context, userID, filmID uint32) error
+	GetWatchedFilms(ctx context.Context, filter *pagination.FilmFilter) ([]*domains.WatchedEntry, error)
+}
+
+type GenreRepo interface {
+	AddGenre(ctx context.Context, genre domains.Genre) (uint32, error)
+	GetGenres(ctx context.Context) ([]*domains.Genre, error)
+	UpdateGenre(ctx context.Context, id uint32, genre domains.Genre) error
+	DeleteGenre(ctx context.Context, id uint32) error
+	AddGenresToFilm(ctx context.Context, filmID uint32, genresID []uint32) error
+	DeleteFilmGenres(ctx context.Context, filmID uint32) error
+}
+
+type TokenRepo interface {
+	AddRefreshToken(ctx context.Context, token domains.RefreshToken) error
+	GetRefreshToken(ctx context.Context, hash string) (*domains.RefreshToken, error)
+	RevokeRefreshToken(ctx context.Context, id uint32, reason domains.RevokeReason) error
+	RevokeUserRefreshTokens(ctx context.Context, userID uint32, reason domains.RevokeReason) error
+	RevokeAccessToken(ctx context.Context, jti string, expiresAt time.Time) error
+	IsAccessTokenRevoked(ctx context.Context, jti string) (bool, error)
+	AddPasswordResetToken(ctx context.Context, token domains.PasswordResetToken) error
+	GetPasswordResetToken(ctx context.Context, hash string) (*domains.PasswordResetToken, error)
+	UsePasswordResetToken(ctx context.Context, id uint32) error
+	DeleteExpiredTokens(ctx context.Context) error
+}
+
+type APIKeyRepo interface {
+	AddAPIKey(ctx context.Context, key domains.APIKey) (*domains.APIKey, error)
+	GetAPIKeyByHash(ctx context.Context, hash string) (*domains.APIKey, error)
+	GetAPIKeys(ctx context.Context, page *pagination.Pagination) ([]*domains.APIKey, error)
+	TouchAPIKey(ctx context.Context, id uint32) error
+	DeleteAPIKey(ctx context.Context, id uint32) error
+}
+
+type RatingRepo interface {
+	SetUserRating(ctx context.Context, userID, filmID uint32, rating int) error
+	DeleteUserRating(ctx context.Context, userID, filmID uint32) error
+	GetRatedFilmsID(ctx context.Context, userID uint32) ([]uint32, error)
+	RefreshFilmScore(ctx context.Context, filmID uint32, priorMean, priorWeight float64) (*domains.FilmScore, error)
+	RefreshFilmScores(ctx context.Context, priorMean, priorWeight float64) (int64, error)
+}
+
+type ReviewRepo interface {
+	AddReview(ctx context.Context, review domains.Review) (uint32, error)
+	GetReviewByID(ctx context.Context, id uint32) (*domains.Review, error)
+	GetFilmReviews(ctx context.Context, filter *pagination.ReviewFilter) ([]*domains.Review, error)
+	UpdateReviewText(ctx context.Context, id, authorID uint32, text string) error
+	DeleteReview(ctx context.Context, id, authorID uint32) error
+	SetReviewVote(ctx context.Context, reviewID, userID uint32, helpful bool) error
+	DeleteReviewVote(ctx context.Context, reviewID, userID uint32) error
+	AddReviewReport(ctx context.Context, reviewID, userID uint32, reason string) error
+	GetModerationQueue(ctx context.Context, status string, page *pagination.Pagination) ([]*domains.ModeratedReview, error)
+	SetReviewHidden(ctx context.Context, id, moderatorID uint32, hidden bool) error
+}
+
+type Transactor interface {
+	// WithTx runs fn inside a transaction: it is committed if fn returns nil
+	// and rolled back otherwise. Called on a repository that is already in a
+	// transaction, fn joins it instead of starting a new one.
+	WithTx(ctx context.Context, fn func(repo IRepository) error) error
 }
 
 type IRepository interface {
 	UserRepo
 	ActorRepo
 	FilmRepo
+	WatchlistRepo
+	GenreRepo
+	RatingRepo
+	ReviewRepo
+	TokenRepo
+	APIKeyRepo
+	Transactor
 }
 
 type Repository struct {
 	UserRepo
 	ActorRepo
 	FilmRepo
+	WatchlistRepo
+	GenreRepo
+	RatingRepo
+	ReviewRepo
+	TokenRepo
+	APIKeyRepo
+
+	db      *sql.DB
+	tx      *sql.Tx
+	observe querier.Observer
 }
 
-func New(cfg *config.DataBase) (IRepository, error) {
+// New connects to the database. observe, if not nil, receives the duration
+// of every statement executed by the repositories.
+func New(cfg *config.DataBase, observe querier.Observer) (*Repository, error) {
 	dsn := fmt.Sprintf("postgres://%s:%s@%s:%s/%s?sslmode=%s", cfg.User, cfg.Password, cfg.Host, cfg.Port, cfg.Name, cfg.SSLMode)
 	db, err := sql.Open("postgres", dsn)
 	if err != nil {
@@ -62,12 +170,77 @@ func New(cfg *config.DataBase) (IRepository, error) {
 	}
 
 	if err := db.Ping(); err != nil {
+		db.Close()
 		return nil, err
 	}
 
+	repo := newRepository(db, observe)
+	repo.db = db
+	return repo, nil
+}
+
+func newRepository(q querier.Querier, observe querier.Observer) *Repository {
+	q = querier.Instrument(q, observe)
+	films := filmrepo.NewFilmRepository(q)
 	return &Repository{
-		userrepo.NewUserRepository(db),
-		actorrepo.NewActorRepository(db),
-		filmrepo.NewFilmRepository(db),
-	}, nil
+		UserRepo:      userrepo.NewUserRepository(q),
+		ActorRepo:     actorrepo.NewActorRepository(q),
+		FilmRepo:      films,
+		WatchlistRepo: films,
+		GenreRepo:     genrerepo.NewGenreRepository(q),
+		RatingRepo:    ratingrepo.NewRatingRepository(q),
+		ReviewRepo:    reviewrepo.NewReviewRepository(q),
+		TokenRepo:     tokenrepo.NewTokenRepository(q),
+		APIKeyRepo:    apikeyrepo.NewAPIKeyRepository(q),
+		observe:       observe,
+	}
+}
+
+func (r *Repository) WithTx(ctx context.Context, txFunc func(repo IRepository) error) error {
+	fn := "repository.WithTx"
+
+	if r.tx != nil {
+		return txFunc(r)
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("%s: %w", fn, err)
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			_ = tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	txRepo := newRepository(tx, r.observe)
+	txRepo.db = r.db
+	txRepo.tx = tx
+
+	if err := txFunc(txRepo); err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			return fmt.Errorf("%s: %w: rollback: %s", fn, err, rbErr.Error())
+		}
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("%s: %w", fn, err)
+	}
+
+	return nil
+}
+
+func (r *Repository) Ping(ctx context.Context) error {
+	return r.db.PingContext(ctx)
+}
+
+func (r *Repository) Stats() sql.DBStats {
+	return r.db.Stats()
+}
+
+func (r *Repository) Close() error {
+	return r.db.Close()
 }
@@ -0,0 +1,100 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"film_library/internal/domains"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestRepositoryWithTx(t *testing.T) {
+	customError := fmt.Errorf("some error")
+	film := domains.Film{Name: "Oppenheimer", ReleaseDate: domains.Time(time.Now()), Rating: 10}
+
+	tests := []struct {
+		name   string
+		mock   func(mock sqlmock.Sqlmock)
+		txFunc func(repo IRepository) error
+		err    error
+	}{
+		{
+			name: "Commit",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("INSERT INTO films").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+				mock.ExpectExec("INSERT INTO credits").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			txFunc: func(repo IRepository) error {
+				id, err := repo.AddFilm(context.Background(), film)
+				if err != nil {
+					return err
+				}
+				return repo.AddActorsToFilm(context.Background(), id, []domains.Credit{{PersonID: 1, Type: domains.CreditActor}})
+			},
+		},
+		{
+			name: "Rollback",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("INSERT INTO films").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+				mock.ExpectExec("INSERT INTO credits").
+					WillReturnError(customError)
+				mock.ExpectRollback()
+			},
+			txFunc: func(repo IRepository) error {
+				id, err := repo.AddFilm(context.Background(), film)
+				if err != nil {
+					return err
+				}
+				return repo.AddActorsToFilm(context.Background(), id, []domains.Credit{{PersonID: 1024, Type: domains.CreditActor}})
+			},
+			err: customError,
+		},
+		{
+			name: "Nested transaction joins outer",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec("DELETE FROM credits").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			txFunc: func(repo IRepository) error {
+				return repo.WithTx(context.Background(), func(repo IRepository) error {
+					return repo.DeleteFilmActors(context.Background(), 1)
+				})
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("%s", err.Error())
+			}
+			defer db.Close()
+
+			repo := newRepository(db, nil)
+			repo.db = db
+			tc.mock(mock)
+
+			err = repo.WithTx(context.Background(), tc.txFunc)
+
+			if !errors.Is(err, tc.err) {
+				t.Errorf("expected: %s\ngot: %s", tc.err, err)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
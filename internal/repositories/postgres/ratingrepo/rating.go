@@ -0,0 +1,197 @@
+package ratingrepo
+
+import (
+	"context"
+	"database/sql"
+	"film_library/internal/domains"
+	"film_library/pkg/sqltools/querier"
+	"fmt"
+
+	"github.com/lib/pq"
+)
+
+var (
+	ErrNotFound      = fmt.Errorf("rating not found")
+	ErrFilmNotFound  = fmt.Errorf("film not found")
+	ErrInvalidRating = fmt.Errorf("invalid user rating")
+)
+
+type RatingRepository struct {
+	db querier.Querier
+}
+
+func NewRatingRepository(db querier.Querier) *RatingRepository {
+	return &RatingRepository{
+		db: db,
+	}
+}
+
+func (r *RatingRepository) SetUserRating(ctx context.Context, userID, filmID uint32, rating int) error {
+	fn := "ratingRepository.SetUserRating"
+	ctx = querier.WithMethod(ctx, fn)
+
+	stmt := `
+		INSERT INTO user_ratings(user_id, film_id, rating)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (user_id, film_id) DO UPDATE
+		SET rating=EXCLUDED.rating, updated_at=now();
+	`
+
+	_, err := r.db.ExecContext(ctx, stmt, userID, filmID, rating)
+	if err != nil {
+		if err, ok := err.(*pq.Error); ok {
+			switch err.Constraint {
+			case "user_ratings_film_id_fkey":
+				return fmt.Errorf("%s: %w", fn, ErrFilmNotFound)
+			case "user_ratings_rating_check":
+				return fmt.Errorf("%s: %w", fn, ErrInvalidRating)
+			}
+		}
+		return fmt.Errorf("%s: %w", fn, err)
+	}
+
+	return nil
+}
+
+func (r *RatingRepository) DeleteUserRating(ctx context.Context, userID, filmID uint32) error {
+	fn := "ratingRepository.DeleteUserRating"
+	ctx = querier.WithMethod(ctx, fn)
+
+	stmt := `
+		DELETE FROM user_ratings
+		WHERE user_id=$1 AND film_id=$2;
+	`
+
+	res, err := r.db.ExecContext(ctx, stmt, userID, filmID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", fn, err)
+	}
+
+	rowsAff, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", fn, err)
+	}
+
+	if rowsAff == 0 {
+		return fmt.Errorf("%s: %w", fn, ErrNotFound)
+	}
+
+	return nil
+}
+
+// GetRatedFilmsID returns the films rated by the user in ascending order.
+func (r *RatingRepository) GetRatedFilmsID(ctx context.Context, userID uint32) ([]uint32, error) {
+	fn := "ratingRepository.GetRatedFilmsID"
+	ctx = querier.WithMethod(ctx, fn)
+
+	stmt := `
+		SELECT film_id
+		FROM user_ratings
+		WHERE user_id=$1
+		ORDER BY film_id;
+	`
+
+	res, err := r.db.QueryContext(ctx, stmt, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", fn, err)
+	}
+	defer res.Close()
+
+	filmsID := []uint32{}
+	for res.Next() {
+		var id uint32
+		if err := res.Scan(&id); err != nil {
+			return nil, fmt.Errorf("%s: %w", fn, err)
+		}
+		filmsID = append(filmsID, id)
+	}
+
+	if err := res.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", fn, err)
+	}
+
+	return filmsID, nil
+}
+
+// RefreshFilmScore recomputes the aggregated score of the film from its user
+// ratings. The film row is locked first, so the aggregate is computed from a
+// snapshot that includes the ratings of concurrent transactions that got the
+// lock earlier. The lock does not conflict with the foreign key checks of
+// user_ratings inserts.
+func (r *RatingRepository) RefreshFilmScore(ctx context.Context, filmID uint32, priorMean, priorWeight float64) (*domains.FilmScore, error) {
+	fn := "ratingRepository.RefreshFilmScore"
+	ctx = querier.WithMethod(ctx, fn)
+
+	stmt := `
+		SELECT id
+		FROM films
+		WHERE id=$1
+		FOR NO KEY UPDATE;
+	`
+
+	var id uint32
+	err := r.db.QueryRowContext(ctx, stmt, filmID).Scan(&id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("%s: %w", fn, ErrFilmNotFound)
+		}
+		return nil, fmt.Errorf("%s: %w", fn, err)
+	}
+
+	stmt = `
+		UPDATE films
+		SET (rating_count, rating_sum, rating_score) = (
+			SELECT COUNT(*), COALESCE(SUM(rating), 0),
+				CASE WHEN COUNT(*)=0 THEN $2::float8
+				ELSE ($2::float8*$3::float8 + SUM(rating)) / ($3::float8 + COUNT(*)) END
+			FROM user_ratings
+			WHERE film_id=$1
+		)
+		WHERE id=$1
+		RETURNING rating_count, rating_sum, rating_score;
+	`
+
+	var (
+		count, sum int
+		bayesian   float64
+	)
+	err = r.db.QueryRowContext(ctx, stmt, filmID, priorMean, priorWeight).Scan(&count, &sum, &bayesian)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", fn, err)
+	}
+
+	return domains.NewFilmScore(count, sum, bayesian), nil
+}
+
+// RefreshFilmScores recomputes the weighted ratings of all films from their
+// stored aggregates with the given prior, and returns how many changed. Films
+// already scored with the prior are left alone, so it is cheap to run on every
+// start.
+func (r *RatingRepository) RefreshFilmScores(ctx context.Context, priorMean, priorWeight float64) (int64, error) {
+	fn := "ratingRepository.RefreshFilmScores"
+	ctx = querier.WithMethod(ctx, fn)
+
+	stmt := `
+		WITH scores AS (
+			SELECT id, CASE WHEN rating_count=0 THEN $1::float8
+				ELSE ($1::float8*$2::float8 + rating_sum) / ($2::float8 + rating_count) END AS score
+			FROM films
+		)
+		UPDATE films AS f
+		SET rating_score=s.score
+		FROM scores AS s
+		WHERE f.id=s.id AND f.rating_score IS DISTINCT FROM s.score;
+	`
+
+	res, err := r.db.ExecContext(ctx, stmt, priorMean, priorWeight)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", fn, err)
+	}
+
+	rowsAff, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", fn, err)
+	}
+
+	return rowsAff, nil
+}
@@ -0,0 +1,214 @@
+package ratingrepo
+
+import (
+	"context"
+	"errors"
+	"film_library/internal/domains"
+	"fmt"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+)
+
+func TestRatingRepoSetUserRating(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+	defer db.Close()
+
+	repo := NewRatingRepository(db)
+
+	type mockBehavior func(userID, filmID uint32, rating int)
+
+	customError := fmt.Errorf("some error")
+	tests := []struct {
+		name   string
+		userID uint32
+		filmID uint32
+		rating int
+		mock   mockBehavior
+		err    error
+	}{
+		{
+			name:   "Correct",
+			userID: 1,
+			filmID: 2,
+			rating: 8,
+			mock: func(userID, filmID uint32, rating int) {
+				mock.ExpectExec("INSERT INTO user_ratings(.+) ON CONFLICT").
+					WithArgs(userID, filmID, rating).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		{
+			name:   "Film not found",
+			userID: 1,
+			filmID: 1024,
+			rating: 8,
+			mock: func(userID, filmID uint32, rating int) {
+				mock.ExpectExec("INSERT INTO user_ratings").
+					WithArgs(userID, filmID, rating).
+					WillReturnError(&pq.Error{Code: pq.ErrorCode("23503"), Constraint: "user_ratings_film_id_fkey"})
+			},
+			err: ErrFilmNotFound,
+		},
+		{
+			name:   "Invalid rating",
+			userID: 1,
+			filmID: 2,
+			rating: 11,
+			mock: func(userID, filmID uint32, rating int) {
+				mock.ExpectExec("INSERT INTO user_ratings").
+					WithArgs(userID, filmID, rating).
+					WillReturnError(&pq.Error{Code: pq.ErrorCode("23514"), Constraint: "user_ratings_rating_check"})
+			},
+			err: ErrInvalidRating,
+		},
+		{
+			name:   "Unknown error",
+			userID: 1,
+			filmID: 2,
+			rating: 8,
+			mock: func(userID, filmID uint32, rating int) {
+				mock.ExpectExec("INSERT INTO user_ratings").
+					WithArgs(userID, filmID, rating).
+					WillReturnError(customError)
+			},
+			err: customError,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mock(tc.userID, tc.filmID, tc.rating)
+
+			err := repo.SetUserRating(context.Background(), tc.userID, tc.filmID, tc.rating)
+
+			if !errors.Is(err, tc.err) {
+				t.Errorf("expected: %s\ngot: %s", tc.err, err)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestRatingRepoRefreshFilmScore(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+	defer db.Close()
+
+	repo := NewRatingRepository(db)
+
+	type mockBehavior func(filmID uint32)
+
+	customError := fmt.Errorf("some error")
+	tests := []struct {
+		name   string
+		filmID uint32
+		mock   mockBehavior
+		score  *domains.FilmScore
+		err    error
+	}{
+		{
+			name:   "Correct",
+			filmID: 1,
+			mock: func(filmID uint32) {
+				mock.ExpectQuery("SELECT id FROM films WHERE id=(.+) FOR NO KEY UPDATE").
+					WithArgs(filmID).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(filmID))
+				mock.ExpectQuery("UPDATE films SET (.+) FROM user_ratings (.+) RETURNING rating_count, rating_sum, rating_score").
+					WithArgs(filmID, 6.0, 10.0).
+					WillReturnRows(sqlmock.NewRows([]string{"rating_count", "rating_sum", "rating_score"}).AddRow(2, 19, 6.5))
+			},
+			score: &domains.FilmScore{Mean: 9.5, Count: 2, Bayesian: 6.5},
+		},
+		{
+			name:   "No ratings",
+			filmID: 1,
+			mock: func(filmID uint32) {
+				mock.ExpectQuery("SELECT id FROM films").
+					WithArgs(filmID).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(filmID))
+				mock.ExpectQuery("UPDATE films").
+					WithArgs(filmID, 6.0, 10.0).
+					WillReturnRows(sqlmock.NewRows([]string{"rating_count", "rating_sum", "rating_score"}).AddRow(0, 0, 6.0))
+			},
+			score: &domains.FilmScore{Bayesian: 6},
+		},
+		{
+			name:   "Film not found",
+			filmID: 1024,
+			mock: func(filmID uint32) {
+				mock.ExpectQuery("SELECT id FROM films").
+					WithArgs(filmID).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+			},
+			err: ErrFilmNotFound,
+		},
+		{
+			name:   "Unknown error",
+			filmID: 1,
+			mock: func(filmID uint32) {
+				mock.ExpectQuery("SELECT id FROM films").
+					WithArgs(filmID).
+					WillReturnError(customError)
+			},
+			err: customError,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mock(tc.filmID)
+
+			got, err := repo.RefreshFilmScore(context.Background(), tc.filmID, 6, 10)
+
+			if tc.err != nil {
+				if !errors.Is(err, tc.err) {
+					t.Errorf("expected: %s\ngot: %s", tc.err, err)
+				}
+			} else {
+				if got == nil || *got != *tc.score {
+					t.Errorf("expected: %#v\ngot: %#v", tc.score, got)
+				}
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestRatingRepoRefreshFilmScores(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+	defer db.Close()
+
+	repo := NewRatingRepository(db)
+
+	mock.ExpectExec("UPDATE films AS f SET rating_score=s.score FROM scores AS s WHERE (.+) IS DISTINCT FROM s.score").
+		WithArgs(6.0, 10.0).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+
+	got, err := repo.RefreshFilmScores(context.Background(), 6, 10)
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+	if got != 3 {
+		t.Errorf("expected: 3\ngot: %d", got)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
@@ -0,0 +1,397 @@
+package reviewrepo
+
+import (
+	"context"
+	"database/sql"
+	"film_library/internal/domains"
+	"film_library/pkg/pagination"
+	"film_library/pkg/sqltools/querier"
+	selectbuilder "film_library/pkg/sqltools/select_builder"
+	"fmt"
+
+	"github.com/lib/pq"
+)
+
+var (
+	ErrNotFound        = fmt.Errorf("review not found")
+	ErrFilmNotFound    = fmt.Errorf("film not found")
+	ErrAlreadyExists   = fmt.Errorf("film already reviewed by the user")
+	ErrInvalidText     = fmt.Errorf("invalid review text length")
+	ErrVoteNotFound    = fmt.Errorf("review vote not found")
+	ErrAlreadyReported = fmt.Errorf("review already reported by the user")
+)
+
+// reviewColumns selects a review joined with its author. Votes are counted
+// on read, so they never go stale when votes or users are deleted.
+const reviewColumns = `r.id, r.film_id, r.user_id, u.display_name, r.text, r.hidden, r.created_at, r.updated_at,
+	(SELECT COUNT(*) FROM review_votes AS v WHERE v.review_id=r.id AND v.helpful) AS helpful,
+	(SELECT COUNT(*) FROM review_votes AS v WHERE v.review_id=r.id AND NOT v.helpful) AS unhelpful`
+
+var sortColumns = map[string]string{
+	pagination.ReviewSortNewest:  "r.created_at",
+	pagination.ReviewSortHelpful: "helpful",
+	"id":                         "r.id",
+}
+
+type ReviewRepository struct {
+	db querier.Querier
+}
+
+func NewReviewRepository(db querier.Querier) *ReviewRepository {
+	return &ReviewRepository{
+		db: db,
+	}
+}
+
+func (r *ReviewRepository) AddReview(ctx context.Context, review domains.Review) (uint32, error) {
+	fn := "reviewRepository.AddReview"
+	ctx = querier.WithMethod(ctx, fn)
+
+	stmt := `
+		INSERT INTO reviews(film_id, user_id, text)
+		VALUES ($1, $2, $3)
+		RETURNING id;
+	`
+
+	var id uint32
+	err := r.db.QueryRowContext(ctx, stmt, review.FilmID, review.AuthorID, review.Text).Scan(&id)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", fn, mapError(err))
+	}
+
+	return id, nil
+}
+
+func (r *ReviewRepository) GetReviewByID(ctx context.Context, id uint32) (*domains.Review, error) {
+	fn := "reviewRepository.GetReviewByID"
+	ctx = querier.WithMethod(ctx, fn)
+
+	stmt := fmt.Sprintf(`
+		SELECT %s
+		FROM reviews AS r
+		JOIN users AS u ON u.id=r.user_id
+		WHERE r.id=$1;
+	`, reviewColumns)
+
+	review, err := scanReview(r.db.QueryRowContext(ctx, stmt, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("%s: %w", fn, ErrNotFound)
+		}
+		return nil, fmt.Errorf("%s: %w", fn, err)
+	}
+
+	return review, nil
+}
+
+// GetFilmReviews returns the visible reviews of the film.
+func (r *ReviewRepository) GetFilmReviews(ctx context.Context, filter *pagination.ReviewFilter) ([]*domains.Review, error) {
+	fn := "reviewRepository.GetFilmReviews"
+	ctx = querier.WithMethod(ctx, fn)
+
+	query := selectbuilder.New(fmt.Sprintf(`SELECT %s FROM reviews AS r`, reviewColumns)).
+		Join("users AS u ON u.id=r.user_id").
+		Where("r.film_id=?", filter.FilmID).
+		Where("NOT r.hidden").
+		SortColumns(sortColumns).
+		OrderBy(filter.SortBy, "desc")
+	if filter.SortBy != pagination.ReviewSortNewest {
+		query.OrderBy(pagination.ReviewSortNewest, "desc")
+	}
+	q, args := query.OrderBy("id", "desc").
+		AddPagination(filter.Pagination).
+		Build()
+
+	res, err := r.db.QueryContext(ctx, q, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", fn, err)
+	}
+	defer res.Close()
+
+	reviews := []*domains.Review{}
+	for res.Next() {
+		review, err := scanReview(res)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", fn, err)
+		}
+		reviews = append(reviews, review)
+	}
+
+	if err := res.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", fn, err)
+	}
+
+	return reviews, nil
+}
+
+// UpdateReviewText changes the text of a review written by authorID.
+func (r *ReviewRepository) UpdateReviewText(ctx context.Context, id, authorID uint32, text string) error {
+	fn := "reviewRepository.UpdateReviewText"
+	ctx = querier.WithMethod(ctx, fn)
+
+	stmt := `
+		UPDATE reviews
+		SET text=$1, updated_at=now()
+		WHERE id=$2 AND user_id=$3;
+	`
+
+	res, err := r.db.ExecContext(ctx, stmt, text, id, authorID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", fn, mapError(err))
+	}
+
+	return checkAffected(fn, res, ErrNotFound)
+}
+
+// DeleteReview deletes a review written by authorID with its votes and
+// reports.
+func (r *ReviewRepository) DeleteReview(ctx context.Context, id, authorID uint32) error {
+	fn := "reviewRepository.DeleteReview"
+	ctx = querier.WithMethod(ctx, fn)
+
+	stmt := `
+		DELETE FROM reviews
+		WHERE id=$1 AND user_id=$2;
+	`
+
+	res, err := r.db.ExecContext(ctx, stmt, id, authorID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", fn, err)
+	}
+
+	return checkAffected(fn, res, ErrNotFound)
+}
+
+func (r *ReviewRepository) SetReviewVote(ctx context.Context, reviewID, userID uint32, helpful bool) error {
+	fn := "reviewRepository.SetReviewVote"
+	ctx = querier.WithMethod(ctx, fn)
+
+	stmt := `
+		INSERT INTO review_votes(review_id, user_id, helpful)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (review_id, user_id) DO UPDATE
+		SET helpful=EXCLUDED.helpful;
+	`
+
+	_, err := r.db.ExecContext(ctx, stmt, reviewID, userID, helpful)
+	if err != nil {
+		return fmt.Errorf("%s: %w", fn, mapError(err))
+	}
+
+	return nil
+}
+
+func (r *ReviewRepository) DeleteReviewVote(ctx context.Context, reviewID, userID uint32) error {
+	fn := "reviewRepository.DeleteReviewVote"
+	ctx = querier.WithMethod(ctx, fn)
+
+	stmt := `
+		DELETE FROM review_votes
+		WHERE review_id=$1 AND user_id=$2;
+	`
+
+	res, err := r.db.ExecContext(ctx, stmt, reviewID, userID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", fn, err)
+	}
+
+	return checkAffected(fn, res, ErrVoteNotFound)
+}
+
+func (r *ReviewRepository) AddReviewReport(ctx context.Context, reviewID, userID uint32, reason string) error {
+	fn := "reviewRepository.AddReviewReport"
+	ctx = querier.WithMethod(ctx, fn)
+
+	stmt := `
+		INSERT INTO review_reports(review_id, user_id, reason)
+		VALUES ($1, $2, $3);
+	`
+
+	_, err := r.db.ExecContext(ctx, stmt, reviewID, userID, reason)
+	if err != nil {
+		return fmt.Errorf("%s: %w", fn, mapError(err))
+	}
+
+	return nil
+}
+
+// GetModerationQueue returns reviews in the given moderation status. Reported
+// reviews come with their open reports, most reported first; hidden reviews
+// with all their reports, most recently hidden first.
+func (r *ReviewRepository) GetModerationQueue(ctx context.Context, status string, page *pagination.Pagination) ([]*domains.ModeratedReview, error) {
+	fn := "reviewRepository.GetModerationQueue"
+	ctx = querier.WithMethod(ctx, fn)
+
+	reports := "rr.review_id=r.id"
+	if status == domains.ModerationReported {
+		reports += " AND rr.resolved_at IS NULL"
+	}
+
+	query := selectbuilder.New(fmt.Sprintf(`SELECT %s,
+		(SELECT COUNT(*) FROM review_reports AS rr WHERE %[2]s) AS reports,
+		ARRAY(SELECT rr.reason FROM review_reports AS rr WHERE %[2]s AND rr.reason<>'' ORDER BY rr.created_at) AS reasons,
+		(SELECT MAX(rr.created_at) FROM review_reports AS rr WHERE %[2]s) AS last_reported_at,
+		r.moderated_by, r.moderated_at
+		FROM reviews AS r`, reviewColumns, reports)).
+		Join("users AS u ON u.id=r.user_id").
+		SortColumns(map[string]string{
+			"reports":      "reports",
+			"reported_at":  "last_reported_at",
+			"moderated_at": "r.moderated_at",
+			"id":           "r.id",
+		})
+	if status == domains.ModerationHidden {
+		query.Where("r.hidden").
+			OrderBy("moderated_at", "desc")
+	} else {
+		query.Where("NOT r.hidden").
+			Where(fmt.Sprintf("EXISTS (SELECT 1 FROM review_reports AS rr WHERE %s)", reports)).
+			OrderBy("reports", "desc").
+			OrderBy("reported_at", "asc")
+	}
+	q, args := query.OrderBy("id", "asc").
+		AddPagination(page).
+		Build()
+
+	res, err := r.db.QueryContext(ctx, q, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", fn, err)
+	}
+	defer res.Close()
+
+	reviews := []*domains.ModeratedReview{}
+	for res.Next() {
+		review, err := scanModeratedReview(res)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", fn, err)
+		}
+		reviews = append(reviews, review)
+	}
+
+	if err := res.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", fn, err)
+	}
+
+	return reviews, nil
+}
+
+// SetReviewHidden hides or restores the review on behalf of the moderator
+// and resolves its open reports.
+func (r *ReviewRepository) SetReviewHidden(ctx context.Context, id, moderatorID uint32, hidden bool) error {
+	fn := "reviewRepository.SetReviewHidden"
+	ctx = querier.WithMethod(ctx, fn)
+
+	stmt := `
+		UPDATE reviews
+		SET hidden=$1, moderated_by=$2, moderated_at=now()
+		WHERE id=$3;
+	`
+
+	res, err := r.db.ExecContext(ctx, stmt, hidden, moderatorID, id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", fn, err)
+	}
+
+	if err := checkAffected(fn, res, ErrNotFound); err != nil {
+		return err
+	}
+
+	stmt = `
+		UPDATE review_reports
+		SET resolved_at=now()
+		WHERE review_id=$1 AND resolved_at IS NULL;
+	`
+
+	_, err = r.db.ExecContext(ctx, stmt, id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", fn, err)
+	}
+
+	return nil
+}
+
+func checkAffected(fn string, res sql.Result, notFound error) error {
+	rowsAff, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", fn, err)
+	}
+
+	if rowsAff == 0 {
+		return fmt.Errorf("%s: %w", fn, notFound)
+	}
+
+	return nil
+}
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanReview(row scanner) (*domains.Review, error) {
+	review := &domains.Review{}
+	var updatedAt sql.NullTime
+
+	err := row.Scan(&review.ID, &review.FilmID, &review.AuthorID, &review.AuthorName, &review.Text,
+		&review.Hidden, &review.CreatedAt, &updatedAt, &review.Helpful, &review.Unhelpful)
+	if err != nil {
+		return nil, err
+	}
+
+	if updatedAt.Valid {
+		review.UpdatedAt = &updatedAt.Time
+	}
+	return review, nil
+}
+
+func scanModeratedReview(row scanner) (*domains.ModeratedReview, error) {
+	review := &domains.ModeratedReview{}
+	var (
+		updatedAt      sql.NullTime
+		lastReportedAt sql.NullTime
+		moderatedBy    sql.NullInt64
+		moderatedAt    sql.NullTime
+	)
+
+	err := row.Scan(&review.ID, &review.FilmID, &review.AuthorID, &review.AuthorName, &review.Text,
+		&review.Hidden, &review.CreatedAt, &updatedAt, &review.Helpful, &review.Unhelpful,
+		&review.Reports, pq.Array(&review.Reasons), &lastReportedAt, &moderatedBy, &moderatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if review.Reasons == nil {
+		review.Reasons = []string{}
+	}
+	if updatedAt.Valid {
+		review.UpdatedAt = &updatedAt.Time
+	}
+	if lastReportedAt.Valid {
+		review.LastReportedAt = &lastReportedAt.Time
+	}
+	if moderatedBy.Valid {
+		id := uint32(moderatedBy.Int64)
+		review.ModeratedBy = &id
+	}
+	if moderatedAt.Valid {
+		review.ModeratedAt = &moderatedAt.Time
+	}
+	return review, nil
+}
+
+func mapError(err error) error {
+	if err, ok := err.(*pq.Error); ok {
+		switch err.Constraint {
+		case "reviews_film_id_user_id_key":
+			return ErrAlreadyExists
+		case "reviews_film_id_fkey":
+			return ErrFilmNotFound
+		case "reviews_text_check":
+			return ErrInvalidText
+		case "review_votes_review_id_fkey", "review_reports_review_id_fkey":
+			return ErrNotFound
+		case "review_reports_pkey":
+			return ErrAlreadyReported
+		}
+	}
+	return err
+}
@@ -0,0 +1,246 @@
+package reviewrepo
+
+import (
+	"context"
+	"errors"
+	"film_library/internal/domains"
+	"film_library/pkg/pagination"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+)
+
+var reviewColumnNames = []string{"id", "film_id", "user_id", "display_name", "text", "hidden", "created_at", "updated_at", "helpful", "unhelpful"}
+
+func TestReviewRepoAddReview(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+	defer db.Close()
+
+	repo := NewReviewRepository(db)
+
+	type mockBehavior func(review domains.Review)
+
+	customError := fmt.Errorf("some error")
+	tests := []struct {
+		name   string
+		review domains.Review
+		mock   mockBehavior
+		id     uint32
+		err    error
+	}{
+		{
+			name:   "Correct",
+			review: domains.Review{FilmID: 1, AuthorID: 2, Text: "Great film"},
+			mock: func(review domains.Review) {
+				mock.ExpectQuery("INSERT INTO reviews(.+) RETURNING id").
+					WithArgs(review.FilmID, review.AuthorID, review.Text).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(5))
+			},
+			id: 5,
+		},
+		{
+			name:   "Already reviewed",
+			review: domains.Review{FilmID: 1, AuthorID: 2, Text: "Great film"},
+			mock: func(review domains.Review) {
+				mock.ExpectQuery("INSERT INTO reviews").
+					WithArgs(review.FilmID, review.AuthorID, review.Text).
+					WillReturnError(&pq.Error{Code: pq.ErrorCode("23505"), Constraint: "reviews_film_id_user_id_key"})
+			},
+			err: ErrAlreadyExists,
+		},
+		{
+			name:   "Film not found",
+			review: domains.Review{FilmID: 1024, AuthorID: 2, Text: "Great film"},
+			mock: func(review domains.Review) {
+				mock.ExpectQuery("INSERT INTO reviews").
+					WithArgs(review.FilmID, review.AuthorID, review.Text).
+					WillReturnError(&pq.Error{Code: pq.ErrorCode("23503"), Constraint: "reviews_film_id_fkey"})
+			},
+			err: ErrFilmNotFound,
+		},
+		{
+			name:   "Unknown error",
+			review: domains.Review{FilmID: 1, AuthorID: 2, Text: "Great film"},
+			mock: func(review domains.Review) {
+				mock.ExpectQuery("INSERT INTO reviews").
+					WithArgs(review.FilmID, review.AuthorID, review.Text).
+					WillReturnError(customError)
+			},
+			err: customError,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mock(tc.review)
+
+			id, err := repo.AddReview(context.Background(), tc.review)
+
+			if !errors.Is(err, tc.err) {
+				t.Errorf("expected: %s\ngot: %s", tc.err, err)
+			}
+
+			if id != tc.id {
+				t.Errorf("expected: %d\ngot: %d", tc.id, id)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestReviewRepoGetFilmReviews(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+	defer db.Close()
+
+	repo := NewReviewRepository(db)
+
+	type mockBehavior func(filter *pagination.ReviewFilter)
+
+	createdAt := time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC)
+	updatedAt := createdAt.Add(time.Hour)
+	customError := fmt.Errorf("some error")
+
+	tests := []struct {
+		name    string
+		filter  *pagination.ReviewFilter
+		mock    mockBehavior
+		reviews []*domains.Review
+		err     error
+	}{
+		{
+			name:   "Newest",
+			filter: &pagination.ReviewFilter{Pagination: pagination.New(1, 10), FilmID: 1, SortBy: pagination.ReviewSortNewest},
+			mock: func(filter *pagination.ReviewFilter) {
+				mock.ExpectQuery(`FROM reviews AS r (.+) WHERE r.film_id=\$1 AND NOT r.hidden ORDER BY r.created_at desc, r.id desc LIMIT \$2 OFFSET \$3`).
+					WithArgs(filter.FilmID, 10, 0).
+					WillReturnRows(sqlmock.NewRows(reviewColumnNames).
+						AddRow(5, 1, 2, "User", "Great film", false, createdAt, updatedAt, 3, 1))
+			},
+			reviews: []*domains.Review{
+				{ID: 5, FilmID: 1, AuthorID: 2, AuthorName: "User", Text: "Great film", Helpful: 3, Unhelpful: 1, CreatedAt: createdAt, UpdatedAt: &updatedAt},
+			},
+		},
+		{
+			name:   "Most helpful",
+			filter: &pagination.ReviewFilter{Pagination: pagination.New(2, 10), FilmID: 1, SortBy: pagination.ReviewSortHelpful},
+			mock: func(filter *pagination.ReviewFilter) {
+				mock.ExpectQuery(`ORDER BY helpful desc, r.created_at desc, r.id desc LIMIT \$2 OFFSET \$3`).
+					WithArgs(filter.FilmID, 10, 10).
+					WillReturnRows(sqlmock.NewRows(reviewColumnNames).
+						AddRow(5, 1, 2, "User", "Great film", false, createdAt, nil, 0, 0))
+			},
+			reviews: []*domains.Review{
+				{ID: 5, FilmID: 1, AuthorID: 2, AuthorName: "User", Text: "Great film", CreatedAt: createdAt},
+			},
+		},
+		{
+			name:   "Cut short",
+			filter: &pagination.ReviewFilter{Pagination: pagination.New(1, 10), FilmID: 1, SortBy: pagination.ReviewSortNewest},
+			mock: func(filter *pagination.ReviewFilter) {
+				mock.ExpectQuery(`FROM reviews AS r`).
+					WithArgs(filter.FilmID, 10, 0).
+					WillReturnRows(sqlmock.NewRows(reviewColumnNames).
+						AddRow(5, 1, 2, "User", "Great film", false, createdAt, nil, 0, 0).
+						AddRow(6, 1, 3, "Other", "Boring", false, createdAt, nil, 0, 0).
+						RowError(1, customError))
+			},
+			err: customError,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mock(tc.filter)
+
+			reviews, err := repo.GetFilmReviews(context.Background(), tc.filter)
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("expected: %v\ngot: %v", tc.err, err)
+			}
+
+			if !reflect.DeepEqual(reviews, tc.reviews) {
+				t.Errorf("expected: %#v\ngot: %#v", tc.reviews, reviews)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestReviewRepoSetReviewHidden(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+	defer db.Close()
+
+	repo := NewReviewRepository(db)
+
+	type mockBehavior func(id, moderatorID uint32, hidden bool)
+
+	tests := []struct {
+		name        string
+		id          uint32
+		moderatorID uint32
+		hidden      bool
+		mock        mockBehavior
+		err         error
+	}{
+		{
+			name:        "Hide",
+			id:          5,
+			moderatorID: 1,
+			hidden:      true,
+			mock: func(id, moderatorID uint32, hidden bool) {
+				mock.ExpectExec("UPDATE reviews SET hidden=(.+), moderated_by=(.+), moderated_at=now()").
+					WithArgs(hidden, moderatorID, id).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("UPDATE review_reports SET resolved_at=now()").
+					WithArgs(id).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+			},
+		},
+		{
+			name:        "Not found",
+			id:          1024,
+			moderatorID: 1,
+			hidden:      false,
+			mock: func(id, moderatorID uint32, hidden bool) {
+				mock.ExpectExec("UPDATE reviews").
+					WithArgs(hidden, moderatorID, id).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			err: ErrNotFound,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mock(tc.id, tc.moderatorID, tc.hidden)
+
+			err := repo.SetReviewHidden(context.Background(), tc.id, tc.moderatorID, tc.hidden)
+
+			if !errors.Is(err, tc.err) {
+				t.Errorf("expected: %s\ngot: %s", tc.err, err)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,243 @@
+package tokenrepo
+
+import (
+	"context"
+	"database/sql"
+	"film_library/internal/domains"
+	"film_library/pkg/sqltools/querier"
+	"fmt"
+	"time"
+)
+
+var (
+	ErrNotFound = fmt.Errorf("token not found")
+)
+
+type TokenRepository struct {
+	db querier.Querier
+}
+
+func NewTokenRepository(db querier.Querier) *TokenRepository {
+	return &TokenRepository{
+		db: db,
+	}
+}
+
+func (r *TokenRepository) AddRefreshToken(ctx context.Context, token domains.RefreshToken) error {
+	fn := "tokenRepository.AddRefreshToken"
+	ctx = querier.WithMethod(ctx, fn)
+
+	stmt := `
+		INSERT INTO refresh_tokens(user_id, token_hash, expires_at)
+		VALUES ($1, $2, $3);
+	`
+
+	_, err := r.db.ExecContext(ctx, stmt, token.UserID, token.Hash, token.ExpiresAt)
+	if err != nil {
+		return fmt.Errorf("%s: %w", fn, err)
+	}
+
+	return nil
+}
+
+// GetRefreshToken locks the token row, so concurrent refreshes of the same
+// token inside transactions are serialized.
+func (r *TokenRepository) GetRefreshToken(ctx context.Context, hash string) (*domains.RefreshToken, error) {
+	fn := "tokenRepository.GetRefreshToken"
+	ctx = querier.WithMethod(ctx, fn)
+
+	stmt := `
+		SELECT id, user_id, token_hash, expires_at, revoked_at, revoked_reason
+		FROM refresh_tokens
+		WHERE token_hash=$1
+		FOR UPDATE
+	`
+
+	token := &domains.RefreshToken{}
+	var revokedAt sql.NullTime
+	var revokedReason sql.NullString
+	row := r.db.QueryRowContext(ctx, stmt, hash)
+	err := row.Scan(&token.ID, &token.UserID, &token.Hash, &token.ExpiresAt, &revokedAt, &revokedReason)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("%s: %w", fn, ErrNotFound)
+		}
+		return nil, fmt.Errorf("%s: %w", fn, err)
+	}
+
+	if revokedAt.Valid {
+		token.RevokedAt = &revokedAt.Time
+		token.RevokedReason = domains.RevokeReason(revokedReason.String)
+	}
+
+	return token, nil
+}
+
+func (r *TokenRepository) RevokeRefreshToken(ctx context.Context, id uint32, reason domains.RevokeReason) error {
+	fn := "tokenRepository.RevokeRefreshToken"
+	ctx = querier.WithMethod(ctx, fn)
+
+	stmt := `
+		UPDATE refresh_tokens
+		SET revoked_at=now(), revoked_reason=$2
+		WHERE id=$1 AND revoked_at IS NULL
+	`
+
+	_, err := r.db.ExecContext(ctx, stmt, id, reason)
+	if err != nil {
+		return fmt.Errorf("%s: %w", fn, err)
+	}
+
+	return nil
+}
+
+func (r *TokenRepository) RevokeUserRefreshTokens(ctx context.Context, userID uint32, reason domains.RevokeReason) error {
+	fn := "tokenRepository.RevokeUserRefreshTokens"
+	ctx = querier.WithMethod(ctx, fn)
+
+	stmt := `
+		UPDATE refresh_tokens
+		SET revoked_at=now(), revoked_reason=$2
+		WHERE user_id=$1 AND revoked_at IS NULL
+	`
+
+	_, err := r.db.ExecContext(ctx, stmt, userID, reason)
+	if err != nil {
+		return fmt.Errorf("%s: %w", fn, err)
+	}
+
+	return nil
+}
+
+// RevokeAccessToken denylists jti until the token would have expired anyway.
+func (r *TokenRepository) RevokeAccessToken(ctx context.Context, jti string, expiresAt time.Time) error {
+	fn := "tokenRepository.RevokeAccessToken"
+	ctx = querier.WithMethod(ctx, fn)
+
+	stmt := `
+		INSERT INTO revoked_tokens(jti, expires_at)
+		VALUES ($1, $2)
+		ON CONFLICT (jti) DO NOTHING;
+	`
+
+	_, err := r.db.ExecContext(ctx, stmt, jti, expiresAt)
+	if err != nil {
+		return fmt.Errorf("%s: %w", fn, err)
+	}
+
+	return nil
+}
+
+func (r *TokenRepository) IsAccessTokenRevoked(ctx context.Context, jti string) (bool, error) {
+	fn := "tokenRepository.IsAccessTokenRevoked"
+	ctx = querier.WithMethod(ctx, fn)
+
+	stmt := `
+		SELECT EXISTS(SELECT 1 FROM revoked_tokens WHERE jti=$1)
+	`
+
+	var revoked bool
+	row := r.db.QueryRowContext(ctx, stmt, jti)
+	if err := row.Scan(&revoked); err != nil {
+		return false, fmt.Errorf("%s: %w", fn, err)
+	}
+
+	return revoked, nil
+}
+
+// AddPasswordResetToken replaces the unused reset tokens of the user, so only
+// the latest one works.
+func (r *TokenRepository) AddPasswordResetToken(ctx context.Context, token domains.PasswordResetToken) error {
+	fn := "tokenRepository.AddPasswordResetToken"
+	ctx = querier.WithMethod(ctx, fn)
+
+	stmt := `
+		DELETE FROM password_reset_tokens WHERE user_id=$1 AND used_at IS NULL;
+	`
+
+	_, err := r.db.ExecContext(ctx, stmt, token.UserID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", fn, err)
+	}
+
+	stmt = `
+		INSERT INTO password_reset_tokens(user_id, token_hash, expires_at)
+		VALUES ($1, $2, $3);
+	`
+
+	_, err = r.db.ExecContext(ctx, stmt, token.UserID, token.Hash, token.ExpiresAt)
+	if err != nil {
+		return fmt.Errorf("%s: %w", fn, err)
+	}
+
+	return nil
+}
+
+// GetPasswordResetToken locks the token row like GetRefreshToken, so a token
+// cannot be used twice concurrently.
+func (r *TokenRepository) GetPasswordResetToken(ctx context.Context, hash string) (*domains.PasswordResetToken, error) {
+	fn := "tokenRepository.GetPasswordResetToken"
+	ctx = querier.WithMethod(ctx, fn)
+
+	stmt := `
+		SELECT id, user_id, token_hash, expires_at, used_at
+		FROM password_reset_tokens
+		WHERE token_hash=$1
+		FOR UPDATE
+	`
+
+	token := &domains.PasswordResetToken{}
+	var usedAt sql.NullTime
+	row := r.db.QueryRowContext(ctx, stmt, hash)
+	err := row.Scan(&token.ID, &token.UserID, &token.Hash, &token.ExpiresAt, &usedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("%s: %w", fn, ErrNotFound)
+		}
+		return nil, fmt.Errorf("%s: %w", fn, err)
+	}
+
+	if usedAt.Valid {
+		token.UsedAt = &usedAt.Time
+	}
+
+	return token, nil
+}
+
+func (r *TokenRepository) UsePasswordResetToken(ctx context.Context, id uint32) error {
+	fn := "tokenRepository.UsePasswordResetToken"
+	ctx = querier.WithMethod(ctx, fn)
+
+	stmt := `
+		UPDATE password_reset_tokens
+		SET used_at=now()
+		WHERE id=$1
+	`
+
+	_, err := r.db.ExecContext(ctx, stmt, id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", fn, err)
+	}
+
+	return nil
+}
+
+// DeleteExpiredTokens removes refresh tokens, reset tokens and denylist
+// entries that can no longer be used.
+func (r *TokenRepository) DeleteExpiredTokens(ctx context.Context) error {
+	fn := "tokenRepository.DeleteExpiredTokens"
+	ctx = querier.WithMethod(ctx, fn)
+
+	stmt := `
+		DELETE FROM refresh_tokens WHERE expires_at < now();
+		DELETE FROM revoked_tokens WHERE expires_at < now();
+		DELETE FROM password_reset_tokens WHERE expires_at < now();
+	`
+
+	_, err := r.db.ExecContext(ctx, stmt)
+	if err != nil {
+		return fmt.Errorf("%s: %w", fn, err)
+	}
+
+	return nil
+}
@@ -1,8 +1,12 @@
 package userrepo
 
 import (
+	"context"
 	"database/sql"
 	"film_library/internal/domains"
+	"film_library/pkg/pagination"
+	"film_library/pkg/sqltools/querier"
+	selectbuilder "film_library/pkg/sqltools/select_builder"
 	"fmt"
 
 	"github.com/lib/pq"
@@ -15,51 +19,75 @@ var (
 )
 
 type UserRepository struct {
-	db *sql.DB
+	db querier.Querier
 }
 
-func NewUserRepository(db *sql.DB) *UserRepository {
+func NewUserRepository(db querier.Querier) *UserRepository {
 	return &UserRepository{
 		db: db,
 	}
 }
 
-func (r *UserRepository) AddUser(user domains.User) error {
+func (r *UserRepository) AddUser(ctx context.Context, user domains.User) (uint32, error) {
 	fn := "userRepository.AddUser"
+	ctx = querier.WithMethod(ctx, fn)
 
 	stmt := `
 		INSERT INTO users(login, password, role)
-		VALUES ($1, $2, $3);
+		VALUES ($1, $2, $3)
+		RETURNING id;
 	`
 
-	_, err := r.db.Exec(stmt, user.Login, user.Password, user.Role)
+	var userID int
+	row := r.db.QueryRowContext(ctx, stmt, user.Login, user.Password, user.Role)
+	err := row.Scan(&userID)
 	if err != nil {
 		if err, ok := err.(*pq.Error); ok {
 			switch {
 			case err.Code == pq.ErrorCode("23514"):
-				return fmt.Errorf("%s: %w", fn, ErrInvalidRole)
+				return 0, fmt.Errorf("%s: %w", fn, ErrInvalidRole)
 			case err.Code == pq.ErrorCode("23505"):
-				return fmt.Errorf("%s: %w", fn, ErrAlreadyExists)
+				return 0, fmt.Errorf("%s: %w", fn, ErrAlreadyExists)
 			}
 		}
-		return fmt.Errorf("%s: %w", fn, err)
+		return 0, fmt.Errorf("%s: %w", fn, err)
 	}
 
-	return nil
+	return uint32(userID), nil
 }
 
-func (r *UserRepository) GetUserByLoign(login string) (*domains.User, error) {
+func (r *UserRepository) GetUserByLoign(ctx context.Context, login string) (*domains.User, error) {
 	fn := "userRepository.GetUserByLoign"
+	ctx = querier.WithMethod(ctx, fn)
 
 	stmt := `
-		SELECT id, login, password, role
+		SELECT id, login, password, role, disabled, token_version, display_name, created_at, last_login_at
 		FROM users
 		WHERE login=$1
 	`
 
-	user := &domains.User{}
-	row := r.db.QueryRow(stmt, login)
-	err := row.Scan(&user.ID, &user.Login, &user.Password, &user.Role)
+	user, err := scanUser(r.db.QueryRowContext(ctx, stmt, login))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("%s: %w", fn, ErrNotFound)
+		}
+		return nil, fmt.Errorf("%s: %w", fn, err)
+	}
+
+	return user, nil
+}
+
+func (r *UserRepository) GetUserByID(ctx context.Context, id uint32) (*domains.User, error) {
+	fn := "userRepository.GetUserByID"
+	ctx = querier.WithMethod(ctx, fn)
+
+	stmt := `
+		SELECT id, login, password, role, disabled, token_version, display_name, created_at, last_login_at
+		FROM users
+		WHERE id=$1
+	`
+
+	user, err := scanUser(r.db.QueryRowContext(ctx, stmt, id))
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, fmt.Errorf("%s: %w", fn, ErrNotFound)
@@ -69,3 +97,274 @@ func (r *UserRepository) GetUserByLoign(login string) (*domains.User, error) {
 
 	return user, nil
 }
+
+func (r *UserRepository) GetUsers(ctx context.Context, page *pagination.Pagination) ([]*domains.User, error) {
+	fn := "userRepository.GetUsers"
+	ctx = querier.WithMethod(ctx, fn)
+
+	q, args := selectbuilder.New("SELECT id, login, role, disabled, display_name, created_at, last_login_at FROM users").
+		SortColumns(map[string]string{"id": "id"}).
+		OrderBy("id", "asc").
+		AddPagination(page).
+		Build()
+
+	res, err := r.db.QueryContext(ctx, q, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", fn, err)
+	}
+	defer res.Close()
+
+	users := []*domains.User{}
+	for res.Next() {
+		user := &domains.User{}
+		err := res.Scan(&user.ID, &user.Login, &user.Role, &user.Disabled, &user.DisplayName, &user.CreatedAt, &user.LastLoginAt)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", fn, err)
+		}
+		users = append(users, user)
+	}
+
+	if err := res.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", fn, err)
+	}
+
+	return users, nil
+}
+
+// LockEnabledUsers returns the IDs of the enabled users with one of roles and
+// locks them until the transaction ends.
+func (r *UserRepository) LockEnabledUsers(ctx context.Context, roles []string) ([]uint32, error) {
+	fn := "userRepository.LockEnabledUsers"
+	ctx = querier.WithMethod(ctx, fn)
+
+	stmt := `
+		SELECT id FROM users
+		WHERE role=ANY($1) AND NOT disabled
+		ORDER BY id
+		FOR UPDATE
+	`
+
+	res, err := r.db.QueryContext(ctx, stmt, pq.Array(roles))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", fn, err)
+	}
+	defer res.Close()
+
+	ids := []uint32{}
+	for res.Next() {
+		var id uint32
+		if err := res.Scan(&id); err != nil {
+			return nil, fmt.Errorf("%s: %w", fn, err)
+		}
+		ids = append(ids, id)
+	}
+
+	if err := res.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", fn, err)
+	}
+
+	return ids, nil
+}
+
+func (r *UserRepository) UpdateUserRole(ctx context.Context, id uint32, role string) error {
+	fn := "userRepository.UpdateUserRole"
+	ctx = querier.WithMethod(ctx, fn)
+
+	stmt := `
+		UPDATE users
+		SET role=$1
+		WHERE id=$2
+	`
+
+	res, err := r.db.ExecContext(ctx, stmt, role, id)
+	if err != nil {
+		if err, ok := err.(*pq.Error); ok && err.Code == pq.ErrorCode("23514") {
+			return fmt.Errorf("%s: %w", fn, ErrInvalidRole)
+		}
+		return fmt.Errorf("%s: %w", fn, err)
+	}
+
+	rowsAff, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", fn, err)
+	}
+
+	if rowsAff == 0 {
+		return fmt.Errorf("%s: %w", fn, ErrNotFound)
+	}
+
+	return nil
+}
+
+func (r *UserRepository) UpdateUserDisabled(ctx context.Context, id uint32, disabled bool) error {
+	fn := "userRepository.UpdateUserDisabled"
+	ctx = querier.WithMethod(ctx, fn)
+
+	stmt := `
+		UPDATE users
+		SET disabled=$1
+		WHERE id=$2
+	`
+
+	res, err := r.db.ExecContext(ctx, stmt, disabled, id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", fn, err)
+	}
+
+	rowsAff, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", fn, err)
+	}
+
+	if rowsAff == 0 {
+		return fmt.Errorf("%s: %w", fn, ErrNotFound)
+	}
+
+	return nil
+}
+
+func (r *UserRepository) DeleteUser(ctx context.Context, id uint32) error {
+	fn := "userRepository.DeleteUser"
+	ctx = querier.WithMethod(ctx, fn)
+
+	stmt := `
+		DELETE FROM users
+		WHERE id=$1
+	`
+
+	res, err := r.db.ExecContext(ctx, stmt, id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", fn, err)
+	}
+
+	rowsAff, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", fn, err)
+	}
+
+	if rowsAff == 0 {
+		return fmt.Errorf("%s: %w", fn, ErrNotFound)
+	}
+
+	return nil
+}
+
+func (r *UserRepository) UpdateUserPassword(ctx context.Context, id uint32, hash string) error {
+	fn := "userRepository.UpdateUserPassword"
+	ctx = querier.WithMethod(ctx, fn)
+
+	stmt := `
+		UPDATE users
+		SET password=$1
+		WHERE id=$2
+	`
+
+	res, err := r.db.ExecContext(ctx, stmt, hash, id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", fn, err)
+	}
+
+	rowsAff, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", fn, err)
+	}
+
+	if rowsAff == 0 {
+		return fmt.Errorf("%s: %w", fn, ErrNotFound)
+	}
+
+	return nil
+}
+
+// BumpTokenVersion revokes all access tokens issued to the user so far.
+func (r *UserRepository) BumpTokenVersion(ctx context.Context, id uint32) error {
+	fn := "userRepository.BumpTokenVersion"
+	ctx = querier.WithMethod(ctx, fn)
+
+	stmt := `
+		UPDATE users
+		SET token_version=token_version+1
+		WHERE id=$1
+	`
+
+	res, err := r.db.ExecContext(ctx, stmt, id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", fn, err)
+	}
+
+	rowsAff, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", fn, err)
+	}
+
+	if rowsAff == 0 {
+		return fmt.Errorf("%s: %w", fn, ErrNotFound)
+	}
+
+	return nil
+}
+
+func (r *UserRepository) UpdateUserDisplayName(ctx context.Context, id uint32, displayName string) error {
+	fn := "userRepository.UpdateUserDisplayName"
+	ctx = querier.WithMethod(ctx, fn)
+
+	stmt := `
+		UPDATE users
+		SET display_name=$1
+		WHERE id=$2
+	`
+
+	res, err := r.db.ExecContext(ctx, stmt, displayName, id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", fn, err)
+	}
+
+	rowsAff, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", fn, err)
+	}
+
+	if rowsAff == 0 {
+		return fmt.Errorf("%s: %w", fn, ErrNotFound)
+	}
+
+	return nil
+}
+
+// TouchUserLogin records a successful login.
+func (r *UserRepository) TouchUserLogin(ctx context.Context, id uint32) error {
+	fn := "userRepository.TouchUserLogin"
+	ctx = querier.WithMethod(ctx, fn)
+
+	stmt := `
+		UPDATE users
+		SET last_login_at=now()
+		WHERE id=$1
+	`
+
+	res, err := r.db.ExecContext(ctx, stmt, id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", fn, err)
+	}
+
+	rowsAff, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", fn, err)
+	}
+
+	if rowsAff == 0 {
+		return fmt.Errorf("%s: %w", fn, ErrNotFound)
+	}
+
+	return nil
+}
+
+func scanUser(row *sql.Row) (*domains.User, error) {
+	user := &domains.User{}
+	err := row.Scan(&user.ID, &user.Login, &user.Password, &user.Role, &user.Disabled, &user.TokenVersion,
+		&user.DisplayName, &user.CreatedAt, &user.LastLoginAt)
+	if err != nil {
+		return nil, err
+	}
+	return user, nil
+}
@@ -1,10 +1,12 @@
 package userrepo
 
 import (
+	"context"
 	"errors"
 	"film_library/internal/domains"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/lib/pq"
@@ -32,16 +34,17 @@ func TestUserRepoAdd(t *testing.T) {
 			name: "Correct",
 			user: domains.User{Login: "denis", Password: "denis", Role: "admin"},
 			mock: func(user domains.User) {
-				mock.ExpectExec("INSERT INTO users").
+				rows := mock.NewRows([]string{"id"}).AddRow(1)
+				mock.ExpectQuery("INSERT INTO users").
 					WithArgs(user.Login, user.Password, user.Role).
-					WillReturnResult(sqlmock.NewResult(1, 1))
+					WillReturnRows(rows)
 			},
 		},
 		{
 			name: "Already exists",
 			user: domains.User{Login: "denis", Password: "denis", Role: "admin"},
 			mock: func(user domains.User) {
-				mock.ExpectExec("INSERT INTO users").
+				mock.ExpectQuery("INSERT INTO users").
 					WithArgs(user.Login, user.Password, user.Role).
 					WillReturnError(&pq.Error{Code: pq.ErrorCode("23505")})
 			},
@@ -51,7 +54,7 @@ func TestUserRepoAdd(t *testing.T) {
 			name: "Invalid role",
 			user: domains.User{Login: "aboba", Password: "admin", Role: "abobavich"},
 			mock: func(user domains.User) {
-				mock.ExpectExec("INSERT INTO users").
+				mock.ExpectQuery("INSERT INTO users").
 					WithArgs(user.Login, user.Password, user.Role).
 					WillReturnError(&pq.Error{Code: pq.ErrorCode("23514")})
 			},
@@ -61,7 +64,7 @@ func TestUserRepoAdd(t *testing.T) {
 			name: "Unknown error",
 			user: domains.User{Login: "123", Password: "123", Role: "viewer"},
 			mock: func(user domains.User) {
-				mock.ExpectExec("INSERT INTO users").
+				mock.ExpectQuery("INSERT INTO users").
 					WithArgs(user.Login, user.Password, user.Role).
 					WillReturnError(customError)
 			},
@@ -73,7 +76,7 @@ func TestUserRepoAdd(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			tc.mock(tc.user)
 
-			err := repo.AddUser(tc.user)
+			_, err := repo.AddUser(context.Background(), tc.user)
 
 			if !errors.Is(err, tc.err) {
 				t.Errorf("expected: %s\ngot: %s", tc.err, err)
@@ -109,7 +112,8 @@ func TestUserRepoGetByLogin(t *testing.T) {
 			name:  "Correct",
 			login: "denis",
 			mock: func(login string) {
-				rows := mock.NewRows([]string{"id", "login", "password", "role"}).AddRow(1, "denis", "denis", "admin")
+				rows := mock.NewRows([]string{"id", "login", "password", "role", "disabled", "token_version", "display_name", "created_at", "last_login_at"}).
+					AddRow(1, "denis", "denis", "admin", false, 0, "Denis", time.Now(), nil)
 				mock.ExpectQuery("SELECT (.+) FROM users WHERE (.+)").
 					WithArgs(login).
 					WillReturnRows(rows)
@@ -120,7 +124,7 @@ func TestUserRepoGetByLogin(t *testing.T) {
 			name:  "Not found",
 			login: "denis",
 			mock: func(login string) {
-				rows := mock.NewRows([]string{"id", "login", "password", "role"})
+				rows := mock.NewRows([]string{"id", "login", "password", "role", "disabled", "token_version", "display_name", "created_at", "last_login_at"})
 				mock.ExpectQuery("SELECT (.+) FROM users WHERE (.+)").
 					WithArgs(login).
 					WillReturnRows(rows)
@@ -143,7 +147,7 @@ func TestUserRepoGetByLogin(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			tc.mock(tc.login)
 
-			got, err := repo.GetUserByLoign(tc.login)
+			got, err := repo.GetUserByLoign(context.Background(), tc.login)
 
 			if tc.err != nil {
 				if !errors.Is(err, tc.err) {
@@ -161,3 +165,121 @@ func TestUserRepoGetByLogin(t *testing.T) {
 		})
 	}
 }
+
+func TestUserRepoUpdateRole(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+	defer db.Close()
+
+	repo := NewUserRepository(db)
+
+	type mockBehavior func(id uint32, role string)
+
+	customError := fmt.Errorf("some error")
+	tests := []struct {
+		name string
+		id   uint32
+		role string
+		mock mockBehavior
+		err  error
+	}{
+		{
+			name: "Correct",
+			id:   1,
+			role: "admin",
+			mock: func(id uint32, role string) {
+				mock.ExpectExec("UPDATE users SET role").
+					WithArgs(role, id).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		{
+			name: "Not found",
+			id:   2,
+			role: "admin",
+			mock: func(id uint32, role string) {
+				mock.ExpectExec("UPDATE users SET role").
+					WithArgs(role, id).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			err: ErrNotFound,
+		},
+		{
+			name: "Invalid role",
+			id:   1,
+			role: "root",
+			mock: func(id uint32, role string) {
+				mock.ExpectExec("UPDATE users SET role").
+					WithArgs(role, id).
+					WillReturnError(&pq.Error{Code: pq.ErrorCode("23514")})
+			},
+			err: ErrInvalidRole,
+		},
+		{
+			name: "Unknown error",
+			id:   1,
+			role: "viewer",
+			mock: func(id uint32, role string) {
+				mock.ExpectExec("UPDATE users SET role").
+					WithArgs(role, id).
+					WillReturnError(customError)
+			},
+			err: customError,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mock(tc.id, tc.role)
+
+			err := repo.UpdateUserRole(context.Background(), tc.id, tc.role)
+
+			if !errors.Is(err, tc.err) {
+				t.Errorf("expected: %s\ngot: %s", tc.err, err)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestUserRepoLockEnabledUsers(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+	defer db.Close()
+
+	repo := NewUserRepository(db)
+
+	roles := []string{"admin"}
+	mock.ExpectQuery(`SELECT id FROM users WHERE role=ANY\(\$1\) AND NOT disabled ORDER BY id FOR UPDATE`).
+		WithArgs(pq.Array(roles)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1).AddRow(4))
+
+	ids, err := repo.LockEnabledUsers(context.Background(), roles)
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+	if len(ids) != 2 || ids[0] != 1 || ids[1] != 4 {
+		t.Errorf("expected: [1 4]\ngot: %v", ids)
+	}
+
+	// A list cut short must not pass for all the enabled users.
+	customError := fmt.Errorf("some error")
+	mock.ExpectQuery("SELECT id FROM users").
+		WithArgs(pq.Array(roles)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1).AddRow(4).RowError(1, customError))
+
+	if _, err := repo.LockEnabledUsers(context.Background(), roles); !errors.Is(err, customError) {
+		t.Errorf("expected: %s\ngot: %v", customError, err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
@@ -1,7 +1,10 @@
 package actorservice
 
 import (
+	"context"
 	"film_library/internal/domains"
+	"film_library/internal/logger"
+	"film_library/internal/repositories/postgres"
 	"film_library/pkg/pagination"
 	"film_library/pkg/validation"
 	"fmt"
@@ -12,18 +15,28 @@ import (
 var (
 	ErrInvalidFullName = fmt.Errorf("full name must be at least 1 letter long")
 	ErrInvalidGender   = fmt.Errorf("gender must be male or female")
+
+	ErrInvalidCreditType = fmt.Errorf("credit type must be actor, director, writer, producer or composer")
+	ErrInvalidCharacter  = fmt.Errorf("character must be at most 150 characters long and is only allowed for actor credits")
+)
+
+const (
+	maxCharacterLen = 150
+	maxBillingOrder = 32767
 )
 
 type ActorRepo interface {
-	AddActor(actor domains.Actor) error
-	AddActorsToFilm(filmID uint32, actorsID []uint32) error
-	UpdateActorFullName(id uint32, fullName string) error
-	UpdateActorGender(id uint32, gender string) error
-	UpdateActorBirthday(id uint32, birthday time.Time) error
-	UpdateActor(id uint32, actor domains.Actor) error
-	DeleteActor(id uint32) error
-	DeleteActorFromFilm(actorID uint32, filmID uint32) error
-	GetActorsWithFilms(filter *pagination.ActorsFilter) ([]*domains.ActorWithFilms, error)
+	AddActor(ctx context.Context, actor domains.Person) error
+	AddActorsToFilm(ctx context.Context, filmID uint32, credits []domains.Credit) error
+	UpdateActorFullName(ctx context.Context, id uint32, fullName string) error
+	UpdateActorGender(ctx context.Context, id uint32, gender string) error
+	UpdateActorBirthday(ctx context.Context, id uint32, birthday time.Time) error
+	UpdateActor(ctx context.Context, id uint32, actor domains.Person) error
+	DeleteActor(ctx context.Context, id uint32) error
+	DeleteActorFromFilm(ctx context.Context, actorID uint32, filmID uint32) error
+	GetActorsWithFilms(ctx context.Context, filter *pagination.ActorsFilter) ([]*domains.PersonWithCredits, error)
+	GetActorByID(ctx context.Context, id uint32) (*domains.PersonWithCredits, error)
+	WithTx(ctx context.Context, txFunc func(repo postgres.IRepository) error) error
 }
 
 type ActorService struct {
@@ -38,138 +51,193 @@ func New(repo ActorRepo, log *slog.Logger) *ActorService {
 	}
 }
 
-func (s *ActorService) CreateActor(actor domains.Actor) error {
+func (s *ActorService) CreateActor(ctx context.Context, actor domains.Person) error {
 	fn := "actorService.CreateActor"
 
 	err := s.validateActor(actor)
 	if err != nil {
-		s.log.Error(fmt.Sprintf("%s: %s", fn, err.Error()))
+		s.logger(ctx).Error(fmt.Sprintf("%s: %s", fn, err.Error()))
 		return err
 	}
 
-	err = s.repo.AddActor(actor)
+	err = s.repo.AddActor(ctx, actor)
 	if err != nil {
-		s.log.Error(fmt.Sprintf("%s: %s", fn, err.Error()))
+		s.logger(ctx).Error(fmt.Sprintf("%s: %s", fn, err.Error()))
 		return fmt.Errorf("%s: %w", fn, err)
 	}
 
 	return nil
 }
 
-func (s *ActorService) AddActorsToFilm(filmID uint32, actorsID []uint32) error {
+func (s *ActorService) AddActorsToFilm(ctx context.Context, filmID uint32, credits []domains.Credit) error {
 	fn := "actorService.AddActorsToFilm"
 
-	err := s.repo.AddActorsToFilm(filmID, actorsID)
+	err := s.validateCredits(credits)
 	if err != nil {
-		s.log.Error(fmt.Sprintf("%s: %s", fn, err.Error()))
+		s.logger(ctx).Error(fmt.Sprintf("%s: %s", fn, err.Error()))
+		return fmt.Errorf("%s: %w", fn, err)
+	}
+
+	err = s.repo.AddActorsToFilm(ctx, filmID, credits)
+	if err != nil {
+		s.logger(ctx).Error(fmt.Sprintf("%s: %s", fn, err.Error()))
 		return fmt.Errorf("%s: %w", fn, err)
 	}
 
 	return nil
 }
 
-func (s *ActorService) UpdateActorFullName(id uint32, fullName string) error {
+func (s *ActorService) ReplaceFilmActors(ctx context.Context, filmID uint32, credits []domains.Credit) error {
+	fn := "actorService.ReplaceFilmActors"
+
+	err := s.validateCredits(credits)
+	if err != nil {
+		s.logger(ctx).Error(fmt.Sprintf("%s: %s", fn, err.Error()))
+		return fmt.Errorf("%s: %w", fn, err)
+	}
+
+	err = s.repo.WithTx(ctx, func(repo postgres.IRepository) error {
+		if err := repo.DeleteFilmActors(ctx, filmID); err != nil {
+			return err
+		}
+
+		return repo.AddActorsToFilm(ctx, filmID, credits)
+	})
+	if err != nil {
+		s.logger(ctx).Error(fmt.Sprintf("%s: %s", fn, err.Error()))
+		return fmt.Errorf("%s: %w", fn, err)
+	}
+
+	return nil
+}
+
+func (s *ActorService) UpdateActorFullName(ctx context.Context, id uint32, fullName string) error {
 	fn := "actorService.UpdateActorFullName"
 
-	if len(fullName) == 0 {
-		return fmt.Errorf("%s: %w", fn, ErrInvalidFullName)
+	err := validation.Check("fullName", fullName, validation.Required().Err(ErrInvalidFullName))
+	if err != nil {
+		s.logger(ctx).Error(fmt.Sprintf("%s: %s", fn, err.Error()))
+		return fmt.Errorf("%s: %w", fn, err)
 	}
 
-	err := s.repo.UpdateActorFullName(id, fullName)
+	err = s.repo.UpdateActorFullName(ctx, id, fullName)
 	if err != nil {
-		s.log.Error(fmt.Sprintf("%s: %s", fn, err.Error()))
+		s.logger(ctx).Error(fmt.Sprintf("%s: %s", fn, err.Error()))
 		return fmt.Errorf("%s: %w", fn, err)
 	}
 
 	return nil
 }
 
-func (s *ActorService) UpdateActorGender(id uint32, gender domains.Gender) error {
+func (s *ActorService) UpdateActorGender(ctx context.Context, id uint32, gender domains.Gender) error {
 	fn := "actorService.UpdateActorGender"
 
-	if !gender.IsValid() {
-		s.log.Error(fmt.Sprintf("%s: %s: %s", fn, ErrInvalidGender.Error(), gender))
-		return fmt.Errorf("%s: %w", fn, ErrInvalidGender)
+	err := validation.Check("gender", string(gender), genderRule())
+	if err != nil {
+		s.logger(ctx).Error(fmt.Sprintf("%s: %s", fn, err.Error()))
+		return fmt.Errorf("%s: %w", fn, err)
 	}
 
-	err := s.repo.UpdateActorGender(id, string(gender))
+	err = s.repo.UpdateActorGender(ctx, id, string(gender))
 	if err != nil {
-		s.log.Error(fmt.Sprintf("%s: %s", fn, err.Error()))
+		s.logger(ctx).Error(fmt.Sprintf("%s: %s", fn, err.Error()))
 		return fmt.Errorf("%s: %w", fn, err)
 	}
 
 	return nil
 }
 
-func (s *ActorService) UpdateActorBirthday(id uint32, birthday time.Time) error {
+func (s *ActorService) UpdateActorBirthday(ctx context.Context, id uint32, birthday time.Time) error {
 	fn := "actorService.UpdateActorBirthday"
-	err := s.repo.UpdateActorBirthday(id, birthday)
+
+	err := validation.Check("birthday", birthday, validation.NotInFuture())
+	if err != nil {
+		s.logger(ctx).Error(fmt.Sprintf("%s: %s", fn, err.Error()))
+		return fmt.Errorf("%s: %w", fn, err)
+	}
+
+	err = s.repo.UpdateActorBirthday(ctx, id, birthday)
 	if err != nil {
-		s.log.Error(fmt.Sprintf("%s: %s", fn, err.Error()))
+		s.logger(ctx).Error(fmt.Sprintf("%s: %s", fn, err.Error()))
 		return fmt.Errorf("%s: %w", fn, err)
 	}
 
 	return nil
 }
 
-func (s *ActorService) UpdateActor(id uint32, actor domains.Actor) error {
+func (s *ActorService) UpdateActor(ctx context.Context, id uint32, actor domains.Person) error {
 	fn := "actorService.UpdateActor"
 
-	err := validation.NewValidator[domains.Actor](actor).
-		Must(
-			func(a domains.Actor) bool { return len(actor.FullName) > 0 },
-			ErrInvalidFullName.Error()).
-		Must(
-			func(a domains.Actor) bool { return actor.Gender.IsValid() },
-			ErrInvalidGender.Error()).
-		Validate()
-
+	err := s.validateActor(actor)
 	if err != nil {
-		s.log.Error(fmt.Sprintf("%s: %s", fn, err.Error()))
+		s.logger(ctx).Error(fmt.Sprintf("%s: %s", fn, err.Error()))
 		return fmt.Errorf("%s: %w", fn, err)
 	}
 
-	err = s.repo.UpdateActor(id, actor)
+	err = s.repo.UpdateActor(ctx, id, actor)
 	if err != nil {
-		s.log.Error(fmt.Sprintf("%s: %s", fn, err.Error()))
+		s.logger(ctx).Error(fmt.Sprintf("%s: %s", fn, err.Error()))
 		return fmt.Errorf("%s: %w", fn, err)
 	}
 
 	return nil
 }
 
-func (s *ActorService) DeleteActor(id uint32) error {
+func (s *ActorService) DeleteActor(ctx context.Context, id uint32) error {
 	fn := "actorService.DeleteActor"
-	err := s.repo.DeleteActor(id)
+	err := s.repo.DeleteActor(ctx, id)
 	if err != nil {
-		s.log.Error(fmt.Sprintf("%s: %s", fn, err.Error()))
+		s.logger(ctx).Error(fmt.Sprintf("%s: %s", fn, err.Error()))
 		return fmt.Errorf("%s: %w", fn, err)
 	}
 
 	return nil
 }
 
-func (s *ActorService) DeleteActorFromFilm(actorID uint32, filmID uint32) error {
+func (s *ActorService) DeleteActorFromFilm(ctx context.Context, actorID uint32, filmID uint32) error {
 	fn := "actorService.DeleteActor"
-	err := s.repo.DeleteActorFromFilm(actorID, filmID)
+	err := s.repo.DeleteActorFromFilm(ctx, actorID, filmID)
 	if err != nil {
-		s.log.Error(fmt.Sprintf("%s: %s", fn, err.Error()))
+		s.logger(ctx).Error(fmt.Sprintf("%s: %s", fn, err.Error()))
 		return fmt.Errorf("%s: %w", fn, err)
 	}
 
 	return nil
 }
 
-func (s *ActorService) GetActorsWithFilms(filter *pagination.ActorsFilter) ([]*domains.ActorWithFilms, error) {
+func (s *ActorService) GetActorsWithFilms(ctx context.Context, filter *pagination.ActorsFilter) ([]*domains.PersonWithCredits, error) {
 	fn := "actorService.GetActorsWithFilms"
 
 	filter.Pagination.ValidatePagination()
+	if filter.CreditType != "" {
+		err := validation.Check("credit", filter.CreditType, creditTypeRule())
+		if err != nil {
+			s.logger(ctx).Error(fmt.Sprintf("%s: %s", fn, err.Error()))
+			return nil, fmt.Errorf("%s: %w", fn, err)
+		}
+	}
 
-	actorWithFilms, err := s.repo.GetActorsWithFilms(filter)
+	actorWithFilms, err := s.repo.GetActorsWithFilms(ctx, filter)
 	if err != nil {
-		s.log.Error(fmt.Sprintf("%s: %s", fn, err.Error()))
+		s.logger(ctx).Error(fmt.Sprintf("%s: %s", fn, err.Error()))
 		return nil, fmt.Errorf("%s: %w", fn, err)
 	}
 
 	return actorWithFilms, nil
 }
+
+func (s *ActorService) GetActorByID(ctx context.Context, id uint32) (*domains.PersonWithCredits, error) {
+	fn := "actorService.GetActorByID"
+
+	actor, err := s.repo.GetActorByID(ctx, id)
+	if err != nil {
+		s.logger(ctx).Error(fmt.Sprintf("%s: %s", fn, err.Error()))
+		return nil, fmt.Errorf("%s: %w", fn, err)
+	}
+
+	return actor, nil
+}
+
+func (s *ActorService) logger(ctx context.Context) *slog.Logger {
+	return logger.FromContext(ctx, s.log)
+}
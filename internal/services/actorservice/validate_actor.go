@@ -3,17 +3,50 @@ package actorservice
 import (
 	"film_library/internal/domains"
 	"film_library/pkg/validation"
+	"time"
 )
 
-func (s *ActorService) validateActor(actor domains.Actor) error {
-	err := validation.NewValidator[domains.Actor](actor).
-		Must(
-			func(a domains.Actor) bool { return len(actor.FullName) > 0 },
-			ErrInvalidFullName.Error()).
-		Must(
-			func(a domains.Actor) bool { return actor.Gender.IsValid() },
-			ErrInvalidGender.Error()).
+func (s *ActorService) validateActor(actor domains.Person) error {
+	err := validation.NewValidator[domains.Person](actor).
+		String("fullName",
+			func(a domains.Person) string { return a.FullName },
+			validation.Required().Err(ErrInvalidFullName)).
+		String("gender",
+			func(a domains.Person) string { return string(a.Gender) },
+			genderRule()).
+		Time("birthday",
+			func(a domains.Person) time.Time { return time.Time(a.Birthday) },
+			validation.NotInFuture()).
 		Validate()
 
 	return err
 }
+
+func genderRule() validation.Rule[string] {
+	return validation.Enum(domains.Genders).Err(ErrInvalidGender)
+}
+
+// validateCredits checks every credit: the type must be known and only acting
+// credits may name a character.
+func (s *ActorService) validateCredits(credits []domains.Credit) error {
+	return validation.Each(credits, func(c domains.Credit) error {
+		return validation.NewValidator[domains.Credit](c).
+			String("type",
+				func(c domains.Credit) string { return string(c.Type) },
+				creditTypeRule()).
+			String("character",
+				func(c domains.Credit) string { return c.Character },
+				validation.Length(0, maxCharacterLen).Err(ErrInvalidCharacter)).
+			Must("character",
+				func(c domains.Credit) bool { return c.Character == "" || c.Type == domains.CreditActor },
+				ErrInvalidCharacter).
+			Int("billingOrder",
+				func(c domains.Credit) int { return c.BillingOrder },
+				validation.Range(0, maxBillingOrder)).
+			Validate()
+	})
+}
+
+func creditTypeRule() validation.Rule[string] {
+	return validation.Enum(domains.CreditTypes).Err(ErrInvalidCreditType)
+}
@@ -0,0 +1,175 @@
+package apikeyservice
+
+import (
+	"context"
+	"errors"
+	"film_library/internal/domains"
+	"film_library/internal/logger"
+	"film_library/internal/repositories/postgres/apikeyrepo"
+	"film_library/internal/tokens"
+	"film_library/pkg/pagination"
+	"film_library/pkg/validation"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+const maxNameLen = 100
+
+var (
+	ErrInvalidName       = fmt.Errorf("invalid api key name")
+	ErrInvalidPermission = fmt.Errorf("invalid permission")
+	ErrPermissionNotHeld = fmt.Errorf("permission not held by the caller")
+	ErrInvalidExpiry     = fmt.Errorf("api key expiry must be in the future")
+	ErrInvalidAPIKey     = fmt.Errorf("invalid api key")
+	ErrAPIKeyExpired     = fmt.Errorf("api key expired")
+	ErrCreatorRequired   = fmt.Errorf("api keys can only be created by users")
+	ErrCreatorDisabled   = fmt.Errorf("api key creator is disabled")
+)
+
+type APIKeyRepo interface {
+	AddAPIKey(ctx context.Context, key domains.APIKey) (*domains.APIKey, error)
+	GetAPIKeyByHash(ctx context.Context, hash string) (*domains.APIKey, error)
+	GetAPIKeys(ctx context.Context, page *pagination.Pagination) ([]*domains.APIKey, error)
+	TouchAPIKey(ctx context.Context, id uint32) error
+	DeleteAPIKey(ctx context.Context, id uint32) error
+}
+
+type APIKeyService struct {
+	repo APIKeyRepo
+	log  *slog.Logger
+}
+
+func New(repo APIKeyRepo, log *slog.Logger) *APIKeyService {
+	return &APIKeyService{
+		repo: repo,
+		log:  log,
+	}
+}
+
+// CreateAPIKey stores a new key on behalf of creator, who must be a user. A
+// key can only be granted permissions the creator has, so keys cannot
+// escalate privileges, and it is deleted with its creator.
+func (s *APIKeyService) CreateAPIKey(ctx context.Context, creator domains.Principal, key domains.APIKey) (*domains.CreatedAPIKey, error) {
+	fn := "apiKeyService.CreateAPIKey"
+
+	if creator.UserID == 0 {
+		s.logger(ctx).Warn(fmt.Sprintf("%s: %s: %d", fn, ErrCreatorRequired.Error(), creator.APIKeyID))
+		return nil, fmt.Errorf("%s: %w", fn, ErrCreatorRequired)
+	}
+
+	err := validation.NewValidator[domains.APIKey](key).
+		String("name",
+			func(k domains.APIKey) string { return k.Name },
+			validation.Length(1, maxNameLen).Err(ErrInvalidName)).
+		Nested("permissions", func(k domains.APIKey) error {
+			return validation.Each(k.Permissions, func(p domains.Permission) error {
+				return validation.Check("", p,
+					validation.Enum(domains.Permissions).Err(ErrInvalidPermission),
+					held(creator).Err(ErrPermissionNotHeld))
+			})
+		}).
+		Must("expiresAt",
+			func(k domains.APIKey) bool { return k.ExpiresAt == nil || k.ExpiresAt.After(time.Now()) },
+			ErrInvalidExpiry).
+		Validate()
+	if err != nil {
+		s.logger(ctx).Error(fmt.Sprintf("%s: %s", fn, err.Error()))
+		return nil, fmt.Errorf("%s: %w", fn, err)
+	}
+
+	plain, prefix, hash, err := tokens.NewAPIKey()
+	if err != nil {
+		s.logger(ctx).Error(fmt.Sprintf("%s: %s", fn, err.Error()))
+		return nil, fmt.Errorf("%s: %w", fn, err)
+	}
+
+	key.Prefix = prefix
+	key.Hash = hash
+	key.CreatedBy = creator.UserID
+
+	stored, err := s.repo.AddAPIKey(ctx, key)
+	if err != nil {
+		s.logger(ctx).Error(fmt.Sprintf("%s: %s", fn, err.Error()))
+		return nil, fmt.Errorf("%s: %w", fn, err)
+	}
+
+	s.logger(ctx).Info("api key created",
+		slog.Uint64("api_key_id", uint64(stored.ID)),
+		slog.String("name", stored.Name),
+		slog.Any("permissions", stored.Permissions))
+
+	return &domains.CreatedAPIKey{APIKey: *stored, Key: plain}, nil
+}
+
+func (s *APIKeyService) GetAPIKeys(ctx context.Context, page *pagination.Pagination) ([]*domains.APIKey, error) {
+	fn := "apiKeyService.GetAPIKeys"
+
+	page.ValidatePagination()
+
+	keys, err := s.repo.GetAPIKeys(ctx, page)
+	if err != nil {
+		s.logger(ctx).Error(fmt.Sprintf("%s: %s", fn, err.Error()))
+		return nil, fmt.Errorf("%s: %w", fn, err)
+	}
+
+	return keys, nil
+}
+
+func (s *APIKeyService) DeleteAPIKey(ctx context.Context, id uint32) error {
+	fn := "apiKeyService.DeleteAPIKey"
+
+	err := s.repo.DeleteAPIKey(ctx, id)
+	if err != nil {
+		s.logger(ctx).Error(fmt.Sprintf("%s: %s", fn, err.Error()))
+		return fmt.Errorf("%s: %w", fn, err)
+	}
+
+	return nil
+}
+
+// AuthenticateAPIKey returns the key matching plain and records its use. Keys
+// of disabled creators are refused; the caller limits the permissions of the
+// key to those the role of its creator grants.
+func (s *APIKeyService) AuthenticateAPIKey(ctx context.Context, plain string) (*domains.APIKey, error) {
+	fn := "apiKeyService.AuthenticateAPIKey"
+
+	key, err := s.repo.GetAPIKeyByHash(ctx, tokens.HashAPIKey(plain))
+	if err != nil {
+		s.logger(ctx).Warn(fmt.Sprintf("%s: %s", fn, err.Error()))
+		if errors.Is(err, apikeyrepo.ErrNotFound) {
+			return nil, fmt.Errorf("%s: %w", fn, ErrInvalidAPIKey)
+		}
+		return nil, fmt.Errorf("%s: %w", fn, err)
+	}
+
+	if key.ExpiresAt != nil && !key.ExpiresAt.After(time.Now()) {
+		s.logger(ctx).Warn(fmt.Sprintf("%s: %s: %d", fn, ErrAPIKeyExpired.Error(), key.ID))
+		return nil, fmt.Errorf("%s: %w", fn, ErrAPIKeyExpired)
+	}
+
+	if key.CreatorDisabled {
+		s.logger(ctx).Warn(fmt.Sprintf("%s: %s: %d", fn, ErrCreatorDisabled.Error(), key.ID))
+		return nil, fmt.Errorf("%s: %w", fn, ErrCreatorDisabled)
+	}
+
+	// A failed timestamp update must not lock services out.
+	if err := s.repo.TouchAPIKey(ctx, key.ID); err != nil {
+		s.logger(ctx).Error(fmt.Sprintf("%s: %s", fn, err.Error()))
+	}
+
+	return key, nil
+}
+
+func held(creator domains.Principal) validation.Rule[domains.Permission] {
+	return func(p domains.Permission) *validation.FieldError {
+		if creator.Can(p) {
+			return nil
+		}
+		return &validation.FieldError{Code: validation.CodeInvalid, Message: "permission not held"}
+	}
+}
+
+func (s *APIKeyService) logger(ctx context.Context) *slog.Logger {
+	return logger.FromContext(ctx, s.log)
+}
@@ -1,9 +1,13 @@
 package filmservice
 
 import (
+	"context"
 	"film_library/internal/config"
 	"film_library/internal/domains"
+	"film_library/internal/logger"
+	"film_library/internal/repositories/postgres"
 	"film_library/pkg/pagination"
+	"film_library/pkg/validation"
 	"fmt"
 	"log/slog"
 	"time"
@@ -16,168 +20,212 @@ var (
 )
 
 type FilmRepo interface {
-	AddFilm(film domains.Film) (uint32, error)
-	UpdateFilmName(id uint32, name string) error
-	UpdateFilmDescription(id uint32, descrtion string) error
-	UpdateFilmReleaseDate(id uint32, releaseDate time.Time) error
-	UpdateFilmRating(id uint32, rating int) error
-	UpdateFilm(id uint32, film domains.Film) error
-	DeleteFilm(id uint32) error
-	GetFilms(filter *pagination.FilmFilter) ([]*domains.Film, error)
-}
-
-type ActorService interface {
-	AddActorsToFilm(filmID uint32, actors []uint32) error
+	AddFilm(ctx context.Context, film domains.Film) (uint32, error)
+	UpdateFilmName(ctx context.Context, id uint32, name string) error
+	UpdateFilmDescription(ctx context.Context, id uint32, descrtion string) error
+	UpdateFilmReleaseDate(ctx context.Context, id uint32, releaseDate time.Time) error
+	UpdateFilmRating(ctx context.Context, id uint32, rating int) error
+	UpdateFilm(ctx context.Context, id uint32, film domains.Film) error
+	DeleteFilm(ctx context.Context, id uint32) error
+	GetFilms(ctx context.Context, filter *pagination.FilmFilter) ([]*domains.Film, error)
+	GetFilmByID(ctx context.Context, id uint32) (*domains.FilmWithCredits, error)
+	WatchlistRepo
+	WithTx(ctx context.Context, txFunc func(repo postgres.IRepository) error) error
 }
 
 type FilmService struct {
-	repo         FilmRepo
-	actorService ActorService
-	log          *slog.Logger
-	cfg          *config.Config
+	repo FilmRepo
+	log  *slog.Logger
+	cfg  *config.Config
 }
 
-func New(repo FilmRepo, actorService ActorService, log *slog.Logger, cfg *config.Config) *FilmService {
+func New(repo FilmRepo, log *slog.Logger, cfg *config.Config) *FilmService {
 	return &FilmService{
-		repo:         repo,
-		actorService: actorService,
-		log:          log,
-		cfg:          cfg,
+		repo: repo,
+		log:  log,
+		cfg:  cfg,
 	}
 }
 
-func (s *FilmService) CreateFilm(film domains.Film, actorsID []uint32) (uint32, error) {
+func (s *FilmService) CreateFilm(ctx context.Context, film domains.Film, actorsID, genresID []uint32) (uint32, error) {
 	fn := "filmService.CreateFilm"
 
 	err := s.validateFilm(film)
 
 	if err != nil {
-		s.log.Error(fmt.Sprintf("%s: %s", fn, err.Error()))
+		s.logger(ctx).Error(fmt.Sprintf("%s: %s", fn, err.Error()))
 		return 0, err
 	}
 
-	filmID, err := s.repo.AddFilm(film)
-	if err != nil {
-		s.log.Error(fmt.Sprintf("%s: %s", fn, err.Error()))
-		return 0, fmt.Errorf("%s: %w", fn, err)
-	}
-
-	err = s.actorService.AddActorsToFilm(filmID, actorsID)
+	ratings := s.cfg.UserRatings
+	var filmID uint32
+	err = s.repo.WithTx(ctx, func(repo postgres.IRepository) error {
+		id, err := repo.AddFilm(ctx, film)
+		if err != nil {
+			return err
+		}
+		filmID = id
+
+		// The score of a film without ratings is the prior mean.
+		if _, err := repo.RefreshFilmScore(ctx, filmID, ratings.PriorMean, ratings.PriorWeight); err != nil {
+			return err
+		}
+
+		if err := repo.AddGenresToFilm(ctx, filmID, genresID); err != nil {
+			return err
+		}
+
+		credits := make([]domains.Credit, 0, len(actorsID))
+		for i, actorID := range actorsID {
+			credits = append(credits, domains.Credit{PersonID: actorID, Type: domains.CreditActor, BillingOrder: i + 1})
+		}
+
+		return repo.AddActorsToFilm(ctx, filmID, credits)
+	})
 	if err != nil {
-		s.log.Error(fmt.Sprintf("%s: %s", fn, err.Error()))
+		s.logger(ctx).Error(fmt.Sprintf("%s: %s", fn, err.Error()))
 		return 0, fmt.Errorf("%s: %w", fn, err)
 	}
 
 	return filmID, nil
 }
 
-func (s *FilmService) UpdateFilmName(id uint32, name string) error {
+func (s *FilmService) UpdateFilmName(ctx context.Context, id uint32, name string) error {
 	fn := "filmService.UpdateFilmName"
 
-	minNameLen, maxNameLen := s.cfg.FilmValidations.MinNameLen, s.cfg.FilmValidations.MaxNameLen
-	if len(name) < minNameLen || len(name) > maxNameLen {
-		s.log.Error(fmt.Sprintf("%s: %s", fn, ErrInvalidName.Error()))
-		return fmt.Errorf("%s: %w", fn, ErrInvalidName)
+	err := validation.Check("name", name, s.nameRule())
+	if err != nil {
+		s.logger(ctx).Error(fmt.Sprintf("%s: %s", fn, err.Error()))
+		return fmt.Errorf("%s: %w", fn, err)
 	}
 
-	err := s.repo.UpdateFilmName(id, name)
+	err = s.repo.UpdateFilmName(ctx, id, name)
 	if err != nil {
-		s.log.Error(fmt.Sprintf("%s: %s", fn, err.Error()))
+		s.logger(ctx).Error(fmt.Sprintf("%s: %s", fn, err.Error()))
 		return fmt.Errorf("%s: %w", fn, err)
 	}
 
 	return nil
 }
 
-func (s *FilmService) UpdateFilmDescription(id uint32, descrtion string) error {
+func (s *FilmService) UpdateFilmDescription(ctx context.Context, id uint32, descrtion string) error {
 	fn := "filmService.UpdateFilmName"
 
-	minDescriptionLen, maxDescriptionLen := s.cfg.FilmValidations.MinDescriptionLen, s.cfg.FilmValidations.MaxDescriptionLen
-	if len(descrtion) < minDescriptionLen || len(descrtion) > maxDescriptionLen {
-		s.log.Error(fmt.Sprintf("%s: %s", fn, ErrInvalidDescription.Error()))
-		return fmt.Errorf("%s: %w", fn, ErrInvalidDescription)
+	err := validation.Check("description", descrtion, s.descriptionRule())
+	if err != nil {
+		s.logger(ctx).Error(fmt.Sprintf("%s: %s", fn, err.Error()))
+		return fmt.Errorf("%s: %w", fn, err)
 	}
 
-	err := s.repo.UpdateFilmDescription(id, descrtion)
+	err = s.repo.UpdateFilmDescription(ctx, id, descrtion)
 	if err != nil {
-		s.log.Error(fmt.Sprintf("%s: %s", fn, err.Error()))
+		s.logger(ctx).Error(fmt.Sprintf("%s: %s", fn, err.Error()))
 		return fmt.Errorf("%s: %w", fn, err)
 	}
 
 	return nil
 }
 
-func (s *FilmService) UpdateFilmReleaseDate(id uint32, releaseDate time.Time) error {
+func (s *FilmService) UpdateFilmReleaseDate(ctx context.Context, id uint32, releaseDate time.Time) error {
 	fn := "filmService.UpdateFilmReleaseDate"
 
-	err := s.repo.UpdateFilmReleaseDate(id, releaseDate)
+	err := s.repo.UpdateFilmReleaseDate(ctx, id, releaseDate)
 	if err != nil {
-		s.log.Error(fmt.Sprintf("%s: %s", fn, err.Error()))
+		s.logger(ctx).Error(fmt.Sprintf("%s: %s", fn, err.Error()))
 		return fmt.Errorf("%s: %w", fn, err)
 	}
 
 	return nil
 }
 
-func (s *FilmService) UpdateFilmRating(id uint32, rating int) error {
+func (s *FilmService) UpdateFilmRating(ctx context.Context, id uint32, rating int) error {
 	fn := "filmService.UpdateFilmRating"
 
-	minRating, maxRating := s.cfg.FilmValidations.MinRating, s.cfg.FilmValidations.MaxRating
-	fmt.Println(rating, minRating, maxRating)
-	if rating < minRating || rating > maxRating {
-		s.log.Error(fmt.Sprintf("%s: %s", fn, ErrInvalidRating.Error()))
-		return fmt.Errorf("%s: %w", fn, ErrInvalidRating)
+	err := validation.Check("rating", rating, s.ratingRule())
+	if err != nil {
+		s.logger(ctx).Error(fmt.Sprintf("%s: %s", fn, err.Error()))
+		return fmt.Errorf("%s: %w", fn, err)
 	}
 
-	err := s.repo.UpdateFilmRating(id, rating)
+	err = s.repo.UpdateFilmRating(ctx, id, rating)
 	if err != nil {
-		s.log.Error(fmt.Sprintf("%s: %s", fn, err.Error()))
+		s.logger(ctx).Error(fmt.Sprintf("%s: %s", fn, err.Error()))
 		return fmt.Errorf("%s: %w", fn, err)
 	}
 
 	return nil
 }
 
-func (s *FilmService) UpdateFilm(id uint32, film domains.Film) error {
+// UpdateFilm replaces the fields of the film and, unless genresID is nil, its genres.
+func (s *FilmService) UpdateFilm(ctx context.Context, id uint32, film domains.Film, genresID []uint32) error {
 	fn := "filmService.UpdateFilm"
 
 	err := s.validateFilm(film)
 
 	if err != nil {
-		s.log.Error(fmt.Sprintf("%s: %s", fn, err.Error()))
+		s.logger(ctx).Error(fmt.Sprintf("%s: %s", fn, err.Error()))
 		return err
 	}
 
-	err = s.repo.UpdateFilm(id, film)
+	err = s.repo.WithTx(ctx, func(repo postgres.IRepository) error {
+		if err := repo.UpdateFilm(ctx, id, film); err != nil {
+			return err
+		}
+
+		if genresID == nil {
+			return nil
+		}
+
+		if err := repo.DeleteFilmGenres(ctx, id); err != nil {
+			return err
+		}
+		return repo.AddGenresToFilm(ctx, id, genresID)
+	})
 	if err != nil {
-		s.log.Error(fmt.Sprintf("%s: %s", fn, err.Error()))
+		s.logger(ctx).Error(fmt.Sprintf("%s: %s", fn, err.Error()))
 		return fmt.Errorf("%s: %w", fn, err)
 	}
 
 	return nil
 }
 
-func (s *FilmService) DeleteFilm(id uint32) error {
+func (s *FilmService) DeleteFilm(ctx context.Context, id uint32) error {
 	fn := "filmService.DeleteFilm"
-	err := s.repo.DeleteFilm(id)
+	err := s.repo.DeleteFilm(ctx, id)
 	if err != nil {
-		s.log.Error(fmt.Sprintf("%s: %s", fn, err.Error()))
+		s.logger(ctx).Error(fmt.Sprintf("%s: %s", fn, err.Error()))
 		return fmt.Errorf("%s: %w", fn, err)
 	}
 
 	return nil
 }
 
-func (s *FilmService) GetFilms(filter *pagination.FilmFilter) ([]*domains.Film, error) {
+func (s *FilmService) GetFilms(ctx context.Context, filter *pagination.FilmFilter) ([]*domains.Film, error) {
 	fn := "filmService.GetFilms"
 
 	filter.Validate()
 
-	films, err := s.repo.GetFilms(filter)
+	films, err := s.repo.GetFilms(ctx, filter)
 	if err != nil {
-		s.log.Error(fmt.Sprintf("%s: %s", fn, err.Error()))
+		s.logger(ctx).Error(fmt.Sprintf("%s: %s", fn, err.Error()))
 		return nil, fmt.Errorf("%s: %w", fn, err)
 	}
 
 	return films, nil
 }
+
+func (s *FilmService) GetFilmByID(ctx context.Context, id uint32) (*domains.FilmWithCredits, error) {
+	fn := "filmService.GetFilmByID"
+
+	film, err := s.repo.GetFilmByID(ctx, id)
+	if err != nil {
+		s.logger(ctx).Error(fmt.Sprintf("%s: %s", fn, err.Error()))
+		return nil, fmt.Errorf("%s: %w", fn, err)
+	}
+
+	return film, nil
+}
+
+func (s *FilmService) logger(ctx context.Context) *slog.Logger {
+	return logger.FromContext(ctx, s.log)
+}
@@ -0,0 +1,65 @@
+package filmservice
+
+import (
+	"context"
+	"film_library/internal/domains"
+	"film_library/internal/repositories/postgres"
+	"film_library/pkg/validation"
+	"fmt"
+)
+
+var ErrInvalidUserRating = fmt.Errorf("invalid user rating")
+
+// RateFilm sets the rating the user gives the film and returns the updated
+// score of the film.
+func (s *FilmService) RateFilm(ctx context.Context, userID, filmID uint32, rating int) (*domains.FilmScore, error) {
+	fn := "filmService.RateFilm"
+
+	ratings := s.cfg.UserRatings
+	err := validation.Check("rating", rating, validation.Range(ratings.MinRating, ratings.MaxRating).Err(ErrInvalidUserRating))
+	if err != nil {
+		s.logger(ctx).Error(fmt.Sprintf("%s: %s", fn, err.Error()))
+		return nil, fmt.Errorf("%s: %w", fn, err)
+	}
+
+	var score *domains.FilmScore
+	err = s.repo.WithTx(ctx, func(repo postgres.IRepository) error {
+		if err := repo.SetUserRating(ctx, userID, filmID, rating); err != nil {
+			return err
+		}
+
+		var err error
+		score, err = repo.RefreshFilmScore(ctx, filmID, ratings.PriorMean, ratings.PriorWeight)
+		return err
+	})
+	if err != nil {
+		s.logger(ctx).Error(fmt.Sprintf("%s: %s", fn, err.Error()))
+		return nil, fmt.Errorf("%s: %w", fn, err)
+	}
+
+	return score, nil
+}
+
+// DeleteFilmRating removes the rating of the user and returns the updated
+// score of the film.
+func (s *FilmService) DeleteFilmRating(ctx context.Context, userID, filmID uint32) (*domains.FilmScore, error) {
+	fn := "filmService.DeleteFilmRating"
+
+	ratings := s.cfg.UserRatings
+	var score *domains.FilmScore
+	err := s.repo.WithTx(ctx, func(repo postgres.IRepository) error {
+		if err := repo.DeleteUserRating(ctx, userID, filmID); err != nil {
+			return err
+		}
+
+		var err error
+		score, err = repo.RefreshFilmScore(ctx, filmID, ratings.PriorMean, ratings.PriorWeight)
+		return err
+	})
+	if err != nil {
+		s.logger(ctx).Error(fmt.Sprintf("%s: %s", fn, err.Error()))
+		return nil, fmt.Errorf("%s: %w", fn, err)
+	}
+
+	return score, nil
+}
@@ -6,24 +6,32 @@ import (
 )
 
 func (s *FilmService) validateFilm(film domains.Film) error {
-	minNameLen, maxNameLen := s.cfg.FilmValidations.MinNameLen, s.cfg.FilmValidations.MaxNameLen
-	minDescriptionLen, maxDescriptionLen := s.cfg.FilmValidations.MinDescriptionLen, s.cfg.FilmValidations.MaxDescriptionLen
-	minRating, maxRating := s.cfg.FilmValidations.MinRating, s.cfg.FilmValidations.MaxRating
-
 	err := validation.NewValidator[domains.Film](film).
-		Between(
-			func(f domains.Film) int { return len(f.Name) },
-			minNameLen, maxNameLen,
-			ErrInvalidName.Error()).
-		Between(
-			func(f domains.Film) int { return len(f.Description) },
-			minDescriptionLen, maxDescriptionLen,
-			ErrInvalidDescription.Error()).
-		Between(
+		String("name",
+			func(f domains.Film) string { return f.Name },
+			s.nameRule()).
+		String("description",
+			func(f domains.Film) string { return f.Description },
+			s.descriptionRule()).
+		Int("rating",
 			func(f domains.Film) int { return f.Rating },
-			minRating, maxRating,
-			ErrInvalidRating.Error()).
+			s.ratingRule()).
 		Validate()
 
 	return err
 }
+
+func (s *FilmService) nameRule() validation.Rule[string] {
+	minNameLen, maxNameLen := s.cfg.FilmValidations.MinNameLen, s.cfg.FilmValidations.MaxNameLen
+	return validation.Length(minNameLen, maxNameLen).Err(ErrInvalidName)
+}
+
+func (s *FilmService) descriptionRule() validation.Rule[string] {
+	minDescriptionLen, maxDescriptionLen := s.cfg.FilmValidations.MinDescriptionLen, s.cfg.FilmValidations.MaxDescriptionLen
+	return validation.Length(minDescriptionLen, maxDescriptionLen).Err(ErrInvalidDescription)
+}
+
+func (s *FilmService) ratingRule() validation.Rule[int] {
+	minRating, maxRating := s.cfg.FilmValidations.MinRating, s.cfg.FilmValidations.MaxRating
+	return validation.Range(minRating, maxRating).Err(ErrInvalidRating)
+}
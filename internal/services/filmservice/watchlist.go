@@ -0,0 +1,127 @@
+package filmservice
+
+import (
+	"context"
+	"film_library/internal/domains"
+	"film_library/pkg/pagination"
+	"film_library/pkg/validation"
+	"fmt"
+	"math"
+	"time"
+)
+
+var (
+	ErrInvalidWatchedAt    = fmt.Errorf("watched date must not be in the future")
+	ErrInvalidRewatchCount = fmt.Errorf("rewatch count must not be negative")
+)
+
+type WatchlistRepo interface {
+	AddToWatchlist(ctx context.Context, userID, filmID uint32) error
+	DeleteFromWatchlist(ctx context.Context, userID, filmID uint32) error
+	GetWatchlist(ctx context.Context, filter *pagination.FilmFilter) ([]*domains.WatchlistEntry, error)
+	SetWatched(ctx context.Context, userID, filmID uint32, watchedAt time.Time, rewatchCount *int) error
+	DeleteWatched(ctx context.Context, userID, filmID uint32) error
+	GetWatchedFilms(ctx context.Context, filter *pagination.FilmFilter) ([]*domains.WatchedEntry, error)
+}
+
+func (s *FilmService) AddToWatchlist(ctx context.Context, userID, filmID uint32) error {
+	fn := "filmService.AddToWatchlist"
+
+	err := s.repo.AddToWatchlist(ctx, userID, filmID)
+	if err != nil {
+		s.logger(ctx).Error(fmt.Sprintf("%s: %s", fn, err.Error()))
+		return fmt.Errorf("%s: %w", fn, err)
+	}
+
+	return nil
+}
+
+func (s *FilmService) RemoveFromWatchlist(ctx context.Context, userID, filmID uint32) error {
+	fn := "filmService.RemoveFromWatchlist"
+
+	err := s.repo.DeleteFromWatchlist(ctx, userID, filmID)
+	if err != nil {
+		s.logger(ctx).Error(fmt.Sprintf("%s: %s", fn, err.Error()))
+		return fmt.Errorf("%s: %w", fn, err)
+	}
+
+	return nil
+}
+
+// GetWatchlist returns the watchlist of the user filtered and sorted like
+// GetFilms, or by the time films were added.
+func (s *FilmService) GetWatchlist(ctx context.Context, userID uint32, filter *pagination.FilmFilter) ([]*domains.WatchlistEntry, error) {
+	fn := "filmService.GetWatchlist"
+
+	filter.Validate(pagination.WatchlistSortAddedAt)
+	filter.UserID = userID
+
+	entries, err := s.repo.GetWatchlist(ctx, filter)
+	if err != nil {
+		s.logger(ctx).Error(fmt.Sprintf("%s: %s", fn, err.Error()))
+		return nil, fmt.Errorf("%s: %w", fn, err)
+	}
+
+	return entries, nil
+}
+
+// MarkWatched adds the film to the watched history of the user or updates
+// its entry. A zero watchedAt is today, a nil rewatchCount keeps the count.
+func (s *FilmService) MarkWatched(ctx context.Context, userID, filmID uint32, watchedAt time.Time, rewatchCount *int) error {
+	fn := "filmService.MarkWatched"
+
+	now := time.Now().UTC()
+	if watchedAt.IsZero() {
+		watchedAt = now.Truncate(24 * time.Hour)
+	}
+
+	// Dates are sent in the time zone of the user, which may be a day ahead
+	// of UTC.
+	err := validation.Check("watchedAt", watchedAt,
+		validation.DateBetween(time.Time{}, now.AddDate(0, 0, 1)).Err(ErrInvalidWatchedAt))
+	if err == nil && rewatchCount != nil {
+		err = validation.Check("rewatchCount", *rewatchCount,
+			validation.Range(0, math.MaxInt32).Err(ErrInvalidRewatchCount))
+	}
+	if err != nil {
+		s.logger(ctx).Error(fmt.Sprintf("%s: %s", fn, err.Error()))
+		return fmt.Errorf("%s: %w", fn, err)
+	}
+
+	err = s.repo.SetWatched(ctx, userID, filmID, watchedAt, rewatchCount)
+	if err != nil {
+		s.logger(ctx).Error(fmt.Sprintf("%s: %s", fn, err.Error()))
+		return fmt.Errorf("%s: %w", fn, err)
+	}
+
+	return nil
+}
+
+func (s *FilmService) DeleteWatched(ctx context.Context, userID, filmID uint32) error {
+	fn := "filmService.DeleteWatched"
+
+	err := s.repo.DeleteWatched(ctx, userID, filmID)
+	if err != nil {
+		s.logger(ctx).Error(fmt.Sprintf("%s: %s", fn, err.Error()))
+		return fmt.Errorf("%s: %w", fn, err)
+	}
+
+	return nil
+}
+
+// GetWatchedFilms returns the watched history of the user filtered and
+// sorted like GetFilms, or by the date films were watched.
+func (s *FilmService) GetWatchedFilms(ctx context.Context, userID uint32, filter *pagination.FilmFilter) ([]*domains.WatchedEntry, error) {
+	fn := "filmService.GetWatchedFilms"
+
+	filter.Validate(pagination.WatchedSortWatchedAt)
+	filter.UserID = userID
+
+	entries, err := s.repo.GetWatchedFilms(ctx, filter)
+	if err != nil {
+		s.logger(ctx).Error(fmt.Sprintf("%s: %s", fn, err.Error()))
+		return nil, fmt.Errorf("%s: %w", fn, err)
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,114 @@
+package genreservice
+
+import (
+	"context"
+	"film_library/internal/domains"
+	"film_library/internal/logger"
+	"film_library/pkg/validation"
+	"fmt"
+	"log/slog"
+	"strings"
+)
+
+// maxNameLen matches the genres.name column.
+const maxNameLen = 50
+
+var (
+	ErrInvalidName = fmt.Errorf("genre name must be between 1 and %d characters long", maxNameLen)
+)
+
+type GenreRepo interface {
+	AddGenre(ctx context.Context, genre domains.Genre) (uint32, error)
+	GetGenres(ctx context.Context) ([]*domains.Genre, error)
+	UpdateGenre(ctx context.Context, id uint32, genre domains.Genre) error
+	DeleteGenre(ctx context.Context, id uint32) error
+}
+
+type GenreService struct {
+	repo GenreRepo
+	log  *slog.Logger
+}
+
+func New(repo GenreRepo, log *slog.Logger) *GenreService {
+	return &GenreService{
+		repo: repo,
+		log:  log,
+	}
+}
+
+func (s *GenreService) CreateGenre(ctx context.Context, genre domains.Genre) (uint32, error) {
+	fn := "genreService.CreateGenre"
+
+	genre.Name = strings.TrimSpace(genre.Name)
+
+	err := validateGenre(genre)
+	if err != nil {
+		s.logger(ctx).Error(fmt.Sprintf("%s: %s", fn, err.Error()))
+		return 0, err
+	}
+
+	id, err := s.repo.AddGenre(ctx, genre)
+	if err != nil {
+		s.logger(ctx).Error(fmt.Sprintf("%s: %s", fn, err.Error()))
+		return 0, fmt.Errorf("%s: %w", fn, err)
+	}
+
+	return id, nil
+}
+
+func (s *GenreService) GetGenres(ctx context.Context) ([]*domains.Genre, error) {
+	fn := "genreService.GetGenres"
+
+	genres, err := s.repo.GetGenres(ctx)
+	if err != nil {
+		s.logger(ctx).Error(fmt.Sprintf("%s: %s", fn, err.Error()))
+		return nil, fmt.Errorf("%s: %w", fn, err)
+	}
+
+	return genres, nil
+}
+
+func (s *GenreService) UpdateGenre(ctx context.Context, id uint32, genre domains.Genre) error {
+	fn := "genreService.UpdateGenre"
+
+	genre.Name = strings.TrimSpace(genre.Name)
+
+	err := validateGenre(genre)
+	if err != nil {
+		s.logger(ctx).Error(fmt.Sprintf("%s: %s", fn, err.Error()))
+		return err
+	}
+
+	err = s.repo.UpdateGenre(ctx, id, genre)
+	if err != nil {
+		s.logger(ctx).Error(fmt.Sprintf("%s: %s", fn, err.Error()))
+		return fmt.Errorf("%s: %w", fn, err)
+	}
+
+	return nil
+}
+
+// DeleteGenre deletes the genre and removes it from all films.
+func (s *GenreService) DeleteGenre(ctx context.Context, id uint32) error {
+	fn := "genreService.DeleteGenre"
+
+	err := s.repo.DeleteGenre(ctx, id)
+	if err != nil {
+		s.logger(ctx).Error(fmt.Sprintf("%s: %s", fn, err.Error()))
+		return fmt.Errorf("%s: %w", fn, err)
+	}
+
+	return nil
+}
+
+func validateGenre(genre domains.Genre) error {
+	return validation.NewValidator[domains.Genre](genre).
+		String("name",
+			func(g domains.Genre) string { return g.Name },
+			validation.Length(1, maxNameLen).Err(ErrInvalidName)).
+		Validate()
+}
+
+func (s *GenreService) logger(ctx context.Context) *slog.Logger {
+	return logger.FromContext(ctx, s.log)
+}
@@ -5,7 +5,9 @@
 package mock_services
 
 import (
+	context "context"
 	domains "film_library/internal/domains"
+	tokens "film_library/internal/tokens"
 	pagination "film_library/pkg/pagination"
 	reflect "reflect"
 	time "time"
@@ -36,34 +38,236 @@ func (m *MockUserService) EXPECT() *MockUserServiceMockRecorder {
 	return m.recorder
 }
 
+// ChangePassword mocks base method.
+func (m *MockUserService) ChangePassword(ctx context.Context, id uint32, currentPassword, newPassword string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangePassword", ctx, id, currentPassword, newPassword)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ChangePassword indicates an expected call of ChangePassword.
+func (mr *MockUserServiceMockRecorder) ChangePassword(ctx, id, currentPassword, newPassword interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangePassword", reflect.TypeOf((*MockUserService)(nil).ChangePassword), ctx, id, currentPassword, newPassword)
+}
+
 // CreateUser mocks base method.
-func (m *MockUserService) CreateUser(user domains.User) (string, error) {
+func (m *MockUserService) CreateUser(ctx context.Context, user domains.User) (*domains.Tokens, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateUser", user)
-	ret0, _ := ret[0].(string)
+	ret := m.ctrl.Call(m, "CreateUser", ctx, user)
+	ret0, _ := ret[0].(*domains.Tokens)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateUser indicates an expected call of CreateUser.
-func (mr *MockUserServiceMockRecorder) CreateUser(user interface{}) *gomock.Call {
+func (mr *MockUserServiceMockRecorder) CreateUser(ctx, user interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockUserService)(nil).CreateUser), ctx, user)
+}
+
+// DeleteAccount mocks base method.
+func (m *MockUserService) DeleteAccount(ctx context.Context, id uint32, password string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAccount", ctx, id, password)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAccount indicates an expected call of DeleteAccount.
+func (mr *MockUserServiceMockRecorder) DeleteAccount(ctx, id, password interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAccount", reflect.TypeOf((*MockUserService)(nil).DeleteAccount), ctx, id, password)
+}
+
+// DeleteUser mocks base method.
+func (m *MockUserService) DeleteUser(ctx context.Context, id uint32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUser", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteUser indicates an expected call of DeleteUser.
+func (mr *MockUserServiceMockRecorder) DeleteUser(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockUserService)(nil).DeleteUser), ctx, id)
+}
+
+// GetProfile mocks base method.
+func (m *MockUserService) GetProfile(ctx context.Context, principal domains.Principal) (*domains.Profile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProfile", ctx, principal)
+	ret0, _ := ret[0].(*domains.Profile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProfile indicates an expected call of GetProfile.
+func (mr *MockUserServiceMockRecorder) GetProfile(ctx, principal interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProfile", reflect.TypeOf((*MockUserService)(nil).GetProfile), ctx, principal)
+}
+
+// GetUserByID mocks base method.
+func (m *MockUserService) GetUserByID(ctx context.Context, id uint32) (*domains.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByID", ctx, id)
+	ret0, _ := ret[0].(*domains.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByID indicates an expected call of GetUserByID.
+func (mr *MockUserServiceMockRecorder) GetUserByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByID", reflect.TypeOf((*MockUserService)(nil).GetUserByID), ctx, id)
+}
+
+// GetUsers mocks base method.
+func (m *MockUserService) GetUsers(ctx context.Context, page *pagination.Pagination) ([]*domains.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUsers", ctx, page)
+	ret0, _ := ret[0].([]*domains.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUsers indicates an expected call of GetUsers.
+func (mr *MockUserServiceMockRecorder) GetUsers(ctx, page interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsers", reflect.TypeOf((*MockUserService)(nil).GetUsers), ctx, page)
+}
+
+// IsTokenRevoked mocks base method.
+func (m *MockUserService) IsTokenRevoked(ctx context.Context, jti string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsTokenRevoked", ctx, jti)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsTokenRevoked indicates an expected call of IsTokenRevoked.
+func (mr *MockUserServiceMockRecorder) IsTokenRevoked(ctx, jti interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsTokenRevoked", reflect.TypeOf((*MockUserService)(nil).IsTokenRevoked), ctx, jti)
+}
+
+// IssuePasswordReset mocks base method.
+func (m *MockUserService) IssuePasswordReset(ctx context.Context, caller domains.Principal, id uint32) (*domains.PasswordReset, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IssuePasswordReset", ctx, caller, id)
+	ret0, _ := ret[0].(*domains.PasswordReset)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IssuePasswordReset indicates an expected call of IssuePasswordReset.
+func (mr *MockUserServiceMockRecorder) IssuePasswordReset(ctx, caller, id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockUserService)(nil).CreateUser), user)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IssuePasswordReset", reflect.TypeOf((*MockUserService)(nil).IssuePasswordReset), ctx, caller, id)
 }
 
 // Login mocks base method.
-func (m *MockUserService) Login(login, password string) (string, error) {
+func (m *MockUserService) Login(ctx context.Context, login, password, ip string) (*domains.Tokens, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Login", login, password)
-	ret0, _ := ret[0].(string)
+	ret := m.ctrl.Call(m, "Login", ctx, login, password, ip)
+	ret0, _ := ret[0].(*domains.Tokens)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Login indicates an expected call of Login.
-func (mr *MockUserServiceMockRecorder) Login(login, password interface{}) *gomock.Call {
+func (mr *MockUserServiceMockRecorder) Login(ctx, login, password, ip interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Login", reflect.TypeOf((*MockUserService)(nil).Login), login, password)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Login", reflect.TypeOf((*MockUserService)(nil).Login), ctx, login, password, ip)
+}
+
+// Logout mocks base method.
+func (m *MockUserService) Logout(ctx context.Context, claims *tokens.Claims, refreshToken string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Logout", ctx, claims, refreshToken)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Logout indicates an expected call of Logout.
+func (mr *MockUserServiceMockRecorder) Logout(ctx, claims, refreshToken interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Logout", reflect.TypeOf((*MockUserService)(nil).Logout), ctx, claims, refreshToken)
+}
+
+// RefreshTokens mocks base method.
+func (m *MockUserService) RefreshTokens(ctx context.Context, refreshToken string) (*domains.Tokens, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefreshTokens", ctx, refreshToken)
+	ret0, _ := ret[0].(*domains.Tokens)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RefreshTokens indicates an expected call of RefreshTokens.
+func (mr *MockUserServiceMockRecorder) RefreshTokens(ctx, refreshToken interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshTokens", reflect.TypeOf((*MockUserService)(nil).RefreshTokens), ctx, refreshToken)
+}
+
+// ResetPassword mocks base method.
+func (m *MockUserService) ResetPassword(ctx context.Context, resetToken, newPassword string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetPassword", ctx, resetToken, newPassword)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetPassword indicates an expected call of ResetPassword.
+func (mr *MockUserServiceMockRecorder) ResetPassword(ctx, resetToken, newPassword interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetPassword", reflect.TypeOf((*MockUserService)(nil).ResetPassword), ctx, resetToken, newPassword)
+}
+
+// SetUserDisabled mocks base method.
+func (m *MockUserService) SetUserDisabled(ctx context.Context, id uint32, disabled bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetUserDisabled", ctx, id, disabled)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetUserDisabled indicates an expected call of SetUserDisabled.
+func (mr *MockUserServiceMockRecorder) SetUserDisabled(ctx, id, disabled interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUserDisabled", reflect.TypeOf((*MockUserService)(nil).SetUserDisabled), ctx, id, disabled)
+}
+
+// UpdateDisplayName mocks base method.
+func (m *MockUserService) UpdateDisplayName(ctx context.Context, id uint32, displayName string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateDisplayName", ctx, id, displayName)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateDisplayName indicates an expected call of UpdateDisplayName.
+func (mr *MockUserServiceMockRecorder) UpdateDisplayName(ctx, id, displayName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDisplayName", reflect.TypeOf((*MockUserService)(nil).UpdateDisplayName), ctx, id, displayName)
+}
+
+// UpdateUserRole mocks base method.
+func (m *MockUserService) UpdateUserRole(ctx context.Context, id uint32, role domains.Role) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserRole", ctx, id, role)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateUserRole indicates an expected call of UpdateUserRole.
+func (mr *MockUserServiceMockRecorder) UpdateUserRole(ctx, id, role interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserRole", reflect.TypeOf((*MockUserService)(nil).UpdateUserRole), ctx, id, role)
 }
 
 // MockFilmService is a mock of FilmService interface.
@@ -89,118 +293,249 @@ func (m *MockFilmService) EXPECT() *MockFilmServiceMockRecorder {
 	return m.recorder
 }
 
+// AddToWatchlist mocks base method.
+func (m *MockFilmService) AddToWatchlist(ctx context.Context, userID, filmID uint32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddToWatchlist", ctx, userID, filmID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddToWatchlist indicates an expected call of AddToWatchlist.
+func (mr *MockFilmServiceMockRecorder) AddToWatchlist(ctx, userID, filmID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddToWatchlist", reflect.TypeOf((*MockFilmService)(nil).AddToWatchlist), ctx, userID, filmID)
+}
+
 // CreateFilm mocks base method.
-func (m *MockFilmService) CreateFilm(film domains.Film, actors []uint32) (uint32, error) {
+func (m *MockFilmService) CreateFilm(ctx context.Context, film domains.Film, actors, genres []uint32) (uint32, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateFilm", film, actors)
+	ret := m.ctrl.Call(m, "CreateFilm", ctx, film, actors, genres)
 	ret0, _ := ret[0].(uint32)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateFilm indicates an expected call of CreateFilm.
-func (mr *MockFilmServiceMockRecorder) CreateFilm(film, actors interface{}) *gomock.Call {
+func (mr *MockFilmServiceMockRecorder) CreateFilm(ctx, film, actors, genres interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFilm", reflect.TypeOf((*MockFilmService)(nil).CreateFilm), film, actors)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFilm", reflect.TypeOf((*MockFilmService)(nil).CreateFilm), ctx, film, actors, genres)
 }
 
 // DeleteFilm mocks base method.
-func (m *MockFilmService) DeleteFilm(id uint32) error {
+func (m *MockFilmService) DeleteFilm(ctx context.Context, id uint32) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteFilm", id)
+	ret := m.ctrl.Call(m, "DeleteFilm", ctx, id)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteFilm indicates an expected call of DeleteFilm.
-func (mr *MockFilmServiceMockRecorder) DeleteFilm(id interface{}) *gomock.Call {
+func (mr *MockFilmServiceMockRecorder) DeleteFilm(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFilm", reflect.TypeOf((*MockFilmService)(nil).DeleteFilm), ctx, id)
+}
+
+// DeleteFilmRating mocks base method.
+func (m *MockFilmService) DeleteFilmRating(ctx context.Context, userID, filmID uint32) (*domains.FilmScore, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFilmRating", ctx, userID, filmID)
+	ret0, _ := ret[0].(*domains.FilmScore)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteFilmRating indicates an expected call of DeleteFilmRating.
+func (mr *MockFilmServiceMockRecorder) DeleteFilmRating(ctx, userID, filmID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFilm", reflect.TypeOf((*MockFilmService)(nil).DeleteFilm), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFilmRating", reflect.TypeOf((*MockFilmService)(nil).DeleteFilmRating), ctx, userID, filmID)
+}
+
+// DeleteWatched mocks base method.
+func (m *MockFilmService) DeleteWatched(ctx context.Context, userID, filmID uint32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWatched", ctx, userID, filmID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteWatched indicates an expected call of DeleteWatched.
+func (mr *MockFilmServiceMockRecorder) DeleteWatched(ctx, userID, filmID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWatched", reflect.TypeOf((*MockFilmService)(nil).DeleteWatched), ctx, userID, filmID)
+}
+
+// GetFilmByID mocks base method.
+func (m *MockFilmService) GetFilmByID(ctx context.Context, id uint32) (*domains.FilmWithCredits, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFilmByID", ctx, id)
+	ret0, _ := ret[0].(*domains.FilmWithCredits)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFilmByID indicates an expected call of GetFilmByID.
+func (mr *MockFilmServiceMockRecorder) GetFilmByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFilmByID", reflect.TypeOf((*MockFilmService)(nil).GetFilmByID), ctx, id)
 }
 
 // GetFilms mocks base method.
-func (m *MockFilmService) GetFilms(filter *pagination.FilmFilter) ([]*domains.Film, error) {
+func (m *MockFilmService) GetFilms(ctx context.Context, filter *pagination.FilmFilter) ([]*domains.Film, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetFilms", filter)
+	ret := m.ctrl.Call(m, "GetFilms", ctx, filter)
 	ret0, _ := ret[0].([]*domains.Film)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetFilms indicates an expected call of GetFilms.
-func (mr *MockFilmServiceMockRecorder) GetFilms(filter interface{}) *gomock.Call {
+func (mr *MockFilmServiceMockRecorder) GetFilms(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFilms", reflect.TypeOf((*MockFilmService)(nil).GetFilms), ctx, filter)
+}
+
+// GetWatchedFilms mocks base method.
+func (m *MockFilmService) GetWatchedFilms(ctx context.Context, userID uint32, filter *pagination.FilmFilter) ([]*domains.WatchedEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWatchedFilms", ctx, userID, filter)
+	ret0, _ := ret[0].([]*domains.WatchedEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWatchedFilms indicates an expected call of GetWatchedFilms.
+func (mr *MockFilmServiceMockRecorder) GetWatchedFilms(ctx, userID, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWatchedFilms", reflect.TypeOf((*MockFilmService)(nil).GetWatchedFilms), ctx, userID, filter)
+}
+
+// GetWatchlist mocks base method.
+func (m *MockFilmService) GetWatchlist(ctx context.Context, userID uint32, filter *pagination.FilmFilter) ([]*domains.WatchlistEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWatchlist", ctx, userID, filter)
+	ret0, _ := ret[0].([]*domains.WatchlistEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWatchlist indicates an expected call of GetWatchlist.
+func (mr *MockFilmServiceMockRecorder) GetWatchlist(ctx, userID, filter interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFilms", reflect.TypeOf((*MockFilmService)(nil).GetFilms), filter)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWatchlist", reflect.TypeOf((*MockFilmService)(nil).GetWatchlist), ctx, userID, filter)
+}
+
+// MarkWatched mocks base method.
+func (m *MockFilmService) MarkWatched(ctx context.Context, userID, filmID uint32, watchedAt time.Time, rewatchCount *int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkWatched", ctx, userID, filmID, watchedAt, rewatchCount)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkWatched indicates an expected call of MarkWatched.
+func (mr *MockFilmServiceMockRecorder) MarkWatched(ctx, userID, filmID, watchedAt, rewatchCount interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkWatched", reflect.TypeOf((*MockFilmService)(nil).MarkWatched), ctx, userID, filmID, watchedAt, rewatchCount)
+}
+
+// RateFilm mocks base method.
+func (m *MockFilmService) RateFilm(ctx context.Context, userID, filmID uint32, rating int) (*domains.FilmScore, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RateFilm", ctx, userID, filmID, rating)
+	ret0, _ := ret[0].(*domains.FilmScore)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RateFilm indicates an expected call of RateFilm.
+func (mr *MockFilmServiceMockRecorder) RateFilm(ctx, userID, filmID, rating interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RateFilm", reflect.TypeOf((*MockFilmService)(nil).RateFilm), ctx, userID, filmID, rating)
+}
+
+// RemoveFromWatchlist mocks base method.
+func (m *MockFilmService) RemoveFromWatchlist(ctx context.Context, userID, filmID uint32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveFromWatchlist", ctx, userID, filmID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveFromWatchlist indicates an expected call of RemoveFromWatchlist.
+func (mr *MockFilmServiceMockRecorder) RemoveFromWatchlist(ctx, userID, filmID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveFromWatchlist", reflect.TypeOf((*MockFilmService)(nil).RemoveFromWatchlist), ctx, userID, filmID)
 }
 
 // UpdateFilm mocks base method.
-func (m *MockFilmService) UpdateFilm(id uint32, film domains.Film) error {
+func (m *MockFilmService) UpdateFilm(ctx context.Context, id uint32, film domains.Film, genres []uint32) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateFilm", id, film)
+	ret := m.ctrl.Call(m, "UpdateFilm", ctx, id, film, genres)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateFilm indicates an expected call of UpdateFilm.
-func (mr *MockFilmServiceMockRecorder) UpdateFilm(id, film interface{}) *gomock.Call {
+func (mr *MockFilmServiceMockRecorder) UpdateFilm(ctx, id, film, genres interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFilm", reflect.TypeOf((*MockFilmService)(nil).UpdateFilm), id, film)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFilm", reflect.TypeOf((*MockFilmService)(nil).UpdateFilm), ctx, id, film, genres)
 }
 
 // UpdateFilmDescription mocks base method.
-func (m *MockFilmService) UpdateFilmDescription(id uint32, descrtion string) error {
+func (m *MockFilmService) UpdateFilmDescription(ctx context.Context, id uint32, descrtion string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateFilmDescription", id, descrtion)
+	ret := m.ctrl.Call(m, "UpdateFilmDescription", ctx, id, descrtion)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateFilmDescription indicates an expected call of UpdateFilmDescription.
-func (mr *MockFilmServiceMockRecorder) UpdateFilmDescription(id, descrtion interface{}) *gomock.Call {
+func (mr *MockFilmServiceMockRecorder) UpdateFilmDescription(ctx, id, descrtion interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFilmDescription", reflect.TypeOf((*MockFilmService)(nil).UpdateFilmDescription), id, descrtion)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFilmDescription", reflect.TypeOf((*MockFilmService)(nil).UpdateFilmDescription), ctx, id, descrtion)
 }
 
 // UpdateFilmName mocks base method.
-func (m *MockFilmService) UpdateFilmName(id uint32, name string) error {
+func (m *MockFilmService) UpdateFilmName(ctx context.Context, id uint32, name string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateFilmName", id, name)
+	ret := m.ctrl.Call(m, "UpdateFilmName", ctx, id, name)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateFilmName indicates an expected call of UpdateFilmName.
-func (mr *MockFilmServiceMockRecorder) UpdateFilmName(id, name interface{}) *gomock.Call {
+func (mr *MockFilmServiceMockRecorder) UpdateFilmName(ctx, id, name interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFilmName", reflect.TypeOf((*MockFilmService)(nil).UpdateFilmName), id, name)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFilmName", reflect.TypeOf((*MockFilmService)(nil).UpdateFilmName), ctx, id, name)
 }
 
 // UpdateFilmRating mocks base method.
-func (m *MockFilmService) UpdateFilmRating(id uint32, rating int) error {
+func (m *MockFilmService) UpdateFilmRating(ctx context.Context, id uint32, rating int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateFilmRating", id, rating)
+	ret := m.ctrl.Call(m, "UpdateFilmRating", ctx, id, rating)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateFilmRating indicates an expected call of UpdateFilmRating.
-func (mr *MockFilmServiceMockRecorder) UpdateFilmRating(id, rating interface{}) *gomock.Call {
+func (mr *MockFilmServiceMockRecorder) UpdateFilmRating(ctx, id, rating interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFilmRating", reflect.TypeOf((*MockFilmService)(nil).UpdateFilmRating), id, rating)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFilmRating", reflect.TypeOf((*MockFilmService)(nil).UpdateFilmRating), ctx, id, rating)
 }
 
 // UpdateFilmReleaseDate mocks base method.
-func (m *MockFilmService) UpdateFilmReleaseDate(id uint32, releaseDate time.Time) error {
+func (m *MockFilmService) UpdateFilmReleaseDate(ctx context.Context, id uint32, releaseDate time.Time) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateFilmReleaseDate", id, releaseDate)
+	ret := m.ctrl.Call(m, "UpdateFilmReleaseDate", ctx, id, releaseDate)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateFilmReleaseDate indicates an expected call of UpdateFilmReleaseDate.
-func (mr *MockFilmServiceMockRecorder) UpdateFilmReleaseDate(id, releaseDate interface{}) *gomock.Call {
+func (mr *MockFilmServiceMockRecorder) UpdateFilmReleaseDate(ctx, id, releaseDate interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFilmReleaseDate", reflect.TypeOf((*MockFilmService)(nil).UpdateFilmReleaseDate), id, releaseDate)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFilmReleaseDate", reflect.TypeOf((*MockFilmService)(nil).UpdateFilmReleaseDate), ctx, id, releaseDate)
 }
 
 // MockActorService is a mock of ActorService interface.
@@ -227,422 +562,1432 @@ func (m *MockActorService) EXPECT() *MockActorServiceMockRecorder {
 }
 
 // AddActorsToFilm mocks base method.
-func (m *MockActorService) AddActorsToFilm(filmID uint32, actorsID []uint32) error {
+func (m *MockActorService) AddActorsToFilm(ctx context.Context, filmID uint32, credits []domains.Credit) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AddActorsToFilm", filmID, actorsID)
+	ret := m.ctrl.Call(m, "AddActorsToFilm", ctx, filmID, credits)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // AddActorsToFilm indicates an expected call of AddActorsToFilm.
-func (mr *MockActorServiceMockRecorder) AddActorsToFilm(filmID, actorsID interface{}) *gomock.Call {
+func (mr *MockActorServiceMockRecorder) AddActorsToFilm(ctx, filmID, credits interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddActorsToFilm", reflect.TypeOf((*MockActorService)(nil).AddActorsToFilm), filmID, actorsID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddActorsToFilm", reflect.TypeOf((*MockActorService)(nil).AddActorsToFilm), ctx, filmID, credits)
 }
 
 // CreateActor mocks base method.
-func (m *MockActorService) CreateActor(actor domains.Actor) error {
+func (m *MockActorService) CreateActor(ctx context.Context, actor domains.Person) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateActor", actor)
+	ret := m.ctrl.Call(m, "CreateActor", ctx, actor)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // CreateActor indicates an expected call of CreateActor.
-func (mr *MockActorServiceMockRecorder) CreateActor(actor interface{}) *gomock.Call {
+func (mr *MockActorServiceMockRecorder) CreateActor(ctx, actor interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateActor", reflect.TypeOf((*MockActorService)(nil).CreateActor), actor)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateActor", reflect.TypeOf((*MockActorService)(nil).CreateActor), ctx, actor)
 }
 
 // DeleteActor mocks base method.
-func (m *MockActorService) DeleteActor(id uint32) error {
+func (m *MockActorService) DeleteActor(ctx context.Context, id uint32) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteActor", id)
+	ret := m.ctrl.Call(m, "DeleteActor", ctx, id)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteActor indicates an expected call of DeleteActor.
-func (mr *MockActorServiceMockRecorder) DeleteActor(id interface{}) *gomock.Call {
+func (mr *MockActorServiceMockRecorder) DeleteActor(ctx, id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteActor", reflect.TypeOf((*MockActorService)(nil).DeleteActor), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteActor", reflect.TypeOf((*MockActorService)(nil).DeleteActor), ctx, id)
 }
 
 // DeleteActorFromFilm mocks base method.
-func (m *MockActorService) DeleteActorFromFilm(actorID, filmID uint32) error {
+func (m *MockActorService) DeleteActorFromFilm(ctx context.Context, actorID, filmID uint32) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteActorFromFilm", actorID, filmID)
+	ret := m.ctrl.Call(m, "DeleteActorFromFilm", ctx, actorID, filmID)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteActorFromFilm indicates an expected call of DeleteActorFromFilm.
-func (mr *MockActorServiceMockRecorder) DeleteActorFromFilm(actorID, filmID interface{}) *gomock.Call {
+func (mr *MockActorServiceMockRecorder) DeleteActorFromFilm(ctx, actorID, filmID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteActorFromFilm", reflect.TypeOf((*MockActorService)(nil).DeleteActorFromFilm), ctx, actorID, filmID)
+}
+
+// GetActorByID mocks base method.
+func (m *MockActorService) GetActorByID(ctx context.Context, id uint32) (*domains.PersonWithCredits, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActorByID", ctx, id)
+	ret0, _ := ret[0].(*domains.PersonWithCredits)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActorByID indicates an expected call of GetActorByID.
+func (mr *MockActorServiceMockRecorder) GetActorByID(ctx, id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteActorFromFilm", reflect.TypeOf((*MockActorService)(nil).DeleteActorFromFilm), actorID, filmID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActorByID", reflect.TypeOf((*MockActorService)(nil).GetActorByID), ctx, id)
 }
 
 // GetActorsWithFilms mocks base method.
-func (m *MockActorService) GetActorsWithFilms(filter *pagination.ActorsFilter) ([]*domains.ActorWithFilms, error) {
+func (m *MockActorService) GetActorsWithFilms(ctx context.Context, filter *pagination.ActorsFilter) ([]*domains.PersonWithCredits, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetActorsWithFilms", filter)
-	ret0, _ := ret[0].([]*domains.ActorWithFilms)
+	ret := m.ctrl.Call(m, "GetActorsWithFilms", ctx, filter)
+	ret0, _ := ret[0].([]*domains.PersonWithCredits)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetActorsWithFilms indicates an expected call of GetActorsWithFilms.
-func (mr *MockActorServiceMockRecorder) GetActorsWithFilms(filter interface{}) *gomock.Call {
+func (mr *MockActorServiceMockRecorder) GetActorsWithFilms(ctx, filter interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActorsWithFilms", reflect.TypeOf((*MockActorService)(nil).GetActorsWithFilms), filter)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActorsWithFilms", reflect.TypeOf((*MockActorService)(nil).GetActorsWithFilms), ctx, filter)
+}
+
+// ReplaceFilmActors mocks base method.
+func (m *MockActorService) ReplaceFilmActors(ctx context.Context, filmID uint32, credits []domains.Credit) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceFilmActors", ctx, filmID, credits)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplaceFilmActors indicates an expected call of ReplaceFilmActors.
+func (mr *MockActorServiceMockRecorder) ReplaceFilmActors(ctx, filmID, credits interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceFilmActors", reflect.TypeOf((*MockActorService)(nil).ReplaceFilmActors), ctx, filmID, credits)
 }
 
 // UpdateActor mocks base method.
-func (m *MockActorService) UpdateActor(id uint32, actor domains.Actor) error {
+func (m *MockActorService) UpdateActor(ctx context.Context, id uint32, actor domains.Person) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateActor", id, actor)
+	ret := m.ctrl.Call(m, "UpdateActor", ctx, id, actor)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateActor indicates an expected call of UpdateActor.
-func (mr *MockActorServiceMockRecorder) UpdateActor(id, actor interface{}) *gomock.Call {
+func (mr *MockActorServiceMockRecorder) UpdateActor(ctx, id, actor interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateActor", reflect.TypeOf((*MockActorService)(nil).UpdateActor), id, actor)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateActor", reflect.TypeOf((*MockActorService)(nil).UpdateActor), ctx, id, actor)
 }
 
 // UpdateActorBirthday mocks base method.
-func (m *MockActorService) UpdateActorBirthday(id uint32, birthday time.Time) error {
+func (m *MockActorService) UpdateActorBirthday(ctx context.Context, id uint32, birthday time.Time) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateActorBirthday", id, birthday)
+	ret := m.ctrl.Call(m, "UpdateActorBirthday", ctx, id, birthday)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateActorBirthday indicates an expected call of UpdateActorBirthday.
-func (mr *MockActorServiceMockRecorder) UpdateActorBirthday(id, birthday interface{}) *gomock.Call {
+func (mr *MockActorServiceMockRecorder) UpdateActorBirthday(ctx, id, birthday interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateActorBirthday", reflect.TypeOf((*MockActorService)(nil).UpdateActorBirthday), id, birthday)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateActorBirthday", reflect.TypeOf((*MockActorService)(nil).UpdateActorBirthday), ctx, id, birthday)
 }
 
 // UpdateActorFullName mocks base method.
-func (m *MockActorService) UpdateActorFullName(id uint32, fullName string) error {
+func (m *MockActorService) UpdateActorFullName(ctx context.Context, id uint32, fullName string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateActorFullName", id, fullName)
+	ret := m.ctrl.Call(m, "UpdateActorFullName", ctx, id, fullName)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateActorFullName indicates an expected call of UpdateActorFullName.
-func (mr *MockActorServiceMockRecorder) UpdateActorFullName(id, fullName interface{}) *gomock.Call {
+func (mr *MockActorServiceMockRecorder) UpdateActorFullName(ctx, id, fullName interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateActorFullName", reflect.TypeOf((*MockActorService)(nil).UpdateActorFullName), id, fullName)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateActorFullName", reflect.TypeOf((*MockActorService)(nil).UpdateActorFullName), ctx, id, fullName)
 }
 
 // UpdateActorGender mocks base method.
-func (m *MockActorService) UpdateActorGender(id uint32, gender domains.Gender) error {
+func (m *MockActorService) UpdateActorGender(ctx context.Context, id uint32, gender domains.Gender) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateActorGender", id, gender)
+	ret := m.ctrl.Call(m, "UpdateActorGender", ctx, id, gender)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateActorGender indicates an expected call of UpdateActorGender.
-func (mr *MockActorServiceMockRecorder) UpdateActorGender(id, gender interface{}) *gomock.Call {
+func (mr *MockActorServiceMockRecorder) UpdateActorGender(ctx, id, gender interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateActorGender", reflect.TypeOf((*MockActorService)(nil).UpdateActorGender), id, gender)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateActorGender", reflect.TypeOf((*MockActorService)(nil).UpdateActorGender), ctx, id, gender)
 }
 
-// MockIService is a mock of IService interface.
-type MockIService struct {
+// MockGenreService is a mock of GenreService interface.
+type MockGenreService struct {
 	ctrl     *gomock.Controller
-	recorder *MockIServiceMockRecorder
+	recorder *MockGenreServiceMockRecorder
 }
 
-// MockIServiceMockRecorder is the mock recorder for MockIService.
-type MockIServiceMockRecorder struct {
-	mock *MockIService
+// MockGenreServiceMockRecorder is the mock recorder for MockGenreService.
+type MockGenreServiceMockRecorder struct {
+	mock *MockGenreService
 }
 
-// NewMockIService creates a new mock instance.
-func NewMockIService(ctrl *gomock.Controller) *MockIService {
-	mock := &MockIService{ctrl: ctrl}
-	mock.recorder = &MockIServiceMockRecorder{mock}
+// NewMockGenreService creates a new mock instance.
+func NewMockGenreService(ctrl *gomock.Controller) *MockGenreService {
+	mock := &MockGenreService{ctrl: ctrl}
+	mock.recorder = &MockGenreServiceMockRecorder{mock}
 	return mock
 }
 
 // EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockIService) EXPECT() *MockIServiceMockRecorder {
+func (m *MockGenreService) EXPECT() *MockGenreServiceMockRecorder {
 	return m.recorder
 }
 
-// AddActorsToFilm mocks base method.
-func (m *MockIService) AddActorsToFilm(filmID uint32, actorsID []uint32) error {
+// CreateGenre mocks base method.
+func (m *MockGenreService) CreateGenre(ctx context.Context, genre domains.Genre) (uint32, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AddActorsToFilm", filmID, actorsID)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "CreateGenre", ctx, genre)
+	ret0, _ := ret[0].(uint32)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// AddActorsToFilm indicates an expected call of AddActorsToFilm.
-func (mr *MockIServiceMockRecorder) AddActorsToFilm(filmID, actorsID interface{}) *gomock.Call {
+// CreateGenre indicates an expected call of CreateGenre.
+func (mr *MockGenreServiceMockRecorder) CreateGenre(ctx, genre interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddActorsToFilm", reflect.TypeOf((*MockIService)(nil).AddActorsToFilm), filmID, actorsID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateGenre", reflect.TypeOf((*MockGenreService)(nil).CreateGenre), ctx, genre)
 }
 
-// CreateActor mocks base method.
-func (m *MockIService) CreateActor(actor domains.Actor) error {
+// DeleteGenre mocks base method.
+func (m *MockGenreService) DeleteGenre(ctx context.Context, id uint32) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateActor", actor)
+	ret := m.ctrl.Call(m, "DeleteGenre", ctx, id)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// CreateActor indicates an expected call of CreateActor.
-func (mr *MockIServiceMockRecorder) CreateActor(actor interface{}) *gomock.Call {
+// DeleteGenre indicates an expected call of DeleteGenre.
+func (mr *MockGenreServiceMockRecorder) DeleteGenre(ctx, id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateActor", reflect.TypeOf((*MockIService)(nil).CreateActor), actor)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteGenre", reflect.TypeOf((*MockGenreService)(nil).DeleteGenre), ctx, id)
 }
 
-// CreateFilm mocks base method.
-func (m *MockIService) CreateFilm(film domains.Film, actors []uint32) (uint32, error) {
+// GetGenres mocks base method.
+func (m *MockGenreService) GetGenres(ctx context.Context) ([]*domains.Genre, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateFilm", film, actors)
-	ret0, _ := ret[0].(uint32)
+	ret := m.ctrl.Call(m, "GetGenres", ctx)
+	ret0, _ := ret[0].([]*domains.Genre)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateFilm indicates an expected call of CreateFilm.
-func (mr *MockIServiceMockRecorder) CreateFilm(film, actors interface{}) *gomock.Call {
+// GetGenres indicates an expected call of GetGenres.
+func (mr *MockGenreServiceMockRecorder) GetGenres(ctx interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFilm", reflect.TypeOf((*MockIService)(nil).CreateFilm), film, actors)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGenres", reflect.TypeOf((*MockGenreService)(nil).GetGenres), ctx)
 }
 
-// CreateUser mocks base method.
-func (m *MockIService) CreateUser(user domains.User) (string, error) {
+// UpdateGenre mocks base method.
+func (m *MockGenreService) UpdateGenre(ctx context.Context, id uint32, genre domains.Genre) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateUser", user)
-	ret0, _ := ret[0].(string)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "UpdateGenre", ctx, id, genre)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// CreateUser indicates an expected call of CreateUser.
-func (mr *MockIServiceMockRecorder) CreateUser(user interface{}) *gomock.Call {
+// UpdateGenre indicates an expected call of UpdateGenre.
+func (mr *MockGenreServiceMockRecorder) UpdateGenre(ctx, id, genre interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockIService)(nil).CreateUser), user)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateGenre", reflect.TypeOf((*MockGenreService)(nil).UpdateGenre), ctx, id, genre)
 }
 
-// DeleteActor mocks base method.
-func (m *MockIService) DeleteActor(id uint32) error {
+// MockReviewService is a mock of ReviewService interface.
+type MockReviewService struct {
+	ctrl     *gomock.Controller
+	recorder *MockReviewServiceMockRecorder
+}
+
+// MockReviewServiceMockRecorder is the mock recorder for MockReviewService.
+type MockReviewServiceMockRecorder struct {
+	mock *MockReviewService
+}
+
+// NewMockReviewService creates a new mock instance.
+func NewMockReviewService(ctrl *gomock.Controller) *MockReviewService {
+	mock := &MockReviewService{ctrl: ctrl}
+	mock.recorder = &MockReviewServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReviewService) EXPECT() *MockReviewServiceMockRecorder {
+	return m.recorder
+}
+
+// CreateReview mocks base method.
+func (m *MockReviewService) CreateReview(ctx context.Context, userID, filmID uint32, text string) (uint32, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteActor", id)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "CreateReview", ctx, userID, filmID, text)
+	ret0, _ := ret[0].(uint32)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// DeleteActor indicates an expected call of DeleteActor.
-func (mr *MockIServiceMockRecorder) DeleteActor(id interface{}) *gomock.Call {
+// CreateReview indicates an expected call of CreateReview.
+func (mr *MockReviewServiceMockRecorder) CreateReview(ctx, userID, filmID, text interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteActor", reflect.TypeOf((*MockIService)(nil).DeleteActor), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateReview", reflect.TypeOf((*MockReviewService)(nil).CreateReview), ctx, userID, filmID, text)
 }
 
-// DeleteActorFromFilm mocks base method.
-func (m *MockIService) DeleteActorFromFilm(actorID, filmID uint32) error {
+// DeleteReview mocks base method.
+func (m *MockReviewService) DeleteReview(ctx context.Context, userID, id uint32) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteActorFromFilm", actorID, filmID)
+	ret := m.ctrl.Call(m, "DeleteReview", ctx, userID, id)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// DeleteActorFromFilm indicates an expected call of DeleteActorFromFilm.
-func (mr *MockIServiceMockRecorder) DeleteActorFromFilm(actorID, filmID interface{}) *gomock.Call {
+// DeleteReview indicates an expected call of DeleteReview.
+func (mr *MockReviewServiceMockRecorder) DeleteReview(ctx, userID, id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteActorFromFilm", reflect.TypeOf((*MockIService)(nil).DeleteActorFromFilm), actorID, filmID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteReview", reflect.TypeOf((*MockReviewService)(nil).DeleteReview), ctx, userID, id)
 }
 
-// DeleteFilm mocks base method.
-func (m *MockIService) DeleteFilm(id uint32) error {
+// DeleteReviewVote mocks base method.
+func (m *MockReviewService) DeleteReviewVote(ctx context.Context, userID, id uint32) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteFilm", id)
+	ret := m.ctrl.Call(m, "DeleteReviewVote", ctx, userID, id)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// DeleteFilm indicates an expected call of DeleteFilm.
-func (mr *MockIServiceMockRecorder) DeleteFilm(id interface{}) *gomock.Call {
+// DeleteReviewVote indicates an expected call of DeleteReviewVote.
+func (mr *MockReviewServiceMockRecorder) DeleteReviewVote(ctx, userID, id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFilm", reflect.TypeOf((*MockIService)(nil).DeleteFilm), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteReviewVote", reflect.TypeOf((*MockReviewService)(nil).DeleteReviewVote), ctx, userID, id)
 }
 
-// GetActorsWithFilms mocks base method.
-func (m *MockIService) GetActorsWithFilms(filter *pagination.ActorsFilter) ([]*domains.ActorWithFilms, error) {
+// GetFilmReviews mocks base method.
+func (m *MockReviewService) GetFilmReviews(ctx context.Context, filter *pagination.ReviewFilter) ([]*domains.Review, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetActorsWithFilms", filter)
-	ret0, _ := ret[0].([]*domains.ActorWithFilms)
+	ret := m.ctrl.Call(m, "GetFilmReviews", ctx, filter)
+	ret0, _ := ret[0].([]*domains.Review)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetActorsWithFilms indicates an expected call of GetActorsWithFilms.
-func (mr *MockIServiceMockRecorder) GetActorsWithFilms(filter interface{}) *gomock.Call {
+// GetFilmReviews indicates an expected call of GetFilmReviews.
+func (mr *MockReviewServiceMockRecorder) GetFilmReviews(ctx, filter interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActorsWithFilms", reflect.TypeOf((*MockIService)(nil).GetActorsWithFilms), filter)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFilmReviews", reflect.TypeOf((*MockReviewService)(nil).GetFilmReviews), ctx, filter)
 }
 
-// GetFilms mocks base method.
-func (m *MockIService) GetFilms(filter *pagination.FilmFilter) ([]*domains.Film, error) {
+// GetModerationQueue mocks base method.
+func (m *MockReviewService) GetModerationQueue(ctx context.Context, status string, page *pagination.Pagination) ([]*domains.ModeratedReview, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetFilms", filter)
-	ret0, _ := ret[0].([]*domains.Film)
+	ret := m.ctrl.Call(m, "GetModerationQueue", ctx, status, page)
+	ret0, _ := ret[0].([]*domains.ModeratedReview)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetModerationQueue indicates an expected call of GetModerationQueue.
+func (mr *MockReviewServiceMockRecorder) GetModerationQueue(ctx, status, page interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetModerationQueue", reflect.TypeOf((*MockReviewService)(nil).GetModerationQueue), ctx, status, page)
+}
+
+// GetReview mocks base method.
+func (m *MockReviewService) GetReview(ctx context.Context, viewer domains.Principal, id uint32) (*domains.Review, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReview", ctx, viewer, id)
+	ret0, _ := ret[0].(*domains.Review)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReview indicates an expected call of GetReview.
+func (mr *MockReviewServiceMockRecorder) GetReview(ctx, viewer, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReview", reflect.TypeOf((*MockReviewService)(nil).GetReview), ctx, viewer, id)
+}
+
+// HideReview mocks base method.
+func (m *MockReviewService) HideReview(ctx context.Context, moderatorID, id uint32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HideReview", ctx, moderatorID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HideReview indicates an expected call of HideReview.
+func (mr *MockReviewServiceMockRecorder) HideReview(ctx, moderatorID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HideReview", reflect.TypeOf((*MockReviewService)(nil).HideReview), ctx, moderatorID, id)
+}
+
+// ReportReview mocks base method.
+func (m *MockReviewService) ReportReview(ctx context.Context, userID, id uint32, reason string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReportReview", ctx, userID, id, reason)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReportReview indicates an expected call of ReportReview.
+func (mr *MockReviewServiceMockRecorder) ReportReview(ctx, userID, id, reason interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReportReview", reflect.TypeOf((*MockReviewService)(nil).ReportReview), ctx, userID, id, reason)
+}
+
+// RestoreReview mocks base method.
+func (m *MockReviewService) RestoreReview(ctx context.Context, moderatorID, id uint32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreReview", ctx, moderatorID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreReview indicates an expected call of RestoreReview.
+func (mr *MockReviewServiceMockRecorder) RestoreReview(ctx, moderatorID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreReview", reflect.TypeOf((*MockReviewService)(nil).RestoreReview), ctx, moderatorID, id)
+}
+
+// UpdateReview mocks base method.
+func (m *MockReviewService) UpdateReview(ctx context.Context, userID, id uint32, text string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateReview", ctx, userID, id, text)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateReview indicates an expected call of UpdateReview.
+func (mr *MockReviewServiceMockRecorder) UpdateReview(ctx, userID, id, text interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateReview", reflect.TypeOf((*MockReviewService)(nil).UpdateReview), ctx, userID, id, text)
+}
+
+// VoteReview mocks base method.
+func (m *MockReviewService) VoteReview(ctx context.Context, userID, id uint32, helpful bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VoteReview", ctx, userID, id, helpful)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// VoteReview indicates an expected call of VoteReview.
+func (mr *MockReviewServiceMockRecorder) VoteReview(ctx, userID, id, helpful interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VoteReview", reflect.TypeOf((*MockReviewService)(nil).VoteReview), ctx, userID, id, helpful)
+}
+
+// MockAPIKeyService is a mock of APIKeyService interface.
+type MockAPIKeyService struct {
+	ctrl     *gomock.Controller
+	recorder *MockAPIKeyServiceMockRecorder
+}
+
+// MockAPIKeyServiceMockRecorder is the mock recorder for MockAPIKeyService.
+type MockAPIKeyServiceMockRecorder struct {
+	mock *MockAPIKeyService
+}
+
+// NewMockAPIKeyService creates a new mock instance.
+func NewMockAPIKeyService(ctrl *gomock.Controller) *MockAPIKeyService {
+	mock := &MockAPIKeyService{ctrl: ctrl}
+	mock.recorder = &MockAPIKeyServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAPIKeyService) EXPECT() *MockAPIKeyServiceMockRecorder {
+	return m.recorder
+}
+
+// AuthenticateAPIKey mocks base method.
+func (m *MockAPIKeyService) AuthenticateAPIKey(ctx context.Context, key string) (*domains.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthenticateAPIKey", ctx, key)
+	ret0, _ := ret[0].(*domains.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AuthenticateAPIKey indicates an expected call of AuthenticateAPIKey.
+func (mr *MockAPIKeyServiceMockRecorder) AuthenticateAPIKey(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthenticateAPIKey", reflect.TypeOf((*MockAPIKeyService)(nil).AuthenticateAPIKey), ctx, key)
+}
+
+// CreateAPIKey mocks base method.
+func (m *MockAPIKeyService) CreateAPIKey(ctx context.Context, creator domains.Principal, key domains.APIKey) (*domains.CreatedAPIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAPIKey", ctx, creator, key)
+	ret0, _ := ret[0].(*domains.CreatedAPIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAPIKey indicates an expected call of CreateAPIKey.
+func (mr *MockAPIKeyServiceMockRecorder) CreateAPIKey(ctx, creator, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAPIKey", reflect.TypeOf((*MockAPIKeyService)(nil).CreateAPIKey), ctx, creator, key)
+}
+
+// DeleteAPIKey mocks base method.
+func (m *MockAPIKeyService) DeleteAPIKey(ctx context.Context, id uint32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAPIKey", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAPIKey indicates an expected call of DeleteAPIKey.
+func (mr *MockAPIKeyServiceMockRecorder) DeleteAPIKey(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAPIKey", reflect.TypeOf((*MockAPIKeyService)(nil).DeleteAPIKey), ctx, id)
+}
+
+// GetAPIKeys mocks base method.
+func (m *MockAPIKeyService) GetAPIKeys(ctx context.Context, page *pagination.Pagination) ([]*domains.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAPIKeys", ctx, page)
+	ret0, _ := ret[0].([]*domains.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAPIKeys indicates an expected call of GetAPIKeys.
+func (mr *MockAPIKeyServiceMockRecorder) GetAPIKeys(ctx, page interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAPIKeys", reflect.TypeOf((*MockAPIKeyService)(nil).GetAPIKeys), ctx, page)
+}
+
+// MockIService is a mock of IService interface.
+type MockIService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIServiceMockRecorder
+}
+
+// MockIServiceMockRecorder is the mock recorder for MockIService.
+type MockIServiceMockRecorder struct {
+	mock *MockIService
+}
+
+// NewMockIService creates a new mock instance.
+func NewMockIService(ctrl *gomock.Controller) *MockIService {
+	mock := &MockIService{ctrl: ctrl}
+	mock.recorder = &MockIServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIService) EXPECT() *MockIServiceMockRecorder {
+	return m.recorder
+}
+
+// AddActorsToFilm mocks base method.
+func (m *MockIService) AddActorsToFilm(ctx context.Context, filmID uint32, credits []domains.Credit) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddActorsToFilm", ctx, filmID, credits)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddActorsToFilm indicates an expected call of AddActorsToFilm.
+func (mr *MockIServiceMockRecorder) AddActorsToFilm(ctx, filmID, credits interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddActorsToFilm", reflect.TypeOf((*MockIService)(nil).AddActorsToFilm), ctx, filmID, credits)
+}
+
+// AddToWatchlist mocks base method.
+func (m *MockIService) AddToWatchlist(ctx context.Context, userID, filmID uint32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddToWatchlist", ctx, userID, filmID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddToWatchlist indicates an expected call of AddToWatchlist.
+func (mr *MockIServiceMockRecorder) AddToWatchlist(ctx, userID, filmID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddToWatchlist", reflect.TypeOf((*MockIService)(nil).AddToWatchlist), ctx, userID, filmID)
+}
+
+// AuthenticateAPIKey mocks base method.
+func (m *MockIService) AuthenticateAPIKey(ctx context.Context, key string) (*domains.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthenticateAPIKey", ctx, key)
+	ret0, _ := ret[0].(*domains.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AuthenticateAPIKey indicates an expected call of AuthenticateAPIKey.
+func (mr *MockIServiceMockRecorder) AuthenticateAPIKey(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthenticateAPIKey", reflect.TypeOf((*MockIService)(nil).AuthenticateAPIKey), ctx, key)
+}
+
+// ChangePassword mocks base method.
+func (m *MockIService) ChangePassword(ctx context.Context, id uint32, currentPassword, newPassword string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangePassword", ctx, id, currentPassword, newPassword)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ChangePassword indicates an expected call of ChangePassword.
+func (mr *MockIServiceMockRecorder) ChangePassword(ctx, id, currentPassword, newPassword interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangePassword", reflect.TypeOf((*MockIService)(nil).ChangePassword), ctx, id, currentPassword, newPassword)
+}
+
+// CreateAPIKey mocks base method.
+func (m *MockIService) CreateAPIKey(ctx context.Context, creator domains.Principal, key domains.APIKey) (*domains.CreatedAPIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAPIKey", ctx, creator, key)
+	ret0, _ := ret[0].(*domains.CreatedAPIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAPIKey indicates an expected call of CreateAPIKey.
+func (mr *MockIServiceMockRecorder) CreateAPIKey(ctx, creator, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAPIKey", reflect.TypeOf((*MockIService)(nil).CreateAPIKey), ctx, creator, key)
+}
+
+// CreateActor mocks base method.
+func (m *MockIService) CreateActor(ctx context.Context, actor domains.Person) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateActor", ctx, actor)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateActor indicates an expected call of CreateActor.
+func (mr *MockIServiceMockRecorder) CreateActor(ctx, actor interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateActor", reflect.TypeOf((*MockIService)(nil).CreateActor), ctx, actor)
+}
+
+// CreateFilm mocks base method.
+func (m *MockIService) CreateFilm(ctx context.Context, film domains.Film, actors, genres []uint32) (uint32, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateFilm", ctx, film, actors, genres)
+	ret0, _ := ret[0].(uint32)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateFilm indicates an expected call of CreateFilm.
+func (mr *MockIServiceMockRecorder) CreateFilm(ctx, film, actors, genres interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFilm", reflect.TypeOf((*MockIService)(nil).CreateFilm), ctx, film, actors, genres)
+}
+
+// CreateGenre mocks base method.
+func (m *MockIService) CreateGenre(ctx context.Context, genre domains.Genre) (uint32, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateGenre", ctx, genre)
+	ret0, _ := ret[0].(uint32)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateGenre indicates an expected call of CreateGenre.
+func (mr *MockIServiceMockRecorder) CreateGenre(ctx, genre interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateGenre", reflect.TypeOf((*MockIService)(nil).CreateGenre), ctx, genre)
+}
+
+// CreateReview mocks base method.
+func (m *MockIService) CreateReview(ctx context.Context, userID, filmID uint32, text string) (uint32, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateReview", ctx, userID, filmID, text)
+	ret0, _ := ret[0].(uint32)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateReview indicates an expected call of CreateReview.
+func (mr *MockIServiceMockRecorder) CreateReview(ctx, userID, filmID, text interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateReview", reflect.TypeOf((*MockIService)(nil).CreateReview), ctx, userID, filmID, text)
+}
+
+// CreateUser mocks base method.
+func (m *MockIService) CreateUser(ctx context.Context, user domains.User) (*domains.Tokens, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateUser", ctx, user)
+	ret0, _ := ret[0].(*domains.Tokens)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateUser indicates an expected call of CreateUser.
+func (mr *MockIServiceMockRecorder) CreateUser(ctx, user interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockIService)(nil).CreateUser), ctx, user)
+}
+
+// DeleteAPIKey mocks base method.
+func (m *MockIService) DeleteAPIKey(ctx context.Context, id uint32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAPIKey", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAPIKey indicates an expected call of DeleteAPIKey.
+func (mr *MockIServiceMockRecorder) DeleteAPIKey(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAPIKey", reflect.TypeOf((*MockIService)(nil).DeleteAPIKey), ctx, id)
+}
+
+// DeleteAccount mocks base method.
+func (m *MockIService) DeleteAccount(ctx context.Context, id uint32, password string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAccount", ctx, id, password)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAccount indicates an expected call of DeleteAccount.
+func (mr *MockIServiceMockRecorder) DeleteAccount(ctx, id, password interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAccount", reflect.TypeOf((*MockIService)(nil).DeleteAccount), ctx, id, password)
+}
+
+// DeleteActor mocks base method.
+func (m *MockIService) DeleteActor(ctx context.Context, id uint32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteActor", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteActor indicates an expected call of DeleteActor.
+func (mr *MockIServiceMockRecorder) DeleteActor(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteActor", reflect.TypeOf((*MockIService)(nil).DeleteActor), ctx, id)
+}
+
+// DeleteActorFromFilm mocks base method.
+func (m *MockIService) DeleteActorFromFilm(ctx context.Context, actorID, filmID uint32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteActorFromFilm", ctx, actorID, filmID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteActorFromFilm indicates an expected call of DeleteActorFromFilm.
+func (mr *MockIServiceMockRecorder) DeleteActorFromFilm(ctx, actorID, filmID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteActorFromFilm", reflect.TypeOf((*MockIService)(nil).DeleteActorFromFilm), ctx, actorID, filmID)
+}
+
+// DeleteFilm mocks base method.
+func (m *MockIService) DeleteFilm(ctx context.Context, id uint32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFilm", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteFilm indicates an expected call of DeleteFilm.
+func (mr *MockIServiceMockRecorder) DeleteFilm(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFilm", reflect.TypeOf((*MockIService)(nil).DeleteFilm), ctx, id)
+}
+
+// DeleteFilmRating mocks base method.
+func (m *MockIService) DeleteFilmRating(ctx context.Context, userID, filmID uint32) (*domains.FilmScore, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFilmRating", ctx, userID, filmID)
+	ret0, _ := ret[0].(*domains.FilmScore)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteFilmRating indicates an expected call of DeleteFilmRating.
+func (mr *MockIServiceMockRecorder) DeleteFilmRating(ctx, userID, filmID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFilmRating", reflect.TypeOf((*MockIService)(nil).DeleteFilmRating), ctx, userID, filmID)
+}
+
+// DeleteGenre mocks base method.
+func (m *MockIService) DeleteGenre(ctx context.Context, id uint32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteGenre", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteGenre indicates an expected call of DeleteGenre.
+func (mr *MockIServiceMockRecorder) DeleteGenre(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteGenre", reflect.TypeOf((*MockIService)(nil).DeleteGenre), ctx, id)
+}
+
+// DeleteReview mocks base method.
+func (m *MockIService) DeleteReview(ctx context.Context, userID, id uint32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteReview", ctx, userID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteReview indicates an expected call of DeleteReview.
+func (mr *MockIServiceMockRecorder) DeleteReview(ctx, userID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteReview", reflect.TypeOf((*MockIService)(nil).DeleteReview), ctx, userID, id)
+}
+
+// DeleteReviewVote mocks base method.
+func (m *MockIService) DeleteReviewVote(ctx context.Context, userID, id uint32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteReviewVote", ctx, userID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteReviewVote indicates an expected call of DeleteReviewVote.
+func (mr *MockIServiceMockRecorder) DeleteReviewVote(ctx, userID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteReviewVote", reflect.TypeOf((*MockIService)(nil).DeleteReviewVote), ctx, userID, id)
+}
+
+// DeleteUser mocks base method.
+func (m *MockIService) DeleteUser(ctx context.Context, id uint32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUser", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteUser indicates an expected call of DeleteUser.
+func (mr *MockIServiceMockRecorder) DeleteUser(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockIService)(nil).DeleteUser), ctx, id)
+}
+
+// DeleteWatched mocks base method.
+func (m *MockIService) DeleteWatched(ctx context.Context, userID, filmID uint32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWatched", ctx, userID, filmID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteWatched indicates an expected call of DeleteWatched.
+func (mr *MockIServiceMockRecorder) DeleteWatched(ctx, userID, filmID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWatched", reflect.TypeOf((*MockIService)(nil).DeleteWatched), ctx, userID, filmID)
+}
+
+// GetAPIKeys mocks base method.
+func (m *MockIService) GetAPIKeys(ctx context.Context, page *pagination.Pagination) ([]*domains.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAPIKeys", ctx, page)
+	ret0, _ := ret[0].([]*domains.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAPIKeys indicates an expected call of GetAPIKeys.
+func (mr *MockIServiceMockRecorder) GetAPIKeys(ctx, page interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAPIKeys", reflect.TypeOf((*MockIService)(nil).GetAPIKeys), ctx, page)
+}
+
+// GetActorByID mocks base method.
+func (m *MockIService) GetActorByID(ctx context.Context, id uint32) (*domains.PersonWithCredits, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActorByID", ctx, id)
+	ret0, _ := ret[0].(*domains.PersonWithCredits)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActorByID indicates an expected call of GetActorByID.
+func (mr *MockIServiceMockRecorder) GetActorByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActorByID", reflect.TypeOf((*MockIService)(nil).GetActorByID), ctx, id)
+}
+
+// GetActorsWithFilms mocks base method.
+func (m *MockIService) GetActorsWithFilms(ctx context.Context, filter *pagination.ActorsFilter) ([]*domains.PersonWithCredits, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActorsWithFilms", ctx, filter)
+	ret0, _ := ret[0].([]*domains.PersonWithCredits)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActorsWithFilms indicates an expected call of GetActorsWithFilms.
+func (mr *MockIServiceMockRecorder) GetActorsWithFilms(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActorsWithFilms", reflect.TypeOf((*MockIService)(nil).GetActorsWithFilms), ctx, filter)
+}
+
+// GetFilmByID mocks base method.
+func (m *MockIService) GetFilmByID(ctx context.Context, id uint32) (*domains.FilmWithCredits, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFilmByID", ctx, id)
+	ret0, _ := ret[0].(*domains.FilmWithCredits)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFilmByID indicates an expected call of GetFilmByID.
+func (mr *MockIServiceMockRecorder) GetFilmByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFilmByID", reflect.TypeOf((*MockIService)(nil).GetFilmByID), ctx, id)
+}
+
+// GetFilmReviews mocks base method.
+func (m *MockIService) GetFilmReviews(ctx context.Context, filter *pagination.ReviewFilter) ([]*domains.Review, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFilmReviews", ctx, filter)
+	ret0, _ := ret[0].([]*domains.Review)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFilmReviews indicates an expected call of GetFilmReviews.
+func (mr *MockIServiceMockRecorder) GetFilmReviews(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFilmReviews", reflect.TypeOf((*MockIService)(nil).GetFilmReviews), ctx, filter)
+}
+
+// GetFilms mocks base method.
+func (m *MockIService) GetFilms(ctx context.Context, filter *pagination.FilmFilter) ([]*domains.Film, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFilms", ctx, filter)
+	ret0, _ := ret[0].([]*domains.Film)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetFilms indicates an expected call of GetFilms.
-func (mr *MockIServiceMockRecorder) GetFilms(filter interface{}) *gomock.Call {
+func (mr *MockIServiceMockRecorder) GetFilms(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFilms", reflect.TypeOf((*MockIService)(nil).GetFilms), ctx, filter)
+}
+
+// GetGenres mocks base method.
+func (m *MockIService) GetGenres(ctx context.Context) ([]*domains.Genre, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGenres", ctx)
+	ret0, _ := ret[0].([]*domains.Genre)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGenres indicates an expected call of GetGenres.
+func (mr *MockIServiceMockRecorder) GetGenres(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGenres", reflect.TypeOf((*MockIService)(nil).GetGenres), ctx)
+}
+
+// GetModerationQueue mocks base method.
+func (m *MockIService) GetModerationQueue(ctx context.Context, status string, page *pagination.Pagination) ([]*domains.ModeratedReview, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetModerationQueue", ctx, status, page)
+	ret0, _ := ret[0].([]*domains.ModeratedReview)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetModerationQueue indicates an expected call of GetModerationQueue.
+func (mr *MockIServiceMockRecorder) GetModerationQueue(ctx, status, page interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetModerationQueue", reflect.TypeOf((*MockIService)(nil).GetModerationQueue), ctx, status, page)
+}
+
+// GetProfile mocks base method.
+func (m *MockIService) GetProfile(ctx context.Context, principal domains.Principal) (*domains.Profile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProfile", ctx, principal)
+	ret0, _ := ret[0].(*domains.Profile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProfile indicates an expected call of GetProfile.
+func (mr *MockIServiceMockRecorder) GetProfile(ctx, principal interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProfile", reflect.TypeOf((*MockIService)(nil).GetProfile), ctx, principal)
+}
+
+// GetReview mocks base method.
+func (m *MockIService) GetReview(ctx context.Context, viewer domains.Principal, id uint32) (*domains.Review, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReview", ctx, viewer, id)
+	ret0, _ := ret[0].(*domains.Review)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReview indicates an expected call of GetReview.
+func (mr *MockIServiceMockRecorder) GetReview(ctx, viewer, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReview", reflect.TypeOf((*MockIService)(nil).GetReview), ctx, viewer, id)
+}
+
+// GetUserByID mocks base method.
+func (m *MockIService) GetUserByID(ctx context.Context, id uint32) (*domains.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByID", ctx, id)
+	ret0, _ := ret[0].(*domains.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByID indicates an expected call of GetUserByID.
+func (mr *MockIServiceMockRecorder) GetUserByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByID", reflect.TypeOf((*MockIService)(nil).GetUserByID), ctx, id)
+}
+
+// GetUsers mocks base method.
+func (m *MockIService) GetUsers(ctx context.Context, page *pagination.Pagination) ([]*domains.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUsers", ctx, page)
+	ret0, _ := ret[0].([]*domains.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUsers indicates an expected call of GetUsers.
+func (mr *MockIServiceMockRecorder) GetUsers(ctx, page interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsers", reflect.TypeOf((*MockIService)(nil).GetUsers), ctx, page)
+}
+
+// GetWatchedFilms mocks base method.
+func (m *MockIService) GetWatchedFilms(ctx context.Context, userID uint32, filter *pagination.FilmFilter) ([]*domains.WatchedEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWatchedFilms", ctx, userID, filter)
+	ret0, _ := ret[0].([]*domains.WatchedEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWatchedFilms indicates an expected call of GetWatchedFilms.
+func (mr *MockIServiceMockRecorder) GetWatchedFilms(ctx, userID, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWatchedFilms", reflect.TypeOf((*MockIService)(nil).GetWatchedFilms), ctx, userID, filter)
+}
+
+// GetWatchlist mocks base method.
+func (m *MockIService) GetWatchlist(ctx context.Context, userID uint32, filter *pagination.FilmFilter) ([]*domains.WatchlistEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWatchlist", ctx, userID, filter)
+	ret0, _ := ret[0].([]*domains.WatchlistEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWatchlist indicates an expected call of GetWatchlist.
+func (mr *MockIServiceMockRecorder) GetWatchlist(ctx, userID, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWatchlist", reflect.TypeOf((*MockIService)(nil).GetWatchlist), ctx, userID, filter)
+}
+
+// HideReview mocks base method.
+func (m *MockIService) HideReview(ctx context.Context, moderatorID, id uint32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HideReview", ctx, moderatorID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HideReview indicates an expected call of HideReview.
+func (mr *MockIServiceMockRecorder) HideReview(ctx, moderatorID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HideReview", reflect.TypeOf((*MockIService)(nil).HideReview), ctx, moderatorID, id)
+}
+
+// IsTokenRevoked mocks base method.
+func (m *MockIService) IsTokenRevoked(ctx context.Context, jti string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsTokenRevoked", ctx, jti)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsTokenRevoked indicates an expected call of IsTokenRevoked.
+func (mr *MockIServiceMockRecorder) IsTokenRevoked(ctx, jti interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFilms", reflect.TypeOf((*MockIService)(nil).GetFilms), filter)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsTokenRevoked", reflect.TypeOf((*MockIService)(nil).IsTokenRevoked), ctx, jti)
+}
+
+// IssuePasswordReset mocks base method.
+func (m *MockIService) IssuePasswordReset(ctx context.Context, caller domains.Principal, id uint32) (*domains.PasswordReset, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IssuePasswordReset", ctx, caller, id)
+	ret0, _ := ret[0].(*domains.PasswordReset)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IssuePasswordReset indicates an expected call of IssuePasswordReset.
+func (mr *MockIServiceMockRecorder) IssuePasswordReset(ctx, caller, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IssuePasswordReset", reflect.TypeOf((*MockIService)(nil).IssuePasswordReset), ctx, caller, id)
 }
 
 // Login mocks base method.
-func (m *MockIService) Login(login, password string) (string, error) {
+func (m *MockIService) Login(ctx context.Context, login, password, ip string) (*domains.Tokens, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Login", login, password)
-	ret0, _ := ret[0].(string)
+	ret := m.ctrl.Call(m, "Login", ctx, login, password, ip)
+	ret0, _ := ret[0].(*domains.Tokens)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Login indicates an expected call of Login.
-func (mr *MockIServiceMockRecorder) Login(login, password interface{}) *gomock.Call {
+func (mr *MockIServiceMockRecorder) Login(ctx, login, password, ip interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Login", reflect.TypeOf((*MockIService)(nil).Login), ctx, login, password, ip)
+}
+
+// Logout mocks base method.
+func (m *MockIService) Logout(ctx context.Context, claims *tokens.Claims, refreshToken string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Logout", ctx, claims, refreshToken)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Logout indicates an expected call of Logout.
+func (mr *MockIServiceMockRecorder) Logout(ctx, claims, refreshToken interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Logout", reflect.TypeOf((*MockIService)(nil).Logout), ctx, claims, refreshToken)
+}
+
+// MarkWatched mocks base method.
+func (m *MockIService) MarkWatched(ctx context.Context, userID, filmID uint32, watchedAt time.Time, rewatchCount *int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkWatched", ctx, userID, filmID, watchedAt, rewatchCount)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkWatched indicates an expected call of MarkWatched.
+func (mr *MockIServiceMockRecorder) MarkWatched(ctx, userID, filmID, watchedAt, rewatchCount interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkWatched", reflect.TypeOf((*MockIService)(nil).MarkWatched), ctx, userID, filmID, watchedAt, rewatchCount)
+}
+
+// RateFilm mocks base method.
+func (m *MockIService) RateFilm(ctx context.Context, userID, filmID uint32, rating int) (*domains.FilmScore, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RateFilm", ctx, userID, filmID, rating)
+	ret0, _ := ret[0].(*domains.FilmScore)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RateFilm indicates an expected call of RateFilm.
+func (mr *MockIServiceMockRecorder) RateFilm(ctx, userID, filmID, rating interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RateFilm", reflect.TypeOf((*MockIService)(nil).RateFilm), ctx, userID, filmID, rating)
+}
+
+// RefreshTokens mocks base method.
+func (m *MockIService) RefreshTokens(ctx context.Context, refreshToken string) (*domains.Tokens, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefreshTokens", ctx, refreshToken)
+	ret0, _ := ret[0].(*domains.Tokens)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RefreshTokens indicates an expected call of RefreshTokens.
+func (mr *MockIServiceMockRecorder) RefreshTokens(ctx, refreshToken interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshTokens", reflect.TypeOf((*MockIService)(nil).RefreshTokens), ctx, refreshToken)
+}
+
+// RemoveFromWatchlist mocks base method.
+func (m *MockIService) RemoveFromWatchlist(ctx context.Context, userID, filmID uint32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveFromWatchlist", ctx, userID, filmID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveFromWatchlist indicates an expected call of RemoveFromWatchlist.
+func (mr *MockIServiceMockRecorder) RemoveFromWatchlist(ctx, userID, filmID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveFromWatchlist", reflect.TypeOf((*MockIService)(nil).RemoveFromWatchlist), ctx, userID, filmID)
+}
+
+// ReplaceFilmActors mocks base method.
+func (m *MockIService) ReplaceFilmActors(ctx context.Context, filmID uint32, credits []domains.Credit) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceFilmActors", ctx, filmID, credits)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplaceFilmActors indicates an expected call of ReplaceFilmActors.
+func (mr *MockIServiceMockRecorder) ReplaceFilmActors(ctx, filmID, credits interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceFilmActors", reflect.TypeOf((*MockIService)(nil).ReplaceFilmActors), ctx, filmID, credits)
+}
+
+// ReportReview mocks base method.
+func (m *MockIService) ReportReview(ctx context.Context, userID, id uint32, reason string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReportReview", ctx, userID, id, reason)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReportReview indicates an expected call of ReportReview.
+func (mr *MockIServiceMockRecorder) ReportReview(ctx, userID, id, reason interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Login", reflect.TypeOf((*MockIService)(nil).Login), login, password)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReportReview", reflect.TypeOf((*MockIService)(nil).ReportReview), ctx, userID, id, reason)
+}
+
+// ResetPassword mocks base method.
+func (m *MockIService) ResetPassword(ctx context.Context, resetToken, newPassword string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetPassword", ctx, resetToken, newPassword)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetPassword indicates an expected call of ResetPassword.
+func (mr *MockIServiceMockRecorder) ResetPassword(ctx, resetToken, newPassword interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetPassword", reflect.TypeOf((*MockIService)(nil).ResetPassword), ctx, resetToken, newPassword)
+}
+
+// RestoreReview mocks base method.
+func (m *MockIService) RestoreReview(ctx context.Context, moderatorID, id uint32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreReview", ctx, moderatorID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreReview indicates an expected call of RestoreReview.
+func (mr *MockIServiceMockRecorder) RestoreReview(ctx, moderatorID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreReview", reflect.TypeOf((*MockIService)(nil).RestoreReview), ctx, moderatorID, id)
+}
+
+// SetUserDisabled mocks base method.
+func (m *MockIService) SetUserDisabled(ctx context.Context, id uint32, disabled bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetUserDisabled", ctx, id, disabled)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetUserDisabled indicates an expected call of SetUserDisabled.
+func (mr *MockIServiceMockRecorder) SetUserDisabled(ctx, id, disabled interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUserDisabled", reflect.TypeOf((*MockIService)(nil).SetUserDisabled), ctx, id, disabled)
 }
 
 // UpdateActor mocks base method.
-func (m *MockIService) UpdateActor(id uint32, actor domains.Actor) error {
+func (m *MockIService) UpdateActor(ctx context.Context, id uint32, actor domains.Person) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateActor", id, actor)
+	ret := m.ctrl.Call(m, "UpdateActor", ctx, id, actor)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateActor indicates an expected call of UpdateActor.
-func (mr *MockIServiceMockRecorder) UpdateActor(id, actor interface{}) *gomock.Call {
+func (mr *MockIServiceMockRecorder) UpdateActor(ctx, id, actor interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateActor", reflect.TypeOf((*MockIService)(nil).UpdateActor), id, actor)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateActor", reflect.TypeOf((*MockIService)(nil).UpdateActor), ctx, id, actor)
 }
 
 // UpdateActorBirthday mocks base method.
-func (m *MockIService) UpdateActorBirthday(id uint32, birthday time.Time) error {
+func (m *MockIService) UpdateActorBirthday(ctx context.Context, id uint32, birthday time.Time) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateActorBirthday", id, birthday)
+	ret := m.ctrl.Call(m, "UpdateActorBirthday", ctx, id, birthday)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateActorBirthday indicates an expected call of UpdateActorBirthday.
-func (mr *MockIServiceMockRecorder) UpdateActorBirthday(id, birthday interface{}) *gomock.Call {
+func (mr *MockIServiceMockRecorder) UpdateActorBirthday(ctx, id, birthday interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateActorBirthday", reflect.TypeOf((*MockIService)(nil).UpdateActorBirthday), id, birthday)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateActorBirthday", reflect.TypeOf((*MockIService)(nil).UpdateActorBirthday), ctx, id, birthday)
 }
 
 // UpdateActorFullName mocks base method.
-func (m *MockIService) UpdateActorFullName(id uint32, fullName string) error {
+func (m *MockIService) UpdateActorFullName(ctx context.Context, id uint32, fullName string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateActorFullName", id, fullName)
+	ret := m.ctrl.Call(m, "UpdateActorFullName", ctx, id, fullName)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateActorFullName indicates an expected call of UpdateActorFullName.
-func (mr *MockIServiceMockRecorder) UpdateActorFullName(id, fullName interface{}) *gomock.Call {
+func (mr *MockIServiceMockRecorder) UpdateActorFullName(ctx, id, fullName interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateActorFullName", reflect.TypeOf((*MockIService)(nil).UpdateActorFullName), id, fullName)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateActorFullName", reflect.TypeOf((*MockIService)(nil).UpdateActorFullName), ctx, id, fullName)
 }
 
 // UpdateActorGender mocks base method.
-func (m *MockIService) UpdateActorGender(id uint32, gender domains.Gender) error {
+func (m *MockIService) UpdateActorGender(ctx context.Context, id uint32, gender domains.Gender) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateActorGender", id, gender)
+	ret := m.ctrl.Call(m, "UpdateActorGender", ctx, id, gender)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateActorGender indicates an expected call of UpdateActorGender.
-func (mr *MockIServiceMockRecorder) UpdateActorGender(id, gender interface{}) *gomock.Call {
+func (mr *MockIServiceMockRecorder) UpdateActorGender(ctx, id, gender interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateActorGender", reflect.TypeOf((*MockIService)(nil).UpdateActorGender), ctx, id, gender)
+}
+
+// UpdateDisplayName mocks base method.
+func (m *MockIService) UpdateDisplayName(ctx context.Context, id uint32, displayName string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateDisplayName", ctx, id, displayName)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateDisplayName indicates an expected call of UpdateDisplayName.
+func (mr *MockIServiceMockRecorder) UpdateDisplayName(ctx, id, displayName interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateActorGender", reflect.TypeOf((*MockIService)(nil).UpdateActorGender), id, gender)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDisplayName", reflect.TypeOf((*MockIService)(nil).UpdateDisplayName), ctx, id, displayName)
 }
 
 // UpdateFilm mocks base method.
-func (m *MockIService) UpdateFilm(id uint32, film domains.Film) error {
+func (m *MockIService) UpdateFilm(ctx context.Context, id uint32, film domains.Film, genres []uint32) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateFilm", id, film)
+	ret := m.ctrl.Call(m, "UpdateFilm", ctx, id, film, genres)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateFilm indicates an expected call of UpdateFilm.
-func (mr *MockIServiceMockRecorder) UpdateFilm(id, film interface{}) *gomock.Call {
+func (mr *MockIServiceMockRecorder) UpdateFilm(ctx, id, film, genres interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFilm", reflect.TypeOf((*MockIService)(nil).UpdateFilm), id, film)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFilm", reflect.TypeOf((*MockIService)(nil).UpdateFilm), ctx, id, film, genres)
 }
 
 // UpdateFilmDescription mocks base method.
-func (m *MockIService) UpdateFilmDescription(id uint32, descrtion string) error {
+func (m *MockIService) UpdateFilmDescription(ctx context.Context, id uint32, descrtion string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateFilmDescription", id, descrtion)
+	ret := m.ctrl.Call(m, "UpdateFilmDescription", ctx, id, descrtion)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateFilmDescription indicates an expected call of UpdateFilmDescription.
-func (mr *MockIServiceMockRecorder) UpdateFilmDescription(id, descrtion interface{}) *gomock.Call {
+func (mr *MockIServiceMockRecorder) UpdateFilmDescription(ctx, id, descrtion interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFilmDescription", reflect.TypeOf((*MockIService)(nil).UpdateFilmDescription), id, descrtion)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFilmDescription", reflect.TypeOf((*MockIService)(nil).UpdateFilmDescription), ctx, id, descrtion)
 }
 
 // UpdateFilmName mocks base method.
-func (m *MockIService) UpdateFilmName(id uint32, name string) error {
+func (m *MockIService) UpdateFilmName(ctx context.Context, id uint32, name string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateFilmName", id, name)
+	ret := m.ctrl.Call(m, "UpdateFilmName", ctx, id, name)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateFilmName indicates an expected call of UpdateFilmName.
-func (mr *MockIServiceMockRecorder) UpdateFilmName(id, name interface{}) *gomock.Call {
+func (mr *MockIServiceMockRecorder) UpdateFilmName(ctx, id, name interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFilmName", reflect.TypeOf((*MockIService)(nil).UpdateFilmName), id, name)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFilmName", reflect.TypeOf((*MockIService)(nil).UpdateFilmName), ctx, id, name)
 }
 
 // UpdateFilmRating mocks base method.
-func (m *MockIService) UpdateFilmRating(id uint32, rating int) error {
+func (m *MockIService) UpdateFilmRating(ctx context.Context, id uint32, rating int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateFilmRating", id, rating)
+	ret := m.ctrl.Call(m, "UpdateFilmRating", ctx, id, rating)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateFilmRating indicates an expected call of UpdateFilmRating.
-func (mr *MockIServiceMockRecorder) UpdateFilmRating(id, rating interface{}) *gomock.Call {
+func (mr *MockIServiceMockRecorder) UpdateFilmRating(ctx, id, rating interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFilmRating", reflect.TypeOf((*MockIService)(nil).UpdateFilmRating), id, rating)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFilmRating", reflect.TypeOf((*MockIService)(nil).UpdateFilmRating), ctx, id, rating)
 }
 
 // UpdateFilmReleaseDate mocks base method.
-func (m *MockIService) UpdateFilmReleaseDate(id uint32, releaseDate time.Time) error {
+func (m *MockIService) UpdateFilmReleaseDate(ctx context.Context, id uint32, releaseDate time.Time) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateFilmReleaseDate", id, releaseDate)
+	ret := m.ctrl.Call(m, "UpdateFilmReleaseDate", ctx, id, releaseDate)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateFilmReleaseDate indicates an expected call of UpdateFilmReleaseDate.
-func (mr *MockIServiceMockRecorder) UpdateFilmReleaseDate(id, releaseDate interface{}) *gomock.Call {
+func (mr *MockIServiceMockRecorder) UpdateFilmReleaseDate(ctx, id, releaseDate interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFilmReleaseDate", reflect.TypeOf((*MockIService)(nil).UpdateFilmReleaseDate), ctx, id, releaseDate)
+}
+
+// UpdateGenre mocks base method.
+func (m *MockIService) UpdateGenre(ctx context.Context, id uint32, genre domains.Genre) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateGenre", ctx, id, genre)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateGenre indicates an expected call of UpdateGenre.
+func (mr *MockIServiceMockRecorder) UpdateGenre(ctx, id, genre interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateGenre", reflect.TypeOf((*MockIService)(nil).UpdateGenre), ctx, id, genre)
+}
+
+// UpdateReview mocks base method.
+func (m *MockIService) UpdateReview(ctx context.Context, userID, id uint32, text string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateReview", ctx, userID, id, text)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateReview indicates an expected call of UpdateReview.
+func (mr *MockIServiceMockRecorder) UpdateReview(ctx, userID, id, text interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateReview", reflect.TypeOf((*MockIService)(nil).UpdateReview), ctx, userID, id, text)
+}
+
+// UpdateUserRole mocks base method.
+func (m *MockIService) UpdateUserRole(ctx context.Context, id uint32, role domains.Role) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserRole", ctx, id, role)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateUserRole indicates an expected call of UpdateUserRole.
+func (mr *MockIServiceMockRecorder) UpdateUserRole(ctx, id, role interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserRole", reflect.TypeOf((*MockIService)(nil).UpdateUserRole), ctx, id, role)
+}
+
+// VoteReview mocks base method.
+func (m *MockIService) VoteReview(ctx context.Context, userID, id uint32, helpful bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VoteReview", ctx, userID, id, helpful)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// VoteReview indicates an expected call of VoteReview.
+func (mr *MockIServiceMockRecorder) VoteReview(ctx, userID, id, helpful interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFilmReleaseDate", reflect.TypeOf((*MockIService)(nil).UpdateFilmReleaseDate), id, releaseDate)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VoteReview", reflect.TypeOf((*MockIService)(nil).VoteReview), ctx, userID, id, helpful)
 }
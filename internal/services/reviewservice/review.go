@@ -0,0 +1,275 @@
+package reviewservice
+
+import (
+	"context"
+	"film_library/internal/domains"
+	"film_library/internal/logger"
+	"film_library/internal/repositories/postgres"
+	"film_library/internal/repositories/postgres/reviewrepo"
+	"film_library/pkg/pagination"
+	"film_library/pkg/validation"
+	"fmt"
+	"log/slog"
+	"strings"
+)
+
+//go:generate mockgen -source=review.go -destination=mocks/mock.go
+
+// maxTextLen and maxReasonLen match the reviews.text and
+// review_reports.reason columns.
+const (
+	maxTextLen   = 5000
+	maxReasonLen = 500
+)
+
+var (
+	ErrInvalidText             = fmt.Errorf("review text must be between 1 and %d characters long", maxTextLen)
+	ErrInvalidReason           = fmt.Errorf("report reason must be at most %d characters long", maxReasonLen)
+	ErrOwnReview               = fmt.Errorf("users cannot vote on or report their own reviews")
+	ErrInvalidModerationStatus = fmt.Errorf("moderation status must be %s or %s", domains.ModerationReported, domains.ModerationHidden)
+)
+
+type ReviewRepo interface {
+	AddReview(ctx context.Context, review domains.Review) (uint32, error)
+	GetReviewByID(ctx context.Context, id uint32) (*domains.Review, error)
+	GetFilmReviews(ctx context.Context, filter *pagination.ReviewFilter) ([]*domains.Review, error)
+	UpdateReviewText(ctx context.Context, id, authorID uint32, text string) error
+	DeleteReview(ctx context.Context, id, authorID uint32) error
+	SetReviewVote(ctx context.Context, reviewID, userID uint32, helpful bool) error
+	DeleteReviewVote(ctx context.Context, reviewID, userID uint32) error
+	AddReviewReport(ctx context.Context, reviewID, userID uint32, reason string) error
+	GetModerationQueue(ctx context.Context, status string, page *pagination.Pagination) ([]*domains.ModeratedReview, error)
+	WithTx(ctx context.Context, txFunc func(repo postgres.IRepository) error) error
+}
+
+type ReviewService struct {
+	repo ReviewRepo
+	log  *slog.Logger
+}
+
+func New(repo ReviewRepo, log *slog.Logger) *ReviewService {
+	return &ReviewService{
+		repo: repo,
+		log:  log,
+	}
+}
+
+func (s *ReviewService) CreateReview(ctx context.Context, userID, filmID uint32, text string) (uint32, error) {
+	fn := "reviewService.CreateReview"
+
+	text = strings.TrimSpace(text)
+
+	err := validateText(text)
+	if err != nil {
+		s.logger(ctx).Error(fmt.Sprintf("%s: %s", fn, err.Error()))
+		return 0, err
+	}
+
+	id, err := s.repo.AddReview(ctx, domains.Review{FilmID: filmID, AuthorID: userID, Text: text})
+	if err != nil {
+		s.logger(ctx).Error(fmt.Sprintf("%s: %s", fn, err.Error()))
+		return 0, fmt.Errorf("%s: %w", fn, err)
+	}
+
+	return id, nil
+}
+
+// GetReview returns the review. Hidden reviews are only shown to their author
+// and to moderators.
+func (s *ReviewService) GetReview(ctx context.Context, viewer domains.Principal, id uint32) (*domains.Review, error) {
+	fn := "reviewService.GetReview"
+
+	review, err := s.repo.GetReviewByID(ctx, id)
+	if err == nil && review.Hidden && !canSeeHidden(viewer, review) {
+		err = fmt.Errorf("hidden: %w", reviewrepo.ErrNotFound)
+	}
+	if err != nil {
+		s.logger(ctx).Error(fmt.Sprintf("%s: %s", fn, err.Error()))
+		return nil, fmt.Errorf("%s: %w", fn, err)
+	}
+
+	return review, nil
+}
+
+func (s *ReviewService) GetFilmReviews(ctx context.Context, filter *pagination.ReviewFilter) ([]*domains.Review, error) {
+	fn := "reviewService.GetFilmReviews"
+
+	filter.Validate()
+
+	reviews, err := s.repo.GetFilmReviews(ctx, filter)
+	if err != nil {
+		s.logger(ctx).Error(fmt.Sprintf("%s: %s", fn, err.Error()))
+		return nil, fmt.Errorf("%s: %w", fn, err)
+	}
+
+	return reviews, nil
+}
+
+// UpdateReview changes the text of a review written by the user.
+func (s *ReviewService) UpdateReview(ctx context.Context, userID, id uint32, text string) error {
+	fn := "reviewService.UpdateReview"
+
+	text = strings.TrimSpace(text)
+
+	err := validateText(text)
+	if err != nil {
+		s.logger(ctx).Error(fmt.Sprintf("%s: %s", fn, err.Error()))
+		return err
+	}
+
+	err = s.repo.UpdateReviewText(ctx, id, userID, text)
+	if err != nil {
+		s.logger(ctx).Error(fmt.Sprintf("%s: %s", fn, err.Error()))
+		return fmt.Errorf("%s: %w", fn, err)
+	}
+
+	return nil
+}
+
+// DeleteReview deletes a review written by the user.
+func (s *ReviewService) DeleteReview(ctx context.Context, userID, id uint32) error {
+	fn := "reviewService.DeleteReview"
+
+	err := s.repo.DeleteReview(ctx, id, userID)
+	if err != nil {
+		s.logger(ctx).Error(fmt.Sprintf("%s: %s", fn, err.Error()))
+		return fmt.Errorf("%s: %w", fn, err)
+	}
+
+	return nil
+}
+
+// VoteReview records whether the user found a visible review of someone else
+// helpful. A second vote replaces the first one.
+func (s *ReviewService) VoteReview(ctx context.Context, userID, id uint32, helpful bool) error {
+	fn := "reviewService.VoteReview"
+
+	err := s.checkOthersReview(ctx, userID, id)
+	if err == nil {
+		err = s.repo.SetReviewVote(ctx, id, userID, helpful)
+	}
+	if err != nil {
+		s.logger(ctx).Error(fmt.Sprintf("%s: %s", fn, err.Error()))
+		return fmt.Errorf("%s: %w", fn, err)
+	}
+
+	return nil
+}
+
+func (s *ReviewService) DeleteReviewVote(ctx context.Context, userID, id uint32) error {
+	fn := "reviewService.DeleteReviewVote"
+
+	err := s.repo.DeleteReviewVote(ctx, id, userID)
+	if err != nil {
+		s.logger(ctx).Error(fmt.Sprintf("%s: %s", fn, err.Error()))
+		return fmt.Errorf("%s: %w", fn, err)
+	}
+
+	return nil
+}
+
+// ReportReview puts a visible review of someone else in the moderation
+// queue. Every user reports a review once.
+func (s *ReviewService) ReportReview(ctx context.Context, userID, id uint32, reason string) error {
+	fn := "reviewService.ReportReview"
+
+	reason = strings.TrimSpace(reason)
+
+	err := validation.Check("reason", reason, validation.Length(0, maxReasonLen).Err(ErrInvalidReason))
+	if err != nil {
+		s.logger(ctx).Error(fmt.Sprintf("%s: %s", fn, err.Error()))
+		return err
+	}
+
+	err = s.checkOthersReview(ctx, userID, id)
+	if err == nil {
+		err = s.repo.AddReviewReport(ctx, id, userID, reason)
+	}
+	if err != nil {
+		s.logger(ctx).Error(fmt.Sprintf("%s: %s", fn, err.Error()))
+		return fmt.Errorf("%s: %w", fn, err)
+	}
+
+	return nil
+}
+
+func (s *ReviewService) GetModerationQueue(ctx context.Context, status string, page *pagination.Pagination) ([]*domains.ModeratedReview, error) {
+	fn := "reviewService.GetModerationQueue"
+
+	if status == "" {
+		status = domains.ModerationReported
+	}
+
+	err := validation.Check("status", status,
+		validation.OneOf(domains.ModerationReported, domains.ModerationHidden).Err(ErrInvalidModerationStatus))
+	if err != nil {
+		s.logger(ctx).Error(fmt.Sprintf("%s: %s", fn, err.Error()))
+		return nil, err
+	}
+
+	page.ValidatePagination()
+
+	reviews, err := s.repo.GetModerationQueue(ctx, status, page)
+	if err != nil {
+		s.logger(ctx).Error(fmt.Sprintf("%s: %s", fn, err.Error()))
+		return nil, fmt.Errorf("%s: %w", fn, err)
+	}
+
+	return reviews, nil
+}
+
+// HideReview hides the review from other users and resolves its reports.
+func (s *ReviewService) HideReview(ctx context.Context, moderatorID, id uint32) error {
+	return s.moderate(ctx, "reviewService.HideReview", moderatorID, id, true)
+}
+
+// RestoreReview makes the review visible again and dismisses its reports.
+func (s *ReviewService) RestoreReview(ctx context.Context, moderatorID, id uint32) error {
+	return s.moderate(ctx, "reviewService.RestoreReview", moderatorID, id, false)
+}
+
+func (s *ReviewService) moderate(ctx context.Context, fn string, moderatorID, id uint32, hidden bool) error {
+	err := s.repo.WithTx(ctx, func(repo postgres.IRepository) error {
+		return repo.SetReviewHidden(ctx, id, moderatorID, hidden)
+	})
+	if err != nil {
+		s.logger(ctx).Error(fmt.Sprintf("%s: %s", fn, err.Error()))
+		return fmt.Errorf("%s: %w", fn, err)
+	}
+
+	s.logger(ctx).Info("review moderated",
+		slog.Uint64("review_id", uint64(id)),
+		slog.Bool("hidden", hidden))
+
+	return nil
+}
+
+// checkOthersReview returns reviewrepo.ErrNotFound for hidden reviews and
+// ErrOwnReview for reviews written by the user.
+func (s *ReviewService) checkOthersReview(ctx context.Context, userID, id uint32) error {
+	review, err := s.repo.GetReviewByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if review.Hidden {
+		return fmt.Errorf("hidden: %w", reviewrepo.ErrNotFound)
+	}
+	if review.AuthorID == userID {
+		return ErrOwnReview
+	}
+
+	return nil
+}
+
+func canSeeHidden(viewer domains.Principal, review *domains.Review) bool {
+	return (viewer.UserID != 0 && viewer.UserID == review.AuthorID) || viewer.Can(domains.PermReviewModerate)
+}
+
+func validateText(text string) error {
+	return validation.Check("text", text, validation.Length(1, maxTextLen).Err(ErrInvalidText))
+}
+
+func (s *ReviewService) logger(ctx context.Context) *slog.Logger {
+	return logger.FromContext(ctx, s.log)
+}
@@ -0,0 +1,342 @@
+package reviewservice
+
+import (
+	"context"
+	"errors"
+	"film_library/internal/domains"
+	"film_library/internal/repositories/postgres"
+	mock_postgres "film_library/internal/repositories/postgres/mocks"
+	"film_library/internal/repositories/postgres/reviewrepo"
+	mock_reviewservice "film_library/internal/services/reviewservice/mocks"
+	"film_library/pkg/pagination"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+)
+
+var discard = slog.New(slog.NewTextHandler(io.Discard, nil))
+
+func TestReviewServiceGetReview(t *testing.T) {
+	type mockBehavior func(r *mock_reviewservice.MockReviewRepo)
+
+	visible := &domains.Review{ID: 7, AuthorID: 3, Text: "Great film"}
+	hidden := &domains.Review{ID: 7, AuthorID: 3, Text: "Spam", Hidden: true}
+
+	tests := []struct {
+		name         string
+		viewer       domains.Principal
+		mockBehavior mockBehavior
+		review       *domains.Review
+		err          error
+	}{
+		{
+			name:   "Visible",
+			viewer: domains.Principal{UserID: 2},
+			mockBehavior: func(r *mock_reviewservice.MockReviewRepo) {
+				r.EXPECT().GetReviewByID(gomock.Any(), uint32(7)).Return(visible, nil)
+			},
+			review: visible,
+		},
+		{
+			name:   "Hidden from others",
+			viewer: domains.Principal{UserID: 2, Permissions: []domains.Permission{domains.PermReviewWrite}},
+			mockBehavior: func(r *mock_reviewservice.MockReviewRepo) {
+				r.EXPECT().GetReviewByID(gomock.Any(), uint32(7)).Return(hidden, nil)
+			},
+			err: reviewrepo.ErrNotFound,
+		},
+		{
+			name:   "Hidden from API keys",
+			viewer: domains.Principal{APIKeyID: 1},
+			mockBehavior: func(r *mock_reviewservice.MockReviewRepo) {
+				r.EXPECT().GetReviewByID(gomock.Any(), uint32(7)).Return(&domains.Review{ID: 7, Hidden: true}, nil)
+			},
+			err: reviewrepo.ErrNotFound,
+		},
+		{
+			name:   "Hidden shown to author",
+			viewer: domains.Principal{UserID: 3},
+			mockBehavior: func(r *mock_reviewservice.MockReviewRepo) {
+				r.EXPECT().GetReviewByID(gomock.Any(), uint32(7)).Return(hidden, nil)
+			},
+			review: hidden,
+		},
+		{
+			name:   "Hidden shown to moderator",
+			viewer: domains.Principal{UserID: 1, Permissions: []domains.Permission{domains.PermReviewModerate}},
+			mockBehavior: func(r *mock_reviewservice.MockReviewRepo) {
+				r.EXPECT().GetReviewByID(gomock.Any(), uint32(7)).Return(hidden, nil)
+			},
+			review: hidden,
+		},
+		{
+			name:   "Not found",
+			viewer: domains.Principal{UserID: 2},
+			mockBehavior: func(r *mock_reviewservice.MockReviewRepo) {
+				r.EXPECT().GetReviewByID(gomock.Any(), uint32(7)).Return(nil, reviewrepo.ErrNotFound)
+			},
+			err: reviewrepo.ErrNotFound,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			repo := mock_reviewservice.NewMockReviewRepo(c)
+			tc.mockBehavior(repo)
+
+			review, err := New(repo, discard).GetReview(context.Background(), tc.viewer, 7)
+
+			if !errors.Is(err, tc.err) {
+				t.Errorf("expected: %s\ngot: %s", tc.err, err)
+			}
+
+			if review != tc.review {
+				t.Errorf("expected: %#v\ngot: %#v", tc.review, review)
+			}
+		})
+	}
+}
+
+func TestReviewServiceVoteReview(t *testing.T) {
+	type mockBehavior func(r *mock_reviewservice.MockReviewRepo)
+
+	customError := fmt.Errorf("some error")
+
+	tests := []struct {
+		name         string
+		mockBehavior mockBehavior
+		err          error
+	}{
+		{
+			name: "Correct",
+			mockBehavior: func(r *mock_reviewservice.MockReviewRepo) {
+				r.EXPECT().GetReviewByID(gomock.Any(), uint32(7)).Return(&domains.Review{ID: 7, AuthorID: 3}, nil)
+				r.EXPECT().SetReviewVote(gomock.Any(), uint32(7), uint32(2), true).Return(nil)
+			},
+		},
+		{
+			name: "Own review",
+			mockBehavior: func(r *mock_reviewservice.MockReviewRepo) {
+				r.EXPECT().GetReviewByID(gomock.Any(), uint32(7)).Return(&domains.Review{ID: 7, AuthorID: 2}, nil)
+			},
+			err: ErrOwnReview,
+		},
+		{
+			name: "Hidden review",
+			mockBehavior: func(r *mock_reviewservice.MockReviewRepo) {
+				r.EXPECT().GetReviewByID(gomock.Any(), uint32(7)).Return(&domains.Review{ID: 7, AuthorID: 3, Hidden: true}, nil)
+			},
+			err: reviewrepo.ErrNotFound,
+		},
+		{
+			name: "Unknown error",
+			mockBehavior: func(r *mock_reviewservice.MockReviewRepo) {
+				r.EXPECT().GetReviewByID(gomock.Any(), uint32(7)).Return(&domains.Review{ID: 7, AuthorID: 3}, nil)
+				r.EXPECT().SetReviewVote(gomock.Any(), uint32(7), uint32(2), true).Return(customError)
+			},
+			err: customError,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			repo := mock_reviewservice.NewMockReviewRepo(c)
+			tc.mockBehavior(repo)
+
+			err := New(repo, discard).VoteReview(context.Background(), 2, 7, true)
+
+			if !errors.Is(err, tc.err) {
+				t.Errorf("expected: %s\ngot: %s", tc.err, err)
+			}
+		})
+	}
+}
+
+func TestReviewServiceReportReview(t *testing.T) {
+	type mockBehavior func(r *mock_reviewservice.MockReviewRepo)
+
+	tests := []struct {
+		name         string
+		reason       string
+		mockBehavior mockBehavior
+		err          error
+	}{
+		{
+			name:   "Correct",
+			reason: "  spam ",
+			mockBehavior: func(r *mock_reviewservice.MockReviewRepo) {
+				r.EXPECT().GetReviewByID(gomock.Any(), uint32(7)).Return(&domains.Review{ID: 7, AuthorID: 3}, nil)
+				r.EXPECT().AddReviewReport(gomock.Any(), uint32(7), uint32(2), "spam").Return(nil)
+			},
+		},
+		{
+			name:   "Own review",
+			reason: "spam",
+			mockBehavior: func(r *mock_reviewservice.MockReviewRepo) {
+				r.EXPECT().GetReviewByID(gomock.Any(), uint32(7)).Return(&domains.Review{ID: 7, AuthorID: 2}, nil)
+			},
+			err: ErrOwnReview,
+		},
+		{
+			name:   "Hidden review",
+			reason: "spam",
+			mockBehavior: func(r *mock_reviewservice.MockReviewRepo) {
+				r.EXPECT().GetReviewByID(gomock.Any(), uint32(7)).Return(&domains.Review{ID: 7, AuthorID: 3, Hidden: true}, nil)
+			},
+			err: reviewrepo.ErrNotFound,
+		},
+		{
+			name:         "Too long reason",
+			reason:       strings.Repeat("a", maxReasonLen+1),
+			mockBehavior: func(r *mock_reviewservice.MockReviewRepo) {},
+			err:          ErrInvalidReason,
+		},
+		{
+			name:   "Already reported",
+			reason: "spam",
+			mockBehavior: func(r *mock_reviewservice.MockReviewRepo) {
+				r.EXPECT().GetReviewByID(gomock.Any(), uint32(7)).Return(&domains.Review{ID: 7, AuthorID: 3}, nil)
+				r.EXPECT().AddReviewReport(gomock.Any(), uint32(7), uint32(2), "spam").Return(reviewrepo.ErrAlreadyReported)
+			},
+			err: reviewrepo.ErrAlreadyReported,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			repo := mock_reviewservice.NewMockReviewRepo(c)
+			tc.mockBehavior(repo)
+
+			err := New(repo, discard).ReportReview(context.Background(), 2, 7, tc.reason)
+
+			if !errors.Is(err, tc.err) {
+				t.Errorf("expected: %s\ngot: %s", tc.err, err)
+			}
+		})
+	}
+}
+
+func TestReviewServiceGetModerationQueue(t *testing.T) {
+	type mockBehavior func(r *mock_reviewservice.MockReviewRepo)
+
+	tests := []struct {
+		name         string
+		status       string
+		mockBehavior mockBehavior
+		err          error
+	}{
+		{
+			name:   "Reported by default",
+			status: "",
+			mockBehavior: func(r *mock_reviewservice.MockReviewRepo) {
+				r.EXPECT().GetModerationQueue(gomock.Any(), domains.ModerationReported, gomock.Any()).Return([]*domains.ModeratedReview{}, nil)
+			},
+		},
+		{
+			name:   "Hidden",
+			status: domains.ModerationHidden,
+			mockBehavior: func(r *mock_reviewservice.MockReviewRepo) {
+				r.EXPECT().GetModerationQueue(gomock.Any(), domains.ModerationHidden, gomock.Any()).Return([]*domains.ModeratedReview{}, nil)
+			},
+		},
+		{
+			name:         "Invalid status",
+			status:       "all",
+			mockBehavior: func(r *mock_reviewservice.MockReviewRepo) {},
+			err:          ErrInvalidModerationStatus,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			repo := mock_reviewservice.NewMockReviewRepo(c)
+			tc.mockBehavior(repo)
+
+			_, err := New(repo, discard).GetModerationQueue(context.Background(), tc.status, pagination.New(1, 10))
+
+			if !errors.Is(err, tc.err) {
+				t.Errorf("expected: %s\ngot: %s", tc.err, err)
+			}
+		})
+	}
+}
+
+func TestReviewServiceModerate(t *testing.T) {
+	type mockBehavior func(tx *mock_postgres.MockIRepository)
+
+	tests := []struct {
+		name         string
+		hide         bool
+		mockBehavior mockBehavior
+		err          error
+	}{
+		{
+			name: "Hide",
+			hide: true,
+			mockBehavior: func(tx *mock_postgres.MockIRepository) {
+				tx.EXPECT().SetReviewHidden(gomock.Any(), uint32(7), uint32(1), true).Return(nil)
+			},
+		},
+		{
+			name: "Restore",
+			hide: false,
+			mockBehavior: func(tx *mock_postgres.MockIRepository) {
+				tx.EXPECT().SetReviewHidden(gomock.Any(), uint32(7), uint32(1), false).Return(nil)
+			},
+		},
+		{
+			name: "Not found",
+			hide: true,
+			mockBehavior: func(tx *mock_postgres.MockIRepository) {
+				tx.EXPECT().SetReviewHidden(gomock.Any(), uint32(7), uint32(1), true).Return(reviewrepo.ErrNotFound)
+			},
+			err: reviewrepo.ErrNotFound,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			repo := mock_reviewservice.NewMockReviewRepo(c)
+			tx := mock_postgres.NewMockIRepository(c)
+			tc.mockBehavior(tx)
+
+			// The review is only changed inside the transaction.
+			repo.EXPECT().WithTx(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(ctx context.Context, txFunc func(repo postgres.IRepository) error) error {
+					return txFunc(tx)
+				})
+
+			service := New(repo, discard)
+
+			var err error
+			if tc.hide {
+				err = service.HideReview(context.Background(), 1, 7)
+			} else {
+				err = service.RestoreReview(context.Background(), 1, 7)
+			}
+
+			if !errors.Is(err, tc.err) {
+				t.Errorf("expected: %s\ngot: %s", tc.err, err)
+			}
+		})
+	}
+}
@@ -1,12 +1,18 @@
 package services
 
 import (
+	"context"
 	"film_library/internal/config"
 	"film_library/internal/domains"
+	"film_library/internal/passwords"
 	"film_library/internal/repositories/postgres"
 	"film_library/internal/services/actorservice"
+	"film_library/internal/services/apikeyservice"
 	"film_library/internal/services/filmservice"
+	"film_library/internal/services/genreservice"
+	"film_library/internal/services/reviewservice"
 	userservice "film_library/internal/services/userservice"
+	"film_library/internal/tokens"
 	"film_library/pkg/pagination"
 	"log/slog"
 	"time"
@@ -15,52 +21,117 @@ import (
 //go:generate mockgen -source=service.go -destination=mocks/mock.go
 
 type UserService interface {
-	CreateUser(user domains.User) (string, error)
-	Login(login, password string) (string, error)
+	CreateUser(ctx context.Context, user domains.User) (*domains.Tokens, error)
+	Login(ctx context.Context, login, password, ip string) (*domains.Tokens, error)
+	RefreshTokens(ctx context.Context, refreshToken string) (*domains.Tokens, error)
+	Logout(ctx context.Context, claims *tokens.Claims, refreshToken string) error
+	IsTokenRevoked(ctx context.Context, jti string) (bool, error)
+	GetUserByID(ctx context.Context, id uint32) (*domains.User, error)
+	GetUsers(ctx context.Context, page *pagination.Pagination) ([]*domains.User, error)
+	UpdateUserRole(ctx context.Context, id uint32, role domains.Role) error
+	SetUserDisabled(ctx context.Context, id uint32, disabled bool) error
+	DeleteUser(ctx context.Context, id uint32) error
+	ChangePassword(ctx context.Context, id uint32, currentPassword, newPassword string) error
+	IssuePasswordReset(ctx context.Context, caller domains.Principal, id uint32) (*domains.PasswordReset, error)
+	ResetPassword(ctx context.Context, resetToken, newPassword string) error
+	GetProfile(ctx context.Context, principal domains.Principal) (*domains.Profile, error)
+	UpdateDisplayName(ctx context.Context, id uint32, displayName string) error
+	DeleteAccount(ctx context.Context, id uint32, password string) error
 }
 
 type FilmService interface {
-	CreateFilm(film domains.Film, actors []uint32) (uint32, error)
-	UpdateFilmName(id uint32, name string) error
-	UpdateFilmDescription(id uint32, descrtion string) error
-	UpdateFilmReleaseDate(id uint32, releaseDate time.Time) error
-	UpdateFilmRating(id uint32, rating int) error
-	UpdateFilm(id uint32, film domains.Film) error
-	DeleteFilm(id uint32) error
-	GetFilms(filter *pagination.FilmFilter) ([]*domains.Film, error)
+	CreateFilm(ctx context.Context, film domains.Film, actors, genres []uint32) (uint32, error)
+	UpdateFilmName(ctx context.Context, id uint32, name string) error
+	UpdateFilmDescription(ctx context.Context, id uint32, descrtion string) error
+	UpdateFilmReleaseDate(ctx context.Context, id uint32, releaseDate time.Time) error
+	UpdateFilmRating(ctx context.Context, id uint32, rating int) error
+	UpdateFilm(ctx context.Context, id uint32, film domains.Film, genres []uint32) error
+	DeleteFilm(ctx context.Context, id uint32) error
+	GetFilms(ctx context.Context, filter *pagination.FilmFilter) ([]*domains.Film, error)
+	GetFilmByID(ctx context.Context, id uint32) (*domains.FilmWithCredits, error)
+	RateFilm(ctx context.Context, userID, filmID uint32, rating int) (*domains.FilmScore, error)
+	DeleteFilmRating(ctx context.Context, userID, filmID uint32) (*domains.FilmScore, error)
+	AddToWatchlist(ctx context.Context, userID, filmID uint32) error
+	RemoveFromWatchlist(ctx context.Context, userID, filmID uint32) error
+	GetWatchlist(ctx context.Context, userID uint32, filter *pagination.FilmFilter) ([]*domains.WatchlistEntry, error)
+	MarkWatched(ctx context.Context, userID, filmID uint32, watchedAt time.Time, rewatchCount *int) error
+	DeleteWatched(ctx context.Context, userID, filmID uint32) error
+	GetWatchedFilms(ctx context.Context, userID uint32, filter *pagination.FilmFilter) ([]*domains.WatchedEntry, error)
 }
 
 type ActorService interface {
-	CreateActor(actor domains.Actor) error
-	AddActorsToFilm(filmID uint32, actorsID []uint32) error
-	UpdateActorFullName(id uint32, fullName string) error
-	UpdateActorGender(id uint32, gender domains.Gender) error
-	UpdateActorBirthday(id uint32, birthday time.Time) error
-	UpdateActor(id uint32, actor domains.Actor) error
-	DeleteActor(id uint32) error
-	DeleteActorFromFilm(actorID uint32, filmID uint32) error
-	GetActorsWithFilms(filter *pagination.ActorsFilter) ([]*domains.ActorWithFilms, error)
+	CreateActor(ctx context.Context, actor domains.Person) error
+	AddActorsToFilm(ctx context.Context, filmID uint32, credits []domains.Credit) error
+	ReplaceFilmActors(ctx context.Context, filmID uint32, credits []domains.Credit) error
+	UpdateActorFullName(ctx context.Context, id uint32, fullName string) error
+	UpdateActorGender(ctx context.Context, id uint32, gender domains.Gender) error
+	UpdateActorBirthday(ctx context.Context, id uint32, birthday time.Time) error
+	UpdateActor(ctx context.Context, id uint32, actor domains.Person) error
+	DeleteActor(ctx context.Context, id uint32) error
+	DeleteActorFromFilm(ctx context.Context, actorID uint32, filmID uint32) error
+	GetActorsWithFilms(ctx context.Context, filter *pagination.ActorsFilter) ([]*domains.PersonWithCredits, error)
+	GetActorByID(ctx context.Context, id uint32) (*domains.PersonWithCredits, error)
+}
+
+type GenreService interface {
+	CreateGenre(ctx context.Context, genre domains.Genre) (uint32, error)
+	GetGenres(ctx context.Context) ([]*domains.Genre, error)
+	UpdateGenre(ctx context.Context, id uint32, genre domains.Genre) error
+	DeleteGenre(ctx context.Context, id uint32) error
+}
+
+type ReviewService interface {
+	CreateReview(ctx context.Context, userID, filmID uint32, text string) (uint32, error)
+	GetReview(ctx context.Context, viewer domains.Principal, id uint32) (*domains.Review, error)
+	GetFilmReviews(ctx context.Context, filter *pagination.ReviewFilter) ([]*domains.Review, error)
+	UpdateReview(ctx context.Context, userID, id uint32, text string) error
+	DeleteReview(ctx context.Context, userID, id uint32) error
+	VoteReview(ctx context.Context, userID, id uint32, helpful bool) error
+	DeleteReviewVote(ctx context.Context, userID, id uint32) error
+	ReportReview(ctx context.Context, userID, id uint32, reason string) error
+	GetModerationQueue(ctx context.Context, status string, page *pagination.Pagination) ([]*domains.ModeratedReview, error)
+	HideReview(ctx context.Context, moderatorID, id uint32) error
+	RestoreReview(ctx context.Context, moderatorID, id uint32) error
+}
+
+type APIKeyService interface {
+	CreateAPIKey(ctx context.Context, creator domains.Principal, key domains.APIKey) (*domains.CreatedAPIKey, error)
+	GetAPIKeys(ctx context.Context, page *pagination.Pagination) ([]*domains.APIKey, error)
+	DeleteAPIKey(ctx context.Context, id uint32) error
+	AuthenticateAPIKey(ctx context.Context, key string) (*domains.APIKey, error)
 }
 
 type Service struct {
 	UserService
 	FilmService
 	ActorService
+	GenreService
+	ReviewService
+	APIKeyService
 }
 
 type IService interface {
 	UserService
 	FilmService
 	ActorService
+	GenreService
+	ReviewService
+	APIKeyService
 }
 
-func New(repo postgres.IRepository, log *slog.Logger, cfg *config.Config) IService {
-	userService := userservice.New(repo, log, cfg)
+func New(repo postgres.IRepository, log *slog.Logger, cfg *config.Config, issuer *tokens.Issuer, policy *passwords.Policy, hasher *passwords.Hasher, roles domains.RolePermissions) IService {
+	userService := userservice.New(repo, log, cfg, issuer, policy, hasher, roles)
 	actorService := actorservice.New(repo, log)
-	filmservice := filmservice.New(repo, actorService, log, cfg)
+	filmservice := filmservice.New(repo, log, cfg)
+	genreService := genreservice.New(repo, log)
+	reviewService := reviewservice.New(repo, log)
+	apiKeyService := apikeyservice.New(repo, log)
 	return &Service{
 		userService,
 		filmservice,
 		actorService,
+		genreService,
+		reviewService,
+		apiKeyService,
 	}
 }
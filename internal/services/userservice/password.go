@@ -0,0 +1,202 @@
+package userservice
+
+import (
+	"context"
+	"errors"
+	"film_library/internal/domains"
+	"film_library/internal/passwords"
+	"film_library/internal/repositories/postgres"
+	"film_library/internal/repositories/postgres/tokenrepo"
+	"film_library/internal/tokens"
+	"film_library/pkg/validation"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+// ChangePassword sets a new password after checking the current one. Wrong
+// current passwords count as failed logins. All sessions of the user are
+// ended, including the one of the request.
+func (s *UserService) ChangePassword(ctx context.Context, id uint32, currentPassword, newPassword string) error {
+	fn := "userService.ChangePassword"
+
+	user, err := s.repo.GetUserByID(ctx, id)
+	if err != nil {
+		s.logger(ctx).Error(fmt.Sprintf("%s: %s", fn, err.Error()))
+		return fmt.Errorf("%s: %w", fn, err)
+	}
+
+	if err := s.confirmPassword(ctx, user, currentPassword); err != nil {
+		s.logger(ctx).Warn(fmt.Sprintf("%s: %s", fn, err.Error()))
+		return fmt.Errorf("%s: %w", fn, err)
+	}
+
+	err = s.setPassword(ctx, s.repo, user, newPassword)
+	if err != nil {
+		s.logger(ctx).Error(fmt.Sprintf("%s: %s", fn, err.Error()))
+		return fmt.Errorf("%s: %w", fn, err)
+	}
+
+	return nil
+}
+
+// IssuePasswordReset creates a one-time token that lets the user set a new
+// password without knowing the current one. Earlier unused tokens stop working.
+// The token takes over the account, so caller may only reset other users
+// whose permissions it holds itself.
+func (s *UserService) IssuePasswordReset(ctx context.Context, caller domains.Principal, id uint32) (*domains.PasswordReset, error) {
+	fn := "userService.IssuePasswordReset"
+
+	if caller.UserID == id {
+		s.logger(ctx).Warn(fmt.Sprintf("%s: %s", fn, ErrOwnAccount.Error()))
+		return nil, fmt.Errorf("%s: %w", fn, ErrOwnAccount)
+	}
+
+	token, hash, err := tokens.NewResetToken()
+	if err != nil {
+		s.logger(ctx).Error(fmt.Sprintf("%s: %s", fn, err.Error()))
+		return nil, fmt.Errorf("%s: %w", fn, err)
+	}
+	expiresAt := time.Now().Add(s.cfg.Identity.PasswordResetTTL)
+
+	err = s.repo.WithTx(ctx, func(repo postgres.IRepository) error {
+		target, err := repo.GetUserByID(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		for _, p := range s.roles.Of(target.Role) {
+			if !caller.Can(p) {
+				return ErrTargetPrivileged
+			}
+		}
+
+		return repo.AddPasswordResetToken(ctx, domains.PasswordResetToken{
+			UserID:    id,
+			Hash:      hash,
+			ExpiresAt: expiresAt,
+		})
+	})
+	if err != nil {
+		s.logger(ctx).Error(fmt.Sprintf("%s: %s", fn, err.Error()))
+		return nil, fmt.Errorf("%s: %w", fn, err)
+	}
+
+	s.logger(ctx).Info("password reset issued", slog.Uint64("target_user_id", uint64(id)))
+
+	return &domains.PasswordReset{Token: token, ExpiresAt: expiresAt}, nil
+}
+
+// ResetPassword redeems a reset token.
+func (s *UserService) ResetPassword(ctx context.Context, resetToken, newPassword string) error {
+	fn := "userService.ResetPassword"
+
+	var login string
+	err := s.repo.WithTx(ctx, func(repo postgres.IRepository) error {
+		stored, err := repo.GetPasswordResetToken(ctx, tokens.HashResetToken(resetToken))
+		if err != nil {
+			if errors.Is(err, tokenrepo.ErrNotFound) {
+				return ErrInvalidResetToken
+			}
+			return err
+		}
+
+		if stored.UsedAt != nil || !stored.ExpiresAt.After(time.Now()) {
+			return ErrInvalidResetToken
+		}
+
+		user, err := repo.GetUserByID(ctx, stored.UserID)
+		if err != nil {
+			return err
+		}
+		login = user.Login
+
+		if err := s.setPassword(ctx, repo, user, newPassword); err != nil {
+			return err
+		}
+
+		return repo.UsePasswordResetToken(ctx, stored.ID)
+	})
+	if err != nil {
+		s.logger(ctx).Error(fmt.Sprintf("%s: %s", fn, err.Error()))
+		return fmt.Errorf("%s: %w", fn, err)
+	}
+
+	s.logins.Reset(login)
+
+	return nil
+}
+
+// setPassword enforces the password policy, stores the new hash and ends all
+// sessions of the user: refresh tokens are revoked and the token version is
+// bumped, so access tokens already issued stop working too.
+func (s *UserService) setPassword(ctx context.Context, repo UserRepo, user *domains.User, password string) error {
+	err := validation.Check("newPassword", password, s.passwordRules(user.Login)...)
+	if err != nil {
+		return err
+	}
+
+	hash, err := s.hasher.Hash(password)
+	if err != nil {
+		return err
+	}
+
+	return repo.WithTx(ctx, func(repo postgres.IRepository) error {
+		if err := repo.UpdateUserPassword(ctx, user.ID, hash); err != nil {
+			return err
+		}
+		if err := repo.BumpTokenVersion(ctx, user.ID); err != nil {
+			return err
+		}
+		return repo.RevokeUserRefreshTokens(ctx, user.ID, domains.RevokedPasswordChange)
+	})
+}
+
+func (s *UserService) passwordRules(login string) []validation.Rule[string] {
+	identity := s.cfg.Identity
+	rules := []validation.Rule[string]{
+		validation.Length(identity.MinPasswordLen, identity.MaxPasswordLen).Err(ErrInvalidPasswordLen),
+	}
+	return append(rules, s.policy.Rules(login)...)
+}
+
+// confirmPassword checks the password of a signed in user before a sensitive
+// change. Wrong passwords count as failed logins.
+func (s *UserService) confirmPassword(ctx context.Context, user *domains.User, password string) error {
+	if err := s.beginLogin(user.Login, ""); err != nil {
+		return err
+	}
+
+	if err := s.hasher.Verify(user.Password, password); err != nil {
+		if !errors.Is(err, passwords.ErrMismatch) {
+			s.releaseLogin(user.Login, "")
+			return err
+		}
+		s.loginFailed(ctx, user.Login, "")
+		return ErrWrongPassword
+	}
+
+	s.releaseLogin(user.Login, "")
+	return nil
+}
+
+// rehash replaces an outdated hash of a just verified password. Failures are
+// only logged: the old hash keeps working and is retried on the next login.
+func (s *UserService) rehash(ctx context.Context, user *domains.User, password string) {
+	fn := "userService.rehash"
+
+	if !s.hasher.NeedsRehash(user.Password) {
+		return
+	}
+
+	hash, err := s.hasher.Hash(password)
+	if err == nil {
+		err = s.repo.UpdateUserPassword(ctx, user.ID, hash)
+	}
+	if err != nil {
+		s.logger(ctx).Error(fmt.Sprintf("%s: %s", fn, err.Error()))
+		return
+	}
+
+	user.Password = hash
+}
@@ -0,0 +1,85 @@
+package userservice
+
+import (
+	"context"
+	"film_library/internal/domains"
+	"film_library/pkg/validation"
+	"fmt"
+	"strings"
+)
+
+// maxDisplayNameLen matches the users.display_name column.
+const maxDisplayNameLen = 100
+
+// GetProfile adds the profile of the user behind principal. API keys have
+// no profile and are returned as they are.
+func (s *UserService) GetProfile(ctx context.Context, principal domains.Principal) (*domains.Profile, error) {
+	fn := "userService.GetProfile"
+
+	profile := &domains.Profile{Principal: principal}
+	if principal.UserID == 0 {
+		return profile, nil
+	}
+
+	user, err := s.repo.GetUserByID(ctx, principal.UserID)
+	if err != nil {
+		s.logger(ctx).Error(fmt.Sprintf("%s: %s", fn, err.Error()))
+		return nil, fmt.Errorf("%s: %w", fn, err)
+	}
+
+	profile.DisplayName = user.DisplayName
+	profile.CreatedAt = &user.CreatedAt
+	profile.LastLoginAt = user.LastLoginAt
+
+	return profile, nil
+}
+
+// UpdateDisplayName sets the display name of the user, an empty name clears it.
+func (s *UserService) UpdateDisplayName(ctx context.Context, id uint32, displayName string) error {
+	fn := "userService.UpdateDisplayName"
+
+	displayName = strings.TrimSpace(displayName)
+
+	err := validation.Check("displayName", displayName,
+		validation.Length(0, maxDisplayNameLen).Err(ErrInvalidDisplayName))
+	if err != nil {
+		s.logger(ctx).Error(fmt.Sprintf("%s: %s", fn, err.Error()))
+		return fmt.Errorf("%s: %w", fn, err)
+	}
+
+	err = s.repo.UpdateUserDisplayName(ctx, id, displayName)
+	if err != nil {
+		s.logger(ctx).Error(fmt.Sprintf("%s: %s", fn, err.Error()))
+		return fmt.Errorf("%s: %w", fn, err)
+	}
+
+	return nil
+}
+
+// DeleteAccount deletes the user after checking the password. Sessions,
+// reset tokens, the API keys the user created and other personal data are
+// removed with the user by the database, in the same transaction.
+func (s *UserService) DeleteAccount(ctx context.Context, id uint32, password string) error {
+	fn := "userService.DeleteAccount"
+
+	user, err := s.repo.GetUserByID(ctx, id)
+	if err != nil {
+		s.logger(ctx).Error(fmt.Sprintf("%s: %s", fn, err.Error()))
+		return fmt.Errorf("%s: %w", fn, err)
+	}
+
+	if err := s.confirmPassword(ctx, user, password); err != nil {
+		s.logger(ctx).Warn(fmt.Sprintf("%s: %s", fn, err.Error()))
+		return fmt.Errorf("%s: %w", fn, err)
+	}
+
+	err = s.deleteUser(ctx, id)
+	if err != nil {
+		s.logger(ctx).Error(fmt.Sprintf("%s: %s", fn, err.Error()))
+		return fmt.Errorf("%s: %w", fn, err)
+	}
+
+	s.logger(ctx).Info("account deleted")
+
+	return nil
+}
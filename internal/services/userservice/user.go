@@ -1,124 +1,529 @@
 package userservice
 
 import (
+	"context"
 	"errors"
 	"film_library/internal/config"
 	"film_library/internal/domains"
+	"film_library/internal/lockout"
+	"film_library/internal/logger"
+	"film_library/internal/passwords"
+	"film_library/internal/repositories/postgres"
+	"film_library/internal/repositories/postgres/ratingrepo"
+	"film_library/internal/repositories/postgres/tokenrepo"
 	"film_library/internal/repositories/postgres/userrepo"
+	"film_library/internal/tokens"
+	"film_library/pkg/pagination"
 	"film_library/pkg/validation"
 	"fmt"
 	"log/slog"
-
-	"github.com/golang-jwt/jwt/v5"
-	"golang.org/x/crypto/bcrypt"
+	"time"
 )
 
+//go:generate mockgen -source=user.go -destination=mocks/mock.go
+
 var (
 	ErrInvalidRole        = fmt.Errorf("invalid role")
 	ErrInvalidLoginLen    = fmt.Errorf("invalid login length")
 	ErrInvalidPasswordLen = fmt.Errorf("invalid password length")
 	ErrNotFound           = fmt.Errorf("user not found")
 	ErrInvalidPassword    = fmt.Errorf("invalid password")
+	ErrUserDisabled       = fmt.Errorf("user is disabled")
+	ErrInvalidRefresh     = fmt.Errorf("invalid refresh token")
+	ErrWrongPassword      = fmt.Errorf("current password is wrong")
+	ErrInvalidResetToken  = fmt.Errorf("invalid or expired password reset token")
+	ErrInvalidDisplayName = fmt.Errorf("invalid display name length")
+	ErrOwnAccount         = fmt.Errorf("not allowed on the own account")
+	ErrTargetPrivileged   = fmt.Errorf("user holds permissions the caller does not")
+	ErrLastUserManager    = fmt.Errorf("no other enabled user could manage users")
 )
 
 type UserRepo interface {
-	AddUser(user domains.User) error
-	GetUserByLoign(login string) (*domains.User, error)
+	AddUser(ctx context.Context, user domains.User) (uint32, error)
+	GetUserByLoign(ctx context.Context, login string) (*domains.User, error)
+	GetUserByID(ctx context.Context, id uint32) (*domains.User, error)
+	GetUsers(ctx context.Context, page *pagination.Pagination) ([]*domains.User, error)
+	LockEnabledUsers(ctx context.Context, roles []string) ([]uint32, error)
+	UpdateUserRole(ctx context.Context, id uint32, role string) error
+	UpdateUserDisabled(ctx context.Context, id uint32, disabled bool) error
+	UpdateUserPassword(ctx context.Context, id uint32, hash string) error
+	BumpTokenVersion(ctx context.Context, id uint32) error
+	UpdateUserDisplayName(ctx context.Context, id uint32, displayName string) error
+	TouchUserLogin(ctx context.Context, id uint32) error
+	DeleteUser(ctx context.Context, id uint32) error
+	AddRefreshToken(ctx context.Context, token domains.RefreshToken) error
+	GetRefreshToken(ctx context.Context, hash string) (*domains.RefreshToken, error)
+	RevokeRefreshToken(ctx context.Context, id uint32, reason domains.RevokeReason) error
+	RevokeUserRefreshTokens(ctx context.Context, userID uint32, reason domains.RevokeReason) error
+	RevokeAccessToken(ctx context.Context, jti string, expiresAt time.Time) error
+	IsAccessTokenRevoked(ctx context.Context, jti string) (bool, error)
+	AddPasswordResetToken(ctx context.Context, token domains.PasswordResetToken) error
+	GetPasswordResetToken(ctx context.Context, hash string) (*domains.PasswordResetToken, error)
+	UsePasswordResetToken(ctx context.Context, id uint32) error
+	WithTx(ctx context.Context, txFunc func(repo postgres.IRepository) error) error
 }
 
 type UserService struct {
-	repo UserRepo
-	log  *slog.Logger
-	cfg  *config.Config
+	repo   UserRepo
+	log    *slog.Logger
+	cfg    *config.Config
+	issuer *tokens.Issuer
+	policy *passwords.Policy
+	hasher *passwords.Hasher
+	roles  domains.RolePermissions
+	logins *lockout.Guard
+	ips    *lockout.Guard
 }
 
-func New(repo UserRepo, log *slog.Logger, cfg *config.Config) *UserService {
+func New(repo UserRepo, log *slog.Logger, cfg *config.Config, issuer *tokens.Issuer, policy *passwords.Policy, hasher *passwords.Hasher, roles domains.RolePermissions) *UserService {
+	throttle := cfg.Identity.LoginThrottle
 	return &UserService{
-		repo: repo,
-		log:  log,
-		cfg:  cfg,
+		repo:   repo,
+		log:    log,
+		cfg:    cfg,
+		issuer: issuer,
+		policy: policy,
+		hasher: hasher,
+		roles:  roles,
+		logins: lockout.New(lockout.Policy{
+			FreeAttempts: throttle.FreeAttempts,
+			BaseDelay:    throttle.BaseDelay,
+			MaxDelay:     throttle.MaxDelay,
+			Threshold:    throttle.LockoutThreshold,
+			Duration:     throttle.LockoutDuration,
+			Window:       throttle.FailureWindow,
+		}),
+		ips: lockout.New(lockout.Policy{
+			FreeAttempts: throttle.IPFreeAttempts,
+			BaseDelay:    throttle.BaseDelay,
+			MaxDelay:     throttle.MaxDelay,
+			Threshold:    throttle.IPLockoutThreshold,
+			Duration:     throttle.LockoutDuration,
+			Window:       throttle.FailureWindow,
+		}),
 	}
 }
 
-func (s *UserService) CreateUser(user domains.User) (string, error) {
+// CreateUser registers a viewer. Roles are granted by admins only.
+func (s *UserService) CreateUser(ctx context.Context, user domains.User) (*domains.Tokens, error) {
 	fn := "userService.CreateUser"
 
+	user.Role = domains.RoleViewer
+
 	minLoginLen, maxLoginLen := s.cfg.Identity.MinLoginLen, s.cfg.Identity.MaxLoginLen
-	minPasswordLen, maxPasswordLen := s.cfg.Identity.MinPasswordLen, s.cfg.Identity.MaxPasswordLen
 
 	err := validation.NewValidator[domains.User](user).
-		Between(
-			func(u domains.User) int { return len(u.Login) },
-			minLoginLen, maxLoginLen,
-			ErrInvalidLoginLen.Error()).
-		Between(
-			func(u domains.User) int { return len(u.Password) },
-			minPasswordLen, maxPasswordLen,
-			ErrInvalidPasswordLen.Error()).
-		Must(
-			func(u domains.User) bool { return user.Role.IsValidRole() },
-			ErrInvalidRole.Error()).
+		String("login",
+			func(u domains.User) string { return u.Login },
+			validation.Length(minLoginLen, maxLoginLen).Err(ErrInvalidLoginLen)).
+		String("password",
+			func(u domains.User) string { return u.Password },
+			s.passwordRules(user.Login)...).
 		Validate()
 
 	if err != nil {
-		s.log.Error(fmt.Sprintf("%s: %s", fn, err.Error()))
-		return "", err
+		s.logger(ctx).Error(fmt.Sprintf("%s: %s", fn, err.Error()))
+		return nil, err
 	}
 
-	hashPassword, err := bcrypt.GenerateFromPassword([]byte(user.Password), bcrypt.DefaultCost)
+	user.Password, err = s.hasher.Hash(user.Password)
 	if err != nil {
-		s.log.Error(fmt.Sprintf("%s: error occurred generating hash password: %s", fn, ErrInvalidRole.Error()))
-		return "", fmt.Errorf("%s: %w", fn, err)
+		s.logger(ctx).Error(fmt.Sprintf("%s: error occurred generating hash password: %s", fn, err.Error()))
+		return nil, fmt.Errorf("%s: %w", fn, err)
 	}
-	user.Password = string(hashPassword)
 
-	err = s.repo.AddUser(user)
+	user.ID, err = s.repo.AddUser(ctx, user)
 	if err != nil {
-		s.log.Error(fmt.Sprintf("%s: %s", fn, err.Error()))
-		return "", fmt.Errorf("%s: %w", fn, err)
+		s.logger(ctx).Error(fmt.Sprintf("%s: %s", fn, err.Error()))
+		return nil, fmt.Errorf("%s: %w", fn, err)
 	}
 
-	return s.generateToken(&user, s.cfg.Server.Secret)
+	return s.issueTokens(ctx, s.repo, &user)
 }
 
-func (s *UserService) GetUserByLogin(login string) (*domains.User, error) {
+func (s *UserService) GetUserByLogin(ctx context.Context, login string) (*domains.User, error) {
 	fn := "userService.GetUserByLogin"
 
-	u, err := s.repo.GetUserByLoign(login)
+	u, err := s.repo.GetUserByLoign(ctx, login)
 	if err != nil {
-		s.log.Error(fmt.Sprintf("%s: %s", fn, err.Error()))
+		s.logger(ctx).Error(fmt.Sprintf("%s: %s", fn, err.Error()))
 		return nil, fmt.Errorf("%s: %w", fn, err)
 	}
 
 	return u, nil
 }
 
-func (s *UserService) Login(login, password string) (string, error) {
+// Login checks the password of login. Failed attempts from the same login or
+// client ip are throttled, see config.LoginThrottle.
+func (s *UserService) Login(ctx context.Context, login, password, ip string) (*domains.Tokens, error) {
 	fn := "userService.Login"
 
-	user, err := s.repo.GetUserByLoign(login)
+	if err := s.beginLogin(login, ip); err != nil {
+		s.logger(ctx).Warn(fmt.Sprintf("%s: %s", fn, err.Error()))
+		return nil, fmt.Errorf("%s: %w", fn, err)
+	}
+
+	user, err := s.repo.GetUserByLoign(ctx, login)
 	if err != nil {
-		s.log.Error(fmt.Sprintf("%s: %s", fn, err.Error()))
+		s.logger(ctx).Error(fmt.Sprintf("%s: %s", fn, err.Error()))
 		if errors.Is(err, userrepo.ErrNotFound) {
-			return "", fmt.Errorf("%s: %w", fn, ErrNotFound)
+			// Unknown logins pay for a hash comparison too, otherwise the
+			// response time tells which logins exist.
+			s.hasher.VerifyDummy(password)
+			s.loginFailed(ctx, login, ip)
+			return nil, fmt.Errorf("%s: %w", fn, ErrNotFound)
+		}
+		s.releaseLogin(login, ip)
+		return nil, fmt.Errorf("%s: %w", fn, err)
+	}
+
+	if err := s.hasher.Verify(user.Password, password); err != nil {
+		if !errors.Is(err, passwords.ErrMismatch) {
+			s.logger(ctx).Error(fmt.Sprintf("%s: %s", fn, err.Error()))
+			s.releaseLogin(login, ip)
+			return nil, fmt.Errorf("%s: %w", fn, err)
+		}
+		s.logger(ctx).Warn(fmt.Sprintf("%s: %s", fn, err.Error()))
+		s.loginFailed(ctx, login, ip)
+		return nil, fmt.Errorf("%s: %w", fn, ErrInvalidPassword)
+	}
+	// A success forgets the failures of the login, but not of the ip, which
+	// may be guessing other logins.
+	s.logins.Reset(login)
+	if ip != "" {
+		s.ips.Release(ip)
+	}
+	s.rehash(ctx, user, password)
+
+	if user.Disabled {
+		s.logger(ctx).Warn(fmt.Sprintf("%s: %s: %s", fn, ErrUserDisabled.Error(), login))
+		return nil, fmt.Errorf("%s: %w", fn, ErrUserDisabled)
+	}
+
+	pair, err := s.issueTokens(ctx, s.repo, user)
+	if err != nil {
+		s.logger(ctx).Error(fmt.Sprintf("%s: %s", fn, err.Error()))
+		return nil, fmt.Errorf("%s: %w", fn, err)
+	}
+
+	if err := s.repo.TouchUserLogin(ctx, user.ID); err != nil {
+		s.logger(ctx).Error(fmt.Sprintf("%s: %s", fn, err.Error()))
+	}
+
+	return pair, nil
+}
+
+// beginLogin reserves an attempt of login from ip, see lockout.Guard.Begin.
+// The attempt is settled by loginFailed, releaseLogin or, after a success, by
+// resetting the login; ip is empty when only the login is known.
+func (s *UserService) beginLogin(login, ip string) error {
+	if err := s.logins.Begin(login); err != nil {
+		return err
+	}
+	if ip == "" {
+		return nil
+	}
+	if err := s.ips.Begin(ip); err != nil {
+		s.logins.Release(login)
+		return err
+	}
+	return nil
+}
+
+// releaseLogin settles an attempt whose password could not be checked.
+func (s *UserService) releaseLogin(login, ip string) {
+	s.logins.Release(login)
+	if ip != "" {
+		s.ips.Release(ip)
+	}
+}
+
+// loginFailed counts a failed attempt; ip is empty when only the login is
+// known. Lockouts are logged with a fixed message, so that alerts can match on it.
+func (s *UserService) loginFailed(ctx context.Context, login, ip string) {
+	if s.logins.Fail(login) {
+		s.logger(ctx).Warn("login locked out",
+			slog.String("scope", "login"),
+			slog.String("login", login),
+			slog.String("ip", ip),
+			slog.String("duration", s.cfg.Identity.LoginThrottle.LockoutDuration.String()))
+	}
+	if ip != "" && s.ips.Fail(ip) {
+		s.logger(ctx).Warn("login locked out",
+			slog.String("scope", "ip"),
+			slog.String("login", login),
+			slog.String("ip", ip),
+			slog.String("duration", s.cfg.Identity.LoginThrottle.LockoutDuration.String()))
+	}
+}
+
+func (s *UserService) GetUserByID(ctx context.Context, id uint32) (*domains.User, error) {
+	fn := "userService.GetUserByID"
+
+	u, err := s.repo.GetUserByID(ctx, id)
+	if err != nil {
+		s.logger(ctx).Error(fmt.Sprintf("%s: %s", fn, err.Error()))
+		return nil, fmt.Errorf("%s: %w", fn, err)
+	}
+
+	return u, nil
+}
+
+func (s *UserService) GetUsers(ctx context.Context, page *pagination.Pagination) ([]*domains.User, error) {
+	fn := "userService.GetUsers"
+
+	page.ValidatePagination()
+
+	users, err := s.repo.GetUsers(ctx, page)
+	if err != nil {
+		s.logger(ctx).Error(fmt.Sprintf("%s: %s", fn, err.Error()))
+		return nil, fmt.Errorf("%s: %w", fn, err)
+	}
+
+	return users, nil
+}
+
+func (s *UserService) UpdateUserRole(ctx context.Context, id uint32, role domains.Role) error {
+	fn := "userService.UpdateUserRole"
+
+	err := validation.Check("role", string(role), validation.Enum(domains.Roles).Err(ErrInvalidRole))
+	if err != nil {
+		s.logger(ctx).Error(fmt.Sprintf("%s: %s", fn, err.Error()))
+		return fmt.Errorf("%s: %w", fn, err)
+	}
+
+	err = s.repo.WithTx(ctx, func(repo postgres.IRepository) error {
+		if !s.roles.Allows(role, domains.PermUserManage) {
+			if err := s.keepUserManager(ctx, repo, id); err != nil {
+				return err
+			}
+		}
+		return repo.UpdateUserRole(ctx, id, string(role))
+	})
+	if err != nil {
+		s.logger(ctx).Error(fmt.Sprintf("%s: %s", fn, err.Error()))
+		return fmt.Errorf("%s: %w", fn, err)
+	}
+
+	return nil
+}
+
+func (s *UserService) SetUserDisabled(ctx context.Context, id uint32, disabled bool) error {
+	fn := "userService.SetUserDisabled"
+
+	err := s.repo.WithTx(ctx, func(repo postgres.IRepository) error {
+		if disabled {
+			if err := s.keepUserManager(ctx, repo, id); err != nil {
+				return err
+			}
+		}
+		return repo.UpdateUserDisabled(ctx, id, disabled)
+	})
+	if err != nil {
+		s.logger(ctx).Error(fmt.Sprintf("%s: %s", fn, err.Error()))
+		return fmt.Errorf("%s: %w", fn, err)
+	}
+
+	return nil
+}
+
+func (s *UserService) DeleteUser(ctx context.Context, id uint32) error {
+	fn := "userService.DeleteUser"
+
+	err := s.deleteUser(ctx, id)
+	if err != nil {
+		s.logger(ctx).Error(fmt.Sprintf("%s: %s", fn, err.Error()))
+		return fmt.Errorf("%s: %w", fn, err)
+	}
+
+	return nil
+}
+
+// deleteUser deletes the user and refreshes the scores of the films it rated,
+// as its ratings are deleted with it. So are the API keys it created, which
+// stop working when the transaction commits.
+func (s *UserService) deleteUser(ctx context.Context, id uint32) error {
+	ratings := s.cfg.UserRatings
+	return s.repo.WithTx(ctx, func(repo postgres.IRepository) error {
+		if err := s.keepUserManager(ctx, repo, id); err != nil {
+			return err
+		}
+
+		filmsID, err := repo.GetRatedFilmsID(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if err := repo.DeleteUser(ctx, id); err != nil {
+			return err
 		}
-		return "", fmt.Errorf("%s: %w", fn, err)
+
+		for _, filmID := range filmsID {
+			_, err := repo.RefreshFilmScore(ctx, filmID, ratings.PriorMean, ratings.PriorWeight)
+			if err != nil && !errors.Is(err, ratingrepo.ErrFilmNotFound) {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// keepUserManager returns ErrLastUserManager if the user is the only enabled
+// one allowed to manage users, so that taking the permission away from it
+// would leave nobody to administer the accounts. The enabled managers stay
+// locked until repo's transaction ends, so concurrent changes see each other.
+func (s *UserService) keepUserManager(ctx context.Context, repo postgres.IRepository, id uint32) error {
+	roles := []string{}
+	for _, role := range s.roles.With(domains.PermUserManage) {
+		roles = append(roles, string(role))
 	}
 
-	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password)); err != nil {
-		s.log.Warn(fmt.Sprintf("%s: %s", fn, err.Error()))
-		return "", fmt.Errorf("%s: %w", fn, ErrInvalidPassword)
+	managers, err := repo.LockEnabledUsers(ctx, roles)
+	if err != nil {
+		return err
 	}
 
-	return s.generateToken(user, s.cfg.Server.Secret)
+	if len(managers) == 1 && managers[0] == id {
+		return ErrLastUserManager
+	}
+	return nil
 }
 
-func (s *UserService) generateToken(user *domains.User, secret string) (string, error) {
-	jwt := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
-		"id":    user.ID,
-		"login": user.Login,
-		"role":  user.Role,
+// RefreshTokens rotates refreshToken: it is revoked and a new pair is issued.
+// Presenting an already rotated token revokes all sessions of its user,
+// because the token has most likely leaked. Tokens revoked for other reasons,
+// such as a logout, are just refused.
+func (s *UserService) RefreshTokens(ctx context.Context, refreshToken string) (*domains.Tokens, error) {
+	fn := "userService.RefreshTokens"
+
+	var issued *domains.Tokens
+	var reused *domains.RefreshToken
+	err := s.repo.WithTx(ctx, func(repo postgres.IRepository) error {
+		stored, err := repo.GetRefreshToken(ctx, tokens.HashRefreshToken(refreshToken))
+		if err != nil {
+			if errors.Is(err, tokenrepo.ErrNotFound) {
+				return ErrInvalidRefresh
+			}
+			return err
+		}
+
+		if stored.RevokedAt != nil {
+			if stored.RevokedReason == domains.RevokedRotated {
+				reused = stored
+			}
+			return ErrInvalidRefresh
+		}
+		if !stored.ExpiresAt.After(time.Now()) {
+			return ErrInvalidRefresh
+		}
+
+		user, err := repo.GetUserByID(ctx, stored.UserID)
+		if err != nil {
+			return err
+		}
+		if user.Disabled {
+			return ErrUserDisabled
+		}
+
+		if err := repo.RevokeRefreshToken(ctx, stored.ID, domains.RevokedRotated); err != nil {
+			return err
+		}
+
+		issued, err = s.issueTokens(ctx, repo, user)
+		return err
 	})
 
-	return jwt.SignedString([]byte(secret))
+	if reused != nil {
+		s.logger(ctx).Warn(fmt.Sprintf("%s: rotated refresh token reused, revoking sessions of user %d", fn, reused.UserID))
+		if err := s.repo.RevokeUserRefreshTokens(ctx, reused.UserID, domains.RevokedReuse); err != nil {
+			s.logger(ctx).Error(fmt.Sprintf("%s: %s", fn, err.Error()))
+		}
+	}
+
+	if err != nil {
+		s.logger(ctx).Error(fmt.Sprintf("%s: %s", fn, err.Error()))
+		return nil, fmt.Errorf("%s: %w", fn, err)
+	}
+
+	return issued, nil
+}
+
+// Logout revokes the access token described by claims and, if given, the
+// refresh token of the same session.
+func (s *UserService) Logout(ctx context.Context, claims *tokens.Claims, refreshToken string) error {
+	fn := "userService.Logout"
+
+	err := s.repo.WithTx(ctx, func(repo postgres.IRepository) error {
+		if err := repo.RevokeAccessToken(ctx, claims.ID, claims.ExpiresAt.Time); err != nil {
+			return err
+		}
+
+		if refreshToken == "" {
+			return nil
+		}
+
+		stored, err := repo.GetRefreshToken(ctx, tokens.HashRefreshToken(refreshToken))
+		if err != nil {
+			if errors.Is(err, tokenrepo.ErrNotFound) {
+				return nil
+			}
+			return err
+		}
+		if stored.UserID != claims.UserID {
+			return nil
+		}
+
+		return repo.RevokeRefreshToken(ctx, stored.ID, domains.RevokedLogout)
+	})
+	if err != nil {
+		s.logger(ctx).Error(fmt.Sprintf("%s: %s", fn, err.Error()))
+		return fmt.Errorf("%s: %w", fn, err)
+	}
+
+	return nil
+}
+
+func (s *UserService) IsTokenRevoked(ctx context.Context, jti string) (bool, error) {
+	fn := "userService.IsTokenRevoked"
+
+	revoked, err := s.repo.IsAccessTokenRevoked(ctx, jti)
+	if err != nil {
+		s.logger(ctx).Error(fmt.Sprintf("%s: %s", fn, err.Error()))
+		return false, fmt.Errorf("%s: %w", fn, err)
+	}
+
+	return revoked, nil
+}
+
+func (s *UserService) issueTokens(ctx context.Context, repo UserRepo, user *domains.User) (*domains.Tokens, error) {
+	fn := "userService.issueTokens"
+
+	accessToken, _, err := s.issuer.Issue(user)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", fn, err)
+	}
+
+	refreshToken, hash, err := tokens.NewRefreshToken()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", fn, err)
+	}
+
+	err = repo.AddRefreshToken(ctx, domains.RefreshToken{
+		UserID:    user.ID,
+		Hash:      hash,
+		ExpiresAt: time.Now().Add(s.cfg.Identity.RefreshTokenTTL),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", fn, err)
+	}
+
+	return &domains.Tokens{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		ExpiresIn:    int(s.cfg.Identity.AccessTokenTTL.Seconds()),
+	}, nil
+}
+
+func (s *UserService) logger(ctx context.Context) *slog.Logger {
+	return logger.FromContext(ctx, s.log)
 }
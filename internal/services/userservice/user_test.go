@@ -0,0 +1,134 @@
+package userservice
+
+import (
+	"context"
+	"errors"
+	"film_library/internal/config"
+	"film_library/internal/domains"
+	"film_library/internal/repositories/postgres"
+	mock_postgres "film_library/internal/repositories/postgres/mocks"
+	"film_library/internal/repositories/postgres/tokenrepo"
+	mock_userservice "film_library/internal/services/userservice/mocks"
+	"film_library/internal/tokens"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+)
+
+var discard = slog.New(slog.NewTextHandler(io.Discard, nil))
+
+func TestUserServiceRefreshTokens(t *testing.T) {
+	type mockBehavior func(r *mock_userservice.MockUserRepo, tx *mock_postgres.MockIRepository)
+
+	hash := tokens.HashRefreshToken("refresh")
+	now := time.Now()
+	valid := &domains.RefreshToken{ID: 5, UserID: 2, Hash: hash, ExpiresAt: now.Add(time.Hour)}
+	revoked := func(reason domains.RevokeReason) *domains.RefreshToken {
+		token := *valid
+		token.RevokedAt = &now
+		token.RevokedReason = reason
+		return &token
+	}
+	user := &domains.User{ID: 2, Login: "denis", Role: domains.RoleViewer}
+
+	tests := []struct {
+		name         string
+		mockBehavior mockBehavior
+		issued       bool
+		err          error
+	}{
+		{
+			name: "Correct",
+			mockBehavior: func(r *mock_userservice.MockUserRepo, tx *mock_postgres.MockIRepository) {
+				tx.EXPECT().GetRefreshToken(gomock.Any(), hash).Return(valid, nil)
+				tx.EXPECT().GetUserByID(gomock.Any(), uint32(2)).Return(user, nil)
+				tx.EXPECT().RevokeRefreshToken(gomock.Any(), uint32(5), domains.RevokedRotated).Return(nil)
+				tx.EXPECT().AddRefreshToken(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			issued: true,
+		},
+		{
+			name: "Reused rotated token",
+			mockBehavior: func(r *mock_userservice.MockUserRepo, tx *mock_postgres.MockIRepository) {
+				tx.EXPECT().GetRefreshToken(gomock.Any(), hash).Return(revoked(domains.RevokedRotated), nil)
+				r.EXPECT().RevokeUserRefreshTokens(gomock.Any(), uint32(2), domains.RevokedReuse).Return(nil)
+			},
+			err: ErrInvalidRefresh,
+		},
+		{
+			name: "Logged out token",
+			mockBehavior: func(r *mock_userservice.MockUserRepo, tx *mock_postgres.MockIRepository) {
+				tx.EXPECT().GetRefreshToken(gomock.Any(), hash).Return(revoked(domains.RevokedLogout), nil)
+			},
+			err: ErrInvalidRefresh,
+		},
+		{
+			name: "Token revoked by password change",
+			mockBehavior: func(r *mock_userservice.MockUserRepo, tx *mock_postgres.MockIRepository) {
+				tx.EXPECT().GetRefreshToken(gomock.Any(), hash).Return(revoked(domains.RevokedPasswordChange), nil)
+			},
+			err: ErrInvalidRefresh,
+		},
+		{
+			name: "Expired",
+			mockBehavior: func(r *mock_userservice.MockUserRepo, tx *mock_postgres.MockIRepository) {
+				expired := *valid
+				expired.ExpiresAt = now.Add(-time.Minute)
+				tx.EXPECT().GetRefreshToken(gomock.Any(), hash).Return(&expired, nil)
+			},
+			err: ErrInvalidRefresh,
+		},
+		{
+			name: "Unknown token",
+			mockBehavior: func(r *mock_userservice.MockUserRepo, tx *mock_postgres.MockIRepository) {
+				tx.EXPECT().GetRefreshToken(gomock.Any(), hash).Return(nil, tokenrepo.ErrNotFound)
+			},
+			err: ErrInvalidRefresh,
+		},
+		{
+			name: "Disabled user",
+			mockBehavior: func(r *mock_userservice.MockUserRepo, tx *mock_postgres.MockIRepository) {
+				tx.EXPECT().GetRefreshToken(gomock.Any(), hash).Return(valid, nil)
+				tx.EXPECT().GetUserByID(gomock.Any(), uint32(2)).Return(&domains.User{ID: 2, Login: "denis", Disabled: true}, nil)
+			},
+			err: ErrUserDisabled,
+		},
+	}
+
+	cfg := &config.Config{Identity: config.Identity{AccessTokenTTL: time.Minute, RefreshTokenTTL: time.Hour}}
+	issuer := tokens.NewIssuer(tokens.NewHMACKey("secret"), time.Minute)
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			repo := mock_userservice.NewMockUserRepo(c)
+			tx := mock_postgres.NewMockIRepository(c)
+			tc.mockBehavior(repo, tx)
+
+			// The token is only looked up and rotated inside the transaction.
+			repo.EXPECT().WithTx(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(ctx context.Context, txFunc func(repo postgres.IRepository) error) error {
+					return txFunc(tx)
+				})
+
+			pair, err := New(repo, discard, cfg, issuer, nil, nil, domains.DefaultRolePermissions).
+				RefreshTokens(context.Background(), "refresh")
+
+			if !errors.Is(err, tc.err) {
+				t.Errorf("expected: %v\ngot: %v", tc.err, err)
+			}
+
+			if tc.issued != (pair != nil) {
+				t.Errorf("expected issued: %t\ngot: %#v", tc.issued, pair)
+			}
+			if pair != nil && (pair.AccessToken == "" || pair.RefreshToken == "" || pair.RefreshToken == "refresh") {
+				t.Errorf("expected: a new token pair\ngot: %#v", pair)
+			}
+		})
+	}
+}
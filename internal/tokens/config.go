@@ -0,0 +1,57 @@
+package tokens
+
+import (
+	"film_library/internal/config"
+	"fmt"
+)
+
+// NewIssuerFromConfig signs tokens with the identity signing key, or with the
+// HS256 server secret when no key file is configured. After a rotation the
+// previous key, and the server secret when AcceptServerSecret is set, keep
+// verifying until PreviousKeyRetiresAt.
+func NewIssuerFromConfig(cfg *config.Config) (*Issuer, error) {
+	identity := cfg.Identity
+
+	if identity.SigningKeyFile == "" {
+		if cfg.Server.Secret == "" {
+			return nil, fmt.Errorf("tokens: either a signing key file or the server secret must be set")
+		}
+		return NewIssuer(NewHMACKey(cfg.Server.Secret), identity.AccessTokenTTL), nil
+	}
+
+	key, err := LoadKey(identity.SigningKeyID, identity.SigningKeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("tokens: %w", err)
+	}
+	if !key.CanSign() {
+		return nil, fmt.Errorf("tokens: signing key %s holds no private key", identity.SigningKeyFile)
+	}
+
+	issuer := NewIssuer(key, identity.AccessTokenTTL)
+	if identity.PreviousKeyFile == "" && !identity.AcceptServerSecret {
+		return issuer, nil
+	}
+
+	// A zero time would make AcceptKey keep the key forever.
+	until := identity.PreviousKeyRetiresAt
+	if until.IsZero() {
+		return nil, fmt.Errorf("tokens: the previous key retirement time must be set")
+	}
+
+	if identity.PreviousKeyFile != "" {
+		previous, err := LoadKey(identity.PreviousKeyID, identity.PreviousKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("tokens: %w", err)
+		}
+		issuer.AcceptKey(previous, until)
+	}
+
+	if identity.AcceptServerSecret {
+		if cfg.Server.Secret == "" {
+			return nil, fmt.Errorf("tokens: the server secret must be set to accept it")
+		}
+		issuer.AcceptKey(NewHMACKey(cfg.Server.Secret), until)
+	}
+
+	return issuer, nil
+}
@@ -0,0 +1,79 @@
+package tokens
+
+import (
+	"errors"
+	"film_library/internal/config"
+	"film_library/internal/domains"
+	"testing"
+	"time"
+)
+
+func TestNewIssuerFromConfig(t *testing.T) {
+	start := time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC)
+	retiresAt := start.Add(30 * time.Minute)
+
+	old := NewIssuer(NewHMACKey("secret"), time.Hour)
+	old.now = func() time.Time { return start }
+	hmacToken, _, err := old.Issue(&domains.User{ID: 7, Login: "denis", Role: domains.RoleViewer})
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+
+	keyFile := edKeyFile(t)
+
+	tests := []struct {
+		name        string
+		identity    config.Identity
+		now         time.Time
+		expectedErr error
+		invalidCfg  bool
+	}{
+		{
+			name:        "Secret without opt-in",
+			identity:    config.Identity{SigningKeyFile: keyFile},
+			now:         start.Add(10 * time.Minute),
+			expectedErr: ErrInvalid,
+		},
+		{
+			name:     "Secret before retirement",
+			identity: config.Identity{SigningKeyFile: keyFile, AcceptServerSecret: true, PreviousKeyRetiresAt: retiresAt},
+			now:      start.Add(10 * time.Minute),
+		},
+		{
+			name:        "Secret after retirement",
+			identity:    config.Identity{SigningKeyFile: keyFile, AcceptServerSecret: true, PreviousKeyRetiresAt: retiresAt},
+			now:         start.Add(40 * time.Minute),
+			expectedErr: ErrInvalid,
+		},
+		{
+			name:       "Missing retirement time",
+			identity:   config.Identity{SigningKeyFile: keyFile, AcceptServerSecret: true},
+			invalidCfg: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.identity.AccessTokenTTL = time.Hour
+			issuer, err := NewIssuerFromConfig(&config.Config{
+				Server:   config.Server{Secret: "secret"},
+				Identity: tc.identity,
+			})
+			if tc.invalidCfg {
+				if err == nil {
+					t.Errorf("expected: error\ngot: <nil>")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("%s", err.Error())
+			}
+
+			issuer.now = func() time.Time { return tc.now }
+			_, err = issuer.Parse(hmacToken)
+			if tc.expectedErr == nil && err != nil || tc.expectedErr != nil && !errors.Is(err, tc.expectedErr) {
+				t.Errorf("expected: %v\ngot: %v", tc.expectedErr, err)
+			}
+		})
+	}
+}
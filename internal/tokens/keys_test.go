@@ -0,0 +1,245 @@
+package tokens
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"film_library/internal/domains"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func writeKey(t *testing.T, name, typ string, der []byte) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: typ, Bytes: der}), 0o600); err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+	return path
+}
+
+func rsaKeyFiles(t *testing.T) (string, string) {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+	pub, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+
+	return writeKey(t, "rsa.pem", "RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(key)),
+		writeKey(t, "rsa.pub", "PUBLIC KEY", pub)
+}
+
+func edKeyFile(t *testing.T) string {
+	t.Helper()
+
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+
+	return writeKey(t, "ed25519.pem", "PRIVATE KEY", der)
+}
+
+func TestLoadKey(t *testing.T) {
+	rsaPrivate, rsaPublic := rsaKeyFiles(t)
+	ed := edKeyFile(t)
+
+	tests := []struct {
+		name    string
+		id      string
+		path    string
+		alg     string
+		canSign bool
+		kty     string
+	}{
+		{
+			name:    "RSA private key",
+			id:      "rsa-1",
+			path:    rsaPrivate,
+			alg:     "RS256",
+			canSign: true,
+			kty:     "RSA",
+		},
+		{
+			name: "RSA public key",
+			id:   "rsa-1",
+			path: rsaPublic,
+			alg:  "RS256",
+			kty:  "RSA",
+		},
+		{
+			name:    "Ed25519 private key with thumbprint id",
+			path:    ed,
+			alg:     "EdDSA",
+			canSign: true,
+			kty:     "OKP",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			key, err := LoadKey(tc.id, tc.path)
+			if err != nil {
+				t.Fatalf("%s", err.Error())
+			}
+
+			if key.Alg() != tc.alg {
+				t.Errorf("expected: %s\ngot: %s", tc.alg, key.Alg())
+			}
+			if key.CanSign() != tc.canSign {
+				t.Errorf("expected: %t\ngot: %t", tc.canSign, key.CanSign())
+			}
+			if key.ID == "" || (tc.id != "" && key.ID != tc.id) {
+				t.Errorf("expected: %s\ngot: %s", tc.id, key.ID)
+			}
+
+			jwk, ok := key.JWK()
+			if !ok || jwk.Kty != tc.kty || jwk.Kid != key.ID {
+				t.Errorf("expected: %s key %s\ngot: %#v", tc.kty, key.ID, jwk)
+			}
+		})
+	}
+
+	if _, err := LoadKey("", filepath.Join(t.TempDir(), "missing.pem")); err == nil {
+		t.Errorf("expected error for a missing key file")
+	}
+}
+
+func TestIssuerKeyRotation(t *testing.T) {
+	rsaPrivate, rsaPublic := rsaKeyFiles(t)
+	start := time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC)
+	user := &domains.User{ID: 7, Login: "denis", Role: domains.RoleViewer}
+
+	previous, err := LoadKey("old", rsaPrivate)
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+	old := NewIssuer(previous, time.Hour)
+	old.now = func() time.Time { return start }
+
+	oldToken, _, err := old.Issue(user)
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+
+	current, err := LoadKey("new", edKeyFile(t))
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+	verifyOnly, err := LoadKey("old", rsaPublic)
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+
+	issuer := NewIssuer(current, time.Hour)
+	issuer.AcceptKey(verifyOnly, start.Add(30*time.Minute))
+	issuer.now = func() time.Time { return start.Add(10 * time.Minute) }
+
+	newToken, _, err := issuer.Issue(user)
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+
+	parsed, _, err := jwt.NewParser().ParseUnverified(newToken, &Claims{})
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+	if parsed.Header["kid"] != "new" || parsed.Method.Alg() != "EdDSA" {
+		t.Errorf("expected: kid new, alg EdDSA\ngot: %v", parsed.Header)
+	}
+
+	// HS256 signed with the public key must not pass as the RSA key.
+	confused := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"id":  7,
+		"jti": "abc",
+		"exp": start.Add(time.Hour).Unix(),
+	})
+	confused.Header["kid"] = "old"
+	pub, err := os.ReadFile(rsaPublic)
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+	confusedToken, err := confused.SignedString(pub)
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+
+	tests := []struct {
+		name  string
+		token string
+		now   time.Time
+		err   error
+	}{
+		{
+			name:  "Current key",
+			token: newToken,
+			now:   start.Add(10 * time.Minute),
+		},
+		{
+			name:  "Previous key within rotation window",
+			token: oldToken,
+			now:   start.Add(20 * time.Minute),
+		},
+		{
+			name:  "Previous key after rotation window",
+			token: oldToken,
+			now:   start.Add(40 * time.Minute),
+			err:   ErrInvalid,
+		},
+		{
+			name:  "Algorithm confusion",
+			token: confusedToken,
+			now:   start.Add(10 * time.Minute),
+			err:   ErrInvalid,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			issuer.now = func() time.Time { return tc.now }
+
+			claims, err := issuer.Parse(tc.token)
+
+			if tc.err != nil {
+				if !errors.Is(err, tc.err) {
+					t.Errorf("expected: %s\ngot: %s", tc.err, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("%s", err.Error())
+			}
+			if claims.UserID != user.ID {
+				t.Errorf("expected: %d\ngot: %d", user.ID, claims.UserID)
+			}
+		})
+	}
+
+	issuer.now = func() time.Time { return start.Add(10 * time.Minute) }
+	if keys := issuer.JWKS().Keys; len(keys) != 2 || keys[0].Kid != "new" || keys[1].Kid != "old" {
+		t.Errorf("expected: [new old]\ngot: %#v", keys)
+	}
+
+	issuer.now = func() time.Time { return start.Add(40 * time.Minute) }
+	if keys := issuer.JWKS().Keys; len(keys) != 1 || keys[0].Kid != "new" {
+		t.Errorf("expected: [new]\ngot: %#v", keys)
+	}
+}
@@ -0,0 +1,227 @@
+package tokens
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"film_library/internal/domains"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const apiKeyPrefix = "flk_"
+
+var (
+	ErrInvalid = fmt.Errorf("invalid token")
+	ErrExpired = fmt.Errorf("token expired")
+	ErrRevoked = fmt.Errorf("token revoked")
+)
+
+// Claims of an access token. The jti (RegisteredClaims.ID) identifies the
+// token for revocation, Version must match the token version of the user.
+type Claims struct {
+	UserID  uint32       `json:"id"`
+	Login   string       `json:"login"`
+	Role    domains.Role `json:"role"`
+	Version int          `json:"ver,omitempty"`
+	jwt.RegisteredClaims
+}
+
+type Issuer struct {
+	key  *Key
+	keys map[string]acceptedKey
+	ttl  time.Duration
+	now  func() time.Time
+}
+
+type acceptedKey struct {
+	key   *Key
+	until time.Time
+}
+
+// NewIssuer signs tokens with key, which must hold a private key (or be an
+// HMAC key).
+func NewIssuer(key *Key, ttl time.Duration) *Issuer {
+	return &Issuer{
+		key:  key,
+		keys: map[string]acceptedKey{key.ID: {key: key}},
+		ttl:  ttl,
+		now:  time.Now,
+	}
+}
+
+// AcceptKey makes Parse verify tokens signed by key until the given time,
+// so that tokens issued before a key rotation stay valid. It must be called
+// before the issuer is used.
+func (i *Issuer) AcceptKey(key *Key, until time.Time) {
+	if key.ID == i.key.ID {
+		return
+	}
+	i.keys[key.ID] = acceptedKey{key: key, until: until}
+}
+
+// Issue signs a short-lived access token for user.
+func (i *Issuer) Issue(user *domains.User) (string, *Claims, error) {
+	jti, err := randomString(16)
+	if err != nil {
+		return "", nil, err
+	}
+
+	now := i.now()
+	claims := &Claims{
+		UserID:  user.ID,
+		Login:   user.Login,
+		Role:    user.Role,
+		Version: user.TokenVersion,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        jti,
+			Subject:   strconv.FormatUint(uint64(user.ID), 10),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(i.ttl)),
+		},
+	}
+
+	t := jwt.NewWithClaims(i.key.method, claims)
+	if i.key.ID != "" {
+		t.Header["kid"] = i.key.ID
+	}
+
+	token, err := t.SignedString(i.key.sign)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return token, claims, nil
+}
+
+// Parse verifies the signature and the expiry of an access token. The key is
+// picked by the kid header; tokens without one are checked against the HMAC key.
+func (i *Issuer) Parse(token string) (*Claims, error) {
+	claims := &Claims{}
+	_, err := jwt.ParseWithClaims(token, claims, i.verificationKey,
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+		jwt.WithTimeFunc(i.now),
+	)
+	if err != nil {
+		if errors.Is(err, jwt.ErrTokenExpired) {
+			return nil, ErrExpired
+		}
+		return nil, fmt.Errorf("%w: %s", ErrInvalid, err.Error())
+	}
+
+	if claims.ID == "" {
+		return nil, fmt.Errorf("%w: missing jti", ErrInvalid)
+	}
+
+	return claims, nil
+}
+
+// JWKS returns the public keys tokens are currently verified with.
+func (i *Issuer) JWKS() JWKS {
+	jwks := JWKS{Keys: []JWK{}}
+	if jwk, ok := i.key.JWK(); ok {
+		jwks.Keys = append(jwks.Keys, jwk)
+	}
+
+	ids := make([]string, 0, len(i.keys))
+	for id := range i.keys {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	for _, id := range ids {
+		accepted := i.keys[id]
+		if accepted.key == i.key || i.retired(accepted) {
+			continue
+		}
+		if jwk, ok := accepted.key.JWK(); ok {
+			jwks.Keys = append(jwks.Keys, jwk)
+		}
+	}
+
+	return jwks
+}
+
+func (i *Issuer) verificationKey(t *jwt.Token) (interface{}, error) {
+	kid, _ := t.Header["kid"].(string)
+
+	accepted, ok := i.keys[kid]
+	if !ok || i.retired(accepted) {
+		return nil, fmt.Errorf("unknown key %q", kid)
+	}
+
+	if t.Method.Alg() != accepted.key.Alg() {
+		return nil, fmt.Errorf("unexpected signing method %s", t.Method.Alg())
+	}
+
+	return accepted.key.verify, nil
+}
+
+func (i *Issuer) retired(accepted acceptedKey) bool {
+	return !accepted.until.IsZero() && i.now().After(accepted.until)
+}
+
+// NewRefreshToken returns an opaque refresh token and the hash it is stored by.
+func NewRefreshToken() (string, string, error) {
+	token, err := randomString(32)
+	if err != nil {
+		return "", "", err
+	}
+	return token, HashRefreshToken(token), nil
+}
+
+func HashRefreshToken(token string) string {
+	return hash(token)
+}
+
+// NewResetToken returns a one-time password reset token and the hash it is
+// stored by.
+func NewResetToken() (string, string, error) {
+	token, err := randomString(32)
+	if err != nil {
+		return "", "", err
+	}
+	return token, HashResetToken(token), nil
+}
+
+func HashResetToken(token string) string {
+	return hash(token)
+}
+
+// NewAPIKey returns a random API key, its prefix that identifies the key in
+// listings, and the hash it is stored by.
+func NewAPIKey() (key, prefix, keyHash string, err error) {
+	random, err := randomString(32)
+	if err != nil {
+		return "", "", "", err
+	}
+
+	key = apiKeyPrefix + random
+	return key, key[:len(apiKeyPrefix)+6], HashAPIKey(key), nil
+}
+
+func HashAPIKey(key string) string {
+	return hash(key)
+}
+
+// hash is enough for tokens with 256 bits of entropy: unlike passwords they
+// cannot be guessed, so a slow hash would only cost time on every request.
+func hash(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func randomString(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
@@ -0,0 +1,120 @@
+package tokens
+
+import (
+	"errors"
+	"film_library/internal/domains"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func TestIssuerParse(t *testing.T) {
+	issued := time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC)
+	user := &domains.User{ID: 7, Login: "denis", Role: domains.RoleViewer}
+
+	issuer := NewIssuer(NewHMACKey("secret"), 15*time.Minute)
+	issuer.now = func() time.Time { return issued }
+
+	token, _, err := issuer.Issue(user)
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+
+	unsigned, err := jwt.NewWithClaims(jwt.SigningMethodNone, jwt.MapClaims{
+		"id":  7,
+		"jti": "abc",
+		"exp": issued.Add(time.Hour).Unix(),
+	}).SignedString(jwt.UnsafeAllowNoneSignatureType)
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+
+	noExpiry, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"id":  7,
+		"jti": "abc",
+	}).SignedString([]byte("secret"))
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+
+	tests := []struct {
+		name   string
+		token  string
+		secret string
+		now    time.Time
+		err    error
+	}{
+		{
+			name:   "Correct",
+			token:  token,
+			secret: "secret",
+			now:    issued.Add(time.Minute),
+		},
+		{
+			name:   "Expired",
+			token:  token,
+			secret: "secret",
+			now:    issued.Add(16 * time.Minute),
+			err:    ErrExpired,
+		},
+		{
+			name:   "Wrong secret",
+			token:  token,
+			secret: "another",
+			now:    issued.Add(time.Minute),
+			err:    ErrInvalid,
+		},
+		{
+			name:   "Unsigned",
+			token:  unsigned,
+			secret: "secret",
+			now:    issued.Add(time.Minute),
+			err:    ErrInvalid,
+		},
+		{
+			name:   "No expiry",
+			token:  noExpiry,
+			secret: "secret",
+			now:    issued.Add(time.Minute),
+			err:    ErrInvalid,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			parser := NewIssuer(NewHMACKey(tc.secret), 15*time.Minute)
+			parser.now = func() time.Time { return tc.now }
+
+			claims, err := parser.Parse(tc.token)
+
+			if tc.err != nil {
+				if !errors.Is(err, tc.err) {
+					t.Errorf("expected: %s\ngot: %s", tc.err, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("%s", err.Error())
+			}
+			if claims.UserID != user.ID || claims.Role != user.Role || claims.ID == "" {
+				t.Errorf("expected: %#v\ngot: %#v", user, claims)
+			}
+		})
+	}
+}
+
+func TestNewRefreshToken(t *testing.T) {
+	token, hash, err := NewRefreshToken()
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+
+	if hash != HashRefreshToken(token) {
+		t.Errorf("expected: %s\ngot: %s", HashRefreshToken(token), hash)
+	}
+	if hash == token {
+		t.Errorf("refresh token must not be stored as is")
+	}
+}
@@ -0,0 +1,9 @@
+ALTER TABLE users
+	DROP COLUMN last_login_at,
+	DROP COLUMN created_at,
+	DROP COLUMN display_name,
+	DROP COLUMN disabled;
+
+UPDATE users SET role='viewer' WHERE role='editor';
+ALTER TABLE users DROP CONSTRAINT users_role_check;
+ALTER TABLE users ADD CONSTRAINT users_role_check CHECK(role IN ('viewer', 'admin'));
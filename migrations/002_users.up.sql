@@ -0,0 +1,8 @@
+ALTER TABLE users DROP CONSTRAINT users_role_check;
+ALTER TABLE users ADD CONSTRAINT users_role_check CHECK(role IN ('viewer', 'editor', 'admin'));
+
+ALTER TABLE users
+	ADD COLUMN disabled BOOLEAN DEFAULT FALSE NOT NULL,
+	ADD COLUMN display_name VARCHAR(100) DEFAULT '' NOT NULL,
+	ADD COLUMN created_at TIMESTAMPTZ DEFAULT now() NOT NULL,
+	ADD COLUMN last_login_at TIMESTAMPTZ;
@@ -0,0 +1,11 @@
+CREATE TABLE api_keys(
+	id SERIAL PRIMARY KEY,
+	name VARCHAR(100) CHECK(length(name)>0) NOT NULL,
+	prefix VARCHAR(16) NOT NULL,
+	key_hash VARCHAR(64) UNIQUE NOT NULL,
+	permissions VARCHAR[] NOT NULL,
+	created_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+	created_at TIMESTAMPTZ DEFAULT now() NOT NULL,
+	expires_at TIMESTAMPTZ,
+	last_used_at TIMESTAMPTZ
+);
@@ -0,0 +1,2 @@
+DROP TABLE film_genre;
+DROP TABLE genres;
@@ -0,0 +1,10 @@
+CREATE TABLE genres(
+	id SERIAL PRIMARY KEY,
+	name VARCHAR(50) CHECK(length(name)>0) UNIQUE NOT NULL
+);
+
+CREATE TABLE film_genre(
+	film_id INTEGER REFERENCES films(id) ON DELETE CASCADE NOT NULL,
+	genre_id INTEGER REFERENCES genres(id) ON DELETE CASCADE NOT NULL,
+	PRIMARY KEY(film_id, genre_id)
+);
@@ -0,0 +1,22 @@
+-- Crew credits have no place in film_actor and are lost.
+DELETE FROM credits WHERE type<>'actor';
+
+DROP INDEX credits_person_id_idx;
+
+ALTER TABLE credits DROP CONSTRAINT credits_pkey;
+ALTER TABLE credits ADD CONSTRAINT film_actor_pkey PRIMARY KEY(film_id, person_id);
+
+ALTER TABLE credits
+	DROP COLUMN billing_order,
+	DROP COLUMN character_name,
+	DROP COLUMN type;
+
+ALTER TABLE credits RENAME CONSTRAINT credits_person_id_fkey TO film_actor_actor_id_fkey;
+ALTER TABLE credits RENAME CONSTRAINT credits_film_id_fkey TO film_actor_film_id_fkey;
+ALTER TABLE credits RENAME COLUMN person_id TO actor_id;
+ALTER TABLE credits RENAME TO film_actor;
+
+ALTER TABLE persons RENAME CONSTRAINT persons_gender_check TO actors_gender_check;
+ALTER TABLE persons RENAME CONSTRAINT persons_pkey TO actors_pkey;
+ALTER SEQUENCE persons_id_seq RENAME TO actors_id_seq;
+ALTER TABLE persons RENAME TO actors;
@@ -0,0 +1,21 @@
+-- Actors become persons credited in films as actors or crew. Existing rows
+-- of film_actor are kept as actor credits.
+ALTER TABLE actors RENAME TO persons;
+ALTER SEQUENCE actors_id_seq RENAME TO persons_id_seq;
+ALTER TABLE persons RENAME CONSTRAINT actors_pkey TO persons_pkey;
+ALTER TABLE persons RENAME CONSTRAINT actors_gender_check TO persons_gender_check;
+
+ALTER TABLE film_actor RENAME TO credits;
+ALTER TABLE credits RENAME COLUMN actor_id TO person_id;
+ALTER TABLE credits RENAME CONSTRAINT film_actor_film_id_fkey TO credits_film_id_fkey;
+ALTER TABLE credits RENAME CONSTRAINT film_actor_actor_id_fkey TO credits_person_id_fkey;
+
+ALTER TABLE credits
+	ADD COLUMN type VARCHAR(20) DEFAULT 'actor' CHECK(type IN ('actor', 'director', 'writer', 'producer', 'composer')) NOT NULL,
+	ADD COLUMN character_name VARCHAR(150),
+	ADD COLUMN billing_order SMALLINT DEFAULT 0 NOT NULL;
+
+ALTER TABLE credits DROP CONSTRAINT film_actor_pkey;
+ALTER TABLE credits ADD CONSTRAINT credits_pkey PRIMARY KEY(film_id, person_id, type);
+
+CREATE INDEX credits_person_id_idx ON credits(person_id);
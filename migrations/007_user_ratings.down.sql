@@ -0,0 +1,8 @@
+DROP TABLE user_ratings;
+
+DROP INDEX films_rating_score_idx;
+
+ALTER TABLE films
+	DROP COLUMN rating_score,
+	DROP COLUMN rating_sum,
+	DROP COLUMN rating_count;
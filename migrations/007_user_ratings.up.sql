@@ -0,0 +1,17 @@
+ALTER TABLE films
+	ADD COLUMN rating_count INTEGER DEFAULT 0 NOT NULL,
+	ADD COLUMN rating_sum INTEGER DEFAULT 0 NOT NULL,
+	ADD COLUMN rating_score DOUBLE PRECISION DEFAULT 0 NOT NULL;
+
+CREATE INDEX films_rating_score_idx ON films(rating_score);
+
+CREATE TABLE user_ratings(
+	user_id INTEGER REFERENCES users(id) ON DELETE CASCADE NOT NULL,
+	film_id INTEGER REFERENCES films(id) ON DELETE CASCADE NOT NULL,
+	-- Mirrored by config.MinUserRating and config.MaxUserRating.
+	rating SMALLINT CHECK(rating BETWEEN 1 AND 10) NOT NULL,
+	updated_at TIMESTAMPTZ DEFAULT now() NOT NULL,
+	PRIMARY KEY(user_id, film_id)
+);
+
+CREATE INDEX user_ratings_film_id_idx ON user_ratings(film_id);
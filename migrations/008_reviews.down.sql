@@ -0,0 +1,3 @@
+DROP TABLE review_reports;
+DROP TABLE review_votes;
+DROP TABLE reviews;
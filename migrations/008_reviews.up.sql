@@ -0,0 +1,30 @@
+CREATE TABLE reviews(
+	id SERIAL PRIMARY KEY,
+	film_id INTEGER REFERENCES films(id) ON DELETE CASCADE NOT NULL,
+	user_id INTEGER REFERENCES users(id) ON DELETE CASCADE NOT NULL,
+	text VARCHAR(5000) CHECK(length(text)>0) NOT NULL,
+	hidden BOOLEAN DEFAULT FALSE NOT NULL,
+	created_at TIMESTAMPTZ DEFAULT now() NOT NULL,
+	updated_at TIMESTAMPTZ,
+	moderated_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+	moderated_at TIMESTAMPTZ,
+	UNIQUE(film_id, user_id)
+);
+
+CREATE TABLE review_votes(
+	review_id INTEGER REFERENCES reviews(id) ON DELETE CASCADE NOT NULL,
+	user_id INTEGER REFERENCES users(id) ON DELETE CASCADE NOT NULL,
+	helpful BOOLEAN NOT NULL,
+	PRIMARY KEY(review_id, user_id)
+);
+
+CREATE TABLE review_reports(
+	review_id INTEGER REFERENCES reviews(id) ON DELETE CASCADE NOT NULL,
+	user_id INTEGER REFERENCES users(id) ON DELETE CASCADE NOT NULL,
+	reason VARCHAR(500) DEFAULT '' NOT NULL,
+	created_at TIMESTAMPTZ DEFAULT now() NOT NULL,
+	resolved_at TIMESTAMPTZ,
+	PRIMARY KEY(review_id, user_id)
+);
+
+CREATE INDEX review_reports_open_idx ON review_reports(review_id) WHERE resolved_at IS NULL;
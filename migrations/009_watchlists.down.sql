@@ -0,0 +1,2 @@
+DROP TABLE watched_films;
+DROP TABLE watchlist;
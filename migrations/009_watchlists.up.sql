@@ -0,0 +1,14 @@
+CREATE TABLE watchlist(
+	user_id INTEGER REFERENCES users(id) ON DELETE CASCADE NOT NULL,
+	film_id INTEGER REFERENCES films(id) ON DELETE CASCADE NOT NULL,
+	added_at TIMESTAMPTZ DEFAULT now() NOT NULL,
+	PRIMARY KEY(user_id, film_id)
+);
+
+CREATE TABLE watched_films(
+	user_id INTEGER REFERENCES users(id) ON DELETE CASCADE NOT NULL,
+	film_id INTEGER REFERENCES films(id) ON DELETE CASCADE NOT NULL,
+	watched_at DATE NOT NULL,
+	rewatch_count INTEGER DEFAULT 0 CHECK(rewatch_count>=0) NOT NULL,
+	PRIMARY KEY(user_id, film_id)
+);
@@ -0,0 +1,4 @@
+ALTER TABLE api_keys DROP CONSTRAINT api_keys_created_by_fkey;
+ALTER TABLE api_keys ADD CONSTRAINT api_keys_created_by_fkey
+	FOREIGN KEY(created_by) REFERENCES users(id) ON DELETE SET NULL;
+ALTER TABLE api_keys ALTER COLUMN created_by DROP NOT NULL;
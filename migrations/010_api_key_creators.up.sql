@@ -0,0 +1,8 @@
+-- Keys act with the permissions of their creator, so keys without one are
+-- dropped and keys are deleted with their creator from now on.
+DELETE FROM api_keys WHERE created_by IS NULL;
+
+ALTER TABLE api_keys ALTER COLUMN created_by SET NOT NULL;
+ALTER TABLE api_keys DROP CONSTRAINT api_keys_created_by_fkey;
+ALTER TABLE api_keys ADD CONSTRAINT api_keys_created_by_fkey
+	FOREIGN KEY(created_by) REFERENCES users(id) ON DELETE CASCADE;
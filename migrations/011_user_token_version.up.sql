@@ -0,0 +1,2 @@
+-- Access tokens carry the token version of their user; bumping it revokes them.
+ALTER TABLE users ADD COLUMN token_version INTEGER DEFAULT 0 NOT NULL;
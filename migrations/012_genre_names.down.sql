@@ -0,0 +1,2 @@
+DROP INDEX genres_name_lower_key;
+ALTER TABLE genres ADD CONSTRAINT genres_name_key UNIQUE(name);
@@ -0,0 +1,2 @@
+ALTER TABLE genres DROP CONSTRAINT genres_name_key;
+CREATE UNIQUE INDEX genres_name_lower_key ON genres(LOWER(name));
@@ -0,0 +1 @@
+ALTER TABLE refresh_tokens ADD COLUMN revoked_reason VARCHAR(20);
@@ -0,0 +1,67 @@
+package metrics
+
+import (
+	"database/sql"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type dbStat struct {
+	desc      *prometheus.Desc
+	valueType prometheus.ValueType
+	value     func(s sql.DBStats) float64
+}
+
+type DBStatsCollector struct {
+	stats   func() sql.DBStats
+	metrics []dbStat
+}
+
+// NewDBStatsCollector exports the connection pool statistics of sql.DB.
+func NewDBStatsCollector(stats func() sql.DBStats) *DBStatsCollector {
+	stat := func(name, help string, valueType prometheus.ValueType, value func(s sql.DBStats) float64) dbStat {
+		return dbStat{
+			desc:      prometheus.NewDesc(name, help, nil, nil),
+			valueType: valueType,
+			value:     value,
+		}
+	}
+
+	return &DBStatsCollector{
+		stats: stats,
+		metrics: []dbStat{
+			stat("db_pool_max_open_connections", "Maximum number of open connections to the database.", prometheus.GaugeValue,
+				func(s sql.DBStats) float64 { return float64(s.MaxOpenConnections) }),
+			stat("db_pool_open_connections", "The number of established connections both in use and idle.", prometheus.GaugeValue,
+				func(s sql.DBStats) float64 { return float64(s.OpenConnections) }),
+			stat("db_pool_in_use_connections", "The number of connections currently in use.", prometheus.GaugeValue,
+				func(s sql.DBStats) float64 { return float64(s.InUse) }),
+			stat("db_pool_idle_connections", "The number of idle connections.", prometheus.GaugeValue,
+				func(s sql.DBStats) float64 { return float64(s.Idle) }),
+			stat("db_pool_wait_count_total", "The total number of connections waited for.", prometheus.CounterValue,
+				func(s sql.DBStats) float64 { return float64(s.WaitCount) }),
+			stat("db_pool_wait_duration_seconds_total", "The total time blocked waiting for a new connection.", prometheus.CounterValue,
+				func(s sql.DBStats) float64 { return s.WaitDuration.Seconds() }),
+			stat("db_pool_max_idle_closed_total", "The total number of connections closed due to SetMaxIdleConns.", prometheus.CounterValue,
+				func(s sql.DBStats) float64 { return float64(s.MaxIdleClosed) }),
+			stat("db_pool_max_idle_time_closed_total", "The total number of connections closed due to SetConnMaxIdleTime.", prometheus.CounterValue,
+				func(s sql.DBStats) float64 { return float64(s.MaxIdleTimeClosed) }),
+			stat("db_pool_max_lifetime_closed_total", "The total number of connections closed due to SetConnMaxLifetime.", prometheus.CounterValue,
+				func(s sql.DBStats) float64 { return float64(s.MaxLifetimeClosed) }),
+		},
+	}
+}
+
+func (c *DBStatsCollector) Describe(ch chan<- *prometheus.Desc) {
+	for _, m := range c.metrics {
+		ch <- m.desc
+	}
+}
+
+func (c *DBStatsCollector) Collect(ch chan<- prometheus.Metric) {
+	s := c.stats()
+
+	for _, m := range c.metrics {
+		ch <- prometheus.MustNewConstMetric(m.desc, m.valueType, m.value(s))
+	}
+}
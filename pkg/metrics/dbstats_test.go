@@ -0,0 +1,46 @@
+package metrics
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestDBStatsCollector(t *testing.T) {
+	registry := prometheus.NewPedanticRegistry()
+	registry.MustRegister(NewDBStatsCollector(func() sql.DBStats {
+		return sql.DBStats{MaxOpenConnections: 10, InUse: 3, WaitDuration: 1500 * time.Millisecond}
+	}))
+
+	families, err := registry.Gather()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	values := map[string]float64{}
+	for _, family := range families {
+		m := family.GetMetric()[0]
+		if m.GetGauge() != nil {
+			values[family.GetName()] = m.GetGauge().GetValue()
+		} else {
+			values[family.GetName()] = m.GetCounter().GetValue()
+		}
+	}
+
+	expected := map[string]float64{
+		"db_pool_max_open_connections":        10,
+		"db_pool_in_use_connections":          3,
+		"db_pool_wait_duration_seconds_total": 1.5,
+		"db_pool_wait_count_total":            0,
+	}
+	if len(values) != 9 {
+		t.Errorf("expected: 9 metrics\ngot: %v", values)
+	}
+	for name, value := range expected {
+		if values[name] != value {
+			t.Errorf("%s expected: %v\ngot: %v", name, value, values[name])
+		}
+	}
+}
@@ -2,64 +2,151 @@ package auth
 
 import (
 	"context"
-	"encoding/json"
-	"film_library/internal/config"
+	"errors"
 	"film_library/internal/domains"
 	"film_library/internal/handlers/response"
-	"fmt"
+	"film_library/internal/logger"
+	"film_library/internal/repositories/postgres/userrepo"
+	"film_library/internal/services/userservice"
+	"film_library/internal/tokens"
 	"log/slog"
 	"net/http"
+	"slices"
 	"strings"
-
-	"github.com/golang-jwt/jwt/v5"
 )
 
+// APIKeyHeader is an alternative to "Authorization: ApiKey <key>".
+const APIKeyHeader = "X-API-Key"
+
+const apiKeyScheme = "apikey"
+
 type UserKey string
 
-func New(log *slog.Logger, cfg *config.Config) func(http.Handler) http.Handler {
+type TokenKey string
+
+type Service interface {
+	GetUserByID(ctx context.Context, id uint32) (*domains.User, error)
+	IsTokenRevoked(ctx context.Context, jti string) (bool, error)
+	AuthenticateAPIKey(ctx context.Context, key string) (*domains.APIKey, error)
+}
+
+type Roles interface {
+	Of(role domains.Role) []domains.Permission
+}
+
+// New authenticates requests by access token or API key and stores the
+// domains.Principal under UserKey("user"). Users are loaded on every request,
+// so disabled accounts and role changes take effect immediately.
+func New(log *slog.Logger, issuer *tokens.Issuer, service Service, roles Roles) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			authParts := strings.Split(r.Header.Get("Authorization"), " ")
-			if len(authParts) < 2 {
-				response.JSONError(w, http.StatusUnauthorized, "unauthorized", log)
-				return
-			}
-
-			inToken := authParts[1]
-			token, err := jwt.Parse(inToken, func(t *jwt.Token) (interface{}, error) {
-				if method, ok := t.Method.(*jwt.SigningMethodHMAC); !ok || method.Alg() != "HS256" {
-					return nil, fmt.Errorf("bad sign method")
-				}
-				return []byte(cfg.Server.Secret), nil
-			})
-			if err != nil || !token.Valid {
-				response.JSONError(w, http.StatusUnauthorized, "bad token", log)
-				return
-			}
+			var (
+				principal *domains.Principal
+				claims    *tokens.Claims
+				err       error
+			)
 
-			payload, ok := token.Claims.(jwt.MapClaims)
-			if !ok {
-				response.JSONError(w, http.StatusUnauthorized, "no payload", log)
-				return
+			scheme, credentials, _ := strings.Cut(r.Header.Get("Authorization"), " ")
+			switch {
+			case r.Header.Get(APIKeyHeader) != "":
+				principal, err = apiKeyPrincipal(r, service, roles, r.Header.Get(APIKeyHeader))
+			case strings.EqualFold(scheme, apiKeyScheme):
+				principal, err = apiKeyPrincipal(r, service, roles, credentials)
+			case credentials != "":
+				principal, claims, err = tokenPrincipal(r, issuer, service, roles, credentials)
+			default:
+				err = response.ErrUnauthorized
 			}
-
-			userStr, err := json.Marshal(payload)
 			if err != nil {
-				response.JSONError(w, http.StatusInternalServerError, "unknown error", log)
+				response.Error(w, r, err, log)
 				return
 			}
 
-			var userStruct domains.User
-			err = json.Unmarshal(userStr, &userStruct)
-			if err != nil {
-				response.JSONError(w, http.StatusInternalServerError, "unknown error", log)
-				return
+			ctx := context.WithValue(r.Context(), UserKey("user"), *principal)
+			if claims != nil {
+				ctx = context.WithValue(ctx, TokenKey("claims"), claims)
 			}
-
-			ctx := context.WithValue(r.Context(), UserKey("user"), userStruct)
 			r = r.WithContext(ctx)
 
 			next.ServeHTTP(w, r)
 		})
 	}
 }
+
+// CurrentUser returns the authenticated user. API keys act for no user and
+// get response.ErrForbidden.
+func CurrentUser(r *http.Request) (domains.Principal, error) {
+	principal, ok := r.Context().Value(UserKey("user")).(domains.Principal)
+	if !ok {
+		return domains.Principal{}, response.ErrUnauthorized
+	}
+	if principal.UserID == 0 {
+		return domains.Principal{}, response.ErrForbidden
+	}
+	return principal, nil
+}
+
+func tokenPrincipal(r *http.Request, issuer *tokens.Issuer, service Service, roles Roles, token string) (*domains.Principal, *tokens.Claims, error) {
+	claims, err := issuer.Parse(token)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	logger.AddAttrs(r.Context(), slog.Uint64("user_id", uint64(claims.UserID)))
+
+	revoked, err := service.IsTokenRevoked(r.Context(), claims.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if revoked {
+		return nil, nil, tokens.ErrRevoked
+	}
+
+	user, err := service.GetUserByID(r.Context(), claims.UserID)
+	if err != nil {
+		if errors.Is(err, userrepo.ErrNotFound) {
+			err = tokens.ErrInvalid
+		}
+		return nil, nil, err
+	}
+
+	if user.Disabled {
+		return nil, nil, userservice.ErrUserDisabled
+	}
+
+	// Password changes bump the version to end all sessions of the user.
+	if claims.Version != user.TokenVersion {
+		return nil, nil, tokens.ErrRevoked
+	}
+
+	return &domains.Principal{
+		UserID:      user.ID,
+		Login:       user.Login,
+		Role:        user.Role,
+		Permissions: roles.Of(user.Role),
+	}, claims, nil
+}
+
+// apiKeyPrincipal grants the permissions of the key that the role of its
+// creator still grants, so keys lose what their creator loses.
+func apiKeyPrincipal(r *http.Request, service Service, roles Roles, plain string) (*domains.Principal, error) {
+	key, err := service.AuthenticateAPIKey(r.Context(), plain)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.AddAttrs(r.Context(), slog.Uint64("api_key_id", uint64(key.ID)))
+
+	held := roles.Of(key.CreatorRole)
+	permissions := make([]domains.Permission, 0, len(key.Permissions))
+	for _, p := range key.Permissions {
+		if slices.Contains(held, p) {
+			permissions = append(permissions, p)
+		}
+	}
+
+	return &domains.Principal{
+		APIKeyID:    key.ID,
+		Permissions: permissions,
+	}, nil
+}
@@ -0,0 +1,183 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"film_library/internal/domains"
+	"film_library/internal/repositories/postgres/userrepo"
+	"film_library/internal/services/apikeyservice"
+	"film_library/internal/tokens"
+	"film_library/pkg/mux"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+type fakeService struct {
+	users   map[uint32]*domains.User
+	revoked map[string]bool
+	keys    map[string]*domains.APIKey
+}
+
+func (s *fakeService) GetUserByID(ctx context.Context, id uint32) (*domains.User, error) {
+	user, ok := s.users[id]
+	if !ok {
+		return nil, fmt.Errorf("get user: %w", userrepo.ErrNotFound)
+	}
+	copied := *user
+	return &copied, nil
+}
+
+func (s *fakeService) IsTokenRevoked(ctx context.Context, jti string) (bool, error) {
+	return s.revoked[jti], nil
+}
+
+func (s *fakeService) AuthenticateAPIKey(ctx context.Context, key string) (*domains.APIKey, error) {
+	k, ok := s.keys[key]
+	if !ok {
+		return nil, fmt.Errorf("authenticate: %w", apikeyservice.ErrInvalidAPIKey)
+	}
+	return k, nil
+}
+
+func TestAuth(t *testing.T) {
+	issuer := tokens.NewIssuer(tokens.NewHMACKey("secret"), time.Minute)
+
+	editor := &domains.User{ID: 1, Login: "editor", Role: domains.RoleEditor}
+	disabled := &domains.User{ID: 2, Login: "disabled", Role: domains.RoleAdmin, Disabled: true}
+	deleted := &domains.User{ID: 3, Login: "deleted", Role: domains.RoleViewer}
+	rotated := &domains.User{ID: 4, Login: "rotated", Role: domains.RoleViewer}
+
+	editorToken, _, err := issuer.Issue(editor)
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+	revokedToken, revokedClaims, err := issuer.Issue(editor)
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+	disabledToken, _, err := issuer.Issue(disabled)
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+	deletedToken, _, err := issuer.Issue(deleted)
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+	// The password of rotated changes after the token is issued.
+	rotatedToken, _, err := issuer.Issue(rotated)
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+	rotated.TokenVersion++
+
+	service := &fakeService{
+		users:   map[uint32]*domains.User{editor.ID: editor, disabled.ID: disabled, rotated.ID: rotated},
+		revoked: map[string]bool{revokedClaims.ID: true},
+		keys: map[string]*domains.APIKey{
+			"flk_batch": {ID: 5, Name: "batch", Permissions: []domains.Permission{domains.PermFilmWrite}, CreatorRole: domains.RoleAdmin},
+			"flk_demoted": {ID: 6, Name: "users", CreatorRole: domains.RoleEditor,
+				Permissions: []domains.Permission{domains.PermFilmWrite, domains.PermUserManage}},
+		},
+	}
+
+	tests := []struct {
+		name               string
+		headers            map[string]string
+		expectedStatusCode int
+		expectedPrincipal  domains.Principal
+	}{
+		{
+			name:               "Access token",
+			headers:            map[string]string{"Authorization": "Bearer " + editorToken},
+			expectedStatusCode: http.StatusOK,
+			expectedPrincipal: domains.Principal{
+				UserID:      editor.ID,
+				Login:       editor.Login,
+				Role:        editor.Role,
+				Permissions: []domains.Permission{domains.PermActorWrite, domains.PermFilmWrite, domains.PermReviewWrite},
+			},
+		},
+		{
+			name:               "API key in authorization header",
+			headers:            map[string]string{"Authorization": "ApiKey flk_batch"},
+			expectedStatusCode: http.StatusOK,
+			expectedPrincipal:  domains.Principal{APIKeyID: 5, Permissions: []domains.Permission{domains.PermFilmWrite}},
+		},
+		{
+			name:               "API key header",
+			headers:            map[string]string{APIKeyHeader: "flk_batch"},
+			expectedStatusCode: http.StatusOK,
+			expectedPrincipal:  domains.Principal{APIKeyID: 5, Permissions: []domains.Permission{domains.PermFilmWrite}},
+		},
+		{
+			name:               "API key of a demoted creator",
+			headers:            map[string]string{APIKeyHeader: "flk_demoted"},
+			expectedStatusCode: http.StatusOK,
+			expectedPrincipal:  domains.Principal{APIKeyID: 6, Permissions: []domains.Permission{domains.PermFilmWrite}},
+		},
+		{
+			name:               "Unknown API key",
+			headers:            map[string]string{APIKeyHeader: "flk_unknown"},
+			expectedStatusCode: http.StatusUnauthorized,
+		},
+		{
+			name:               "No credentials",
+			expectedStatusCode: http.StatusUnauthorized,
+		},
+		{
+			name:               "Revoked token",
+			headers:            map[string]string{"Authorization": "Bearer " + revokedToken},
+			expectedStatusCode: http.StatusUnauthorized,
+		},
+		{
+			name:               "Outdated token version",
+			headers:            map[string]string{"Authorization": "Bearer " + rotatedToken},
+			expectedStatusCode: http.StatusUnauthorized,
+		},
+		{
+			name:               "Disabled user",
+			headers:            map[string]string{"Authorization": "Bearer " + disabledToken},
+			expectedStatusCode: http.StatusForbidden,
+		},
+		{
+			name:               "Deleted user",
+			headers:            map[string]string{"Authorization": "Bearer " + deletedToken},
+			expectedStatusCode: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var principal domains.Principal
+
+			r := mux.New()
+			r.Use(New(slog.New(slog.NewTextHandler(io.Discard, nil)), issuer, service, domains.DefaultRolePermissions))
+			r.HandleFunc("GET /api/films", func(w http.ResponseWriter, r *http.Request) {
+				principal, _ = r.Context().Value(UserKey("user")).(domains.Principal)
+			})
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/api/films", nil)
+			for k, v := range tc.headers {
+				req.Header.Set(k, v)
+			}
+
+			r.ServeHTTP(w, req)
+
+			if tc.expectedStatusCode != w.Code {
+				t.Errorf("expected: %d\ngot: %d", tc.expectedStatusCode, w.Code)
+			}
+
+			expected, _ := json.Marshal(tc.expectedPrincipal)
+			got, _ := json.Marshal(principal)
+			if tc.expectedStatusCode == http.StatusOK && string(expected) != string(got) {
+				t.Errorf("expected: %s\ngot: %s", expected, got)
+			}
+		})
+	}
+}
@@ -1,6 +1,8 @@
 package loggermw
 
 import (
+	"film_library/internal/logger"
+	"film_library/pkg/mux"
 	"fmt"
 	"log/slog"
 	"net/http"
@@ -12,14 +14,17 @@ func New(log *slog.Logger) func(http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			client := r.RemoteAddr
 			start := time.Now()
+			rw := mux.WrapResponseWriter(w)
 
-			next.ServeHTTP(w, r)
+			next.ServeHTTP(rw, r)
 
 			since := time.Since(start)
 
-			log.Info(
+			logger.FromContext(r.Context(), log).Info(
 				fmt.Sprintf("%s %s", r.Method, r.URL.Path),
 				slog.String("client", client),
+				slog.Int("status", rw.Status()),
+				slog.Int("size", rw.Size()),
 				slog.String("latency", since.String()),
 			)
 		})
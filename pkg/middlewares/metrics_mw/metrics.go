@@ -0,0 +1,36 @@
+package metricsmw
+
+import (
+	"film_library/pkg/mux"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func New(registry prometheus.Registerer) func(http.Handler) http.Handler {
+	requests := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "http_requests_total",
+		Help: "Total number of HTTP requests by route and status code.",
+	}, []string{"route", "code"})
+	latency := prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "http_request_duration_seconds",
+		Help:    "HTTP request latency by route and status code.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"route", "code"})
+	registry.MustRegister(requests, latency)
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			rw := mux.WrapResponseWriter(w)
+
+			next.ServeHTTP(rw, r)
+
+			route, code := mux.Pattern(r), strconv.Itoa(rw.Status())
+			requests.WithLabelValues(route, code).Inc()
+			latency.WithLabelValues(route, code).Observe(time.Since(start).Seconds())
+		})
+	}
+}
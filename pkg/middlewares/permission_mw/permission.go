@@ -0,0 +1,27 @@
+package permissionmw
+
+import (
+	"film_library/internal/domains"
+	"film_library/internal/handlers/response"
+	"film_library/pkg/middlewares/auth"
+	"log/slog"
+	"net/http"
+)
+
+// New returns RequirePermission: it builds middlewares that let a request
+// through only if the authenticated principal has the permission, granted by
+// the user's role or by the API key. It must be used after the auth middleware.
+func New(log *slog.Logger) func(p domains.Permission) func(http.Handler) http.Handler {
+	return func(p domains.Permission) func(http.Handler) http.Handler {
+		return func(next http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				principal, ok := r.Context().Value(auth.UserKey("user")).(domains.Principal)
+				if !ok || !principal.Can(p) {
+					response.Error(w, r, response.ErrForbidden, log)
+					return
+				}
+				next.ServeHTTP(w, r)
+			})
+		}
+	}
+}
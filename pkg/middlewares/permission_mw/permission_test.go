@@ -0,0 +1,93 @@
+package permissionmw
+
+import (
+	"context"
+	"film_library/internal/domains"
+	"film_library/pkg/middlewares/auth"
+	"film_library/pkg/mux"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRequirePermission(t *testing.T) {
+	tests := []struct {
+		name               string
+		principal          *domains.Principal
+		permission         domains.Permission
+		expectedStatusCode int
+	}{
+		{
+			name:               "Admin deletes film",
+			principal:          &domains.Principal{UserID: 1, Role: domains.RoleAdmin, Permissions: domains.DefaultRolePermissions.Of(domains.RoleAdmin)},
+			permission:         domains.PermFilmDelete,
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "Editor writes film",
+			principal:          &domains.Principal{UserID: 2, Role: domains.RoleEditor, Permissions: domains.DefaultRolePermissions.Of(domains.RoleEditor)},
+			permission:         domains.PermFilmWrite,
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "Editor deletes film",
+			principal:          &domains.Principal{UserID: 2, Role: domains.RoleEditor, Permissions: domains.DefaultRolePermissions.Of(domains.RoleEditor)},
+			permission:         domains.PermFilmDelete,
+			expectedStatusCode: http.StatusForbidden,
+		},
+		{
+			name:               "Viewer manages users",
+			principal:          &domains.Principal{UserID: 3, Role: domains.RoleViewer, Permissions: domains.DefaultRolePermissions.Of(domains.RoleViewer)},
+			permission:         domains.PermUserManage,
+			expectedStatusCode: http.StatusForbidden,
+		},
+		{
+			name:               "API key with permission",
+			principal:          &domains.Principal{APIKeyID: 1, Permissions: []domains.Permission{domains.PermFilmDelete}},
+			permission:         domains.PermFilmDelete,
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "API key without permission",
+			principal:          &domains.Principal{APIKeyID: 1, Permissions: []domains.Permission{domains.PermFilmWrite}},
+			permission:         domains.PermFilmDelete,
+			expectedStatusCode: http.StatusForbidden,
+		},
+		{
+			name:               "No principal",
+			permission:         domains.PermFilmWrite,
+			expectedStatusCode: http.StatusForbidden,
+		},
+	}
+
+	requirePermission := New(slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			r := mux.New()
+			r.Use(func(next http.Handler) http.Handler {
+				return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					if tc.principal != nil {
+						r = r.WithContext(context.WithValue(r.Context(), auth.UserKey("user"), *tc.principal))
+					}
+					next.ServeHTTP(w, r)
+				})
+			})
+			r.Use(requirePermission(tc.permission))
+			r.HandleFunc("DELETE /api/film/{id}", func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			})
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodDelete, "/api/film/1", nil)
+
+			r.ServeHTTP(w, req)
+
+			if tc.expectedStatusCode != w.Code {
+				t.Errorf("expected: %d\ngot: %d", tc.expectedStatusCode, w.Code)
+			}
+		})
+	}
+}
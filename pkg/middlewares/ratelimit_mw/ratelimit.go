@@ -0,0 +1,150 @@
+package ratelimitmw
+
+import (
+	"film_library/internal/config"
+	"film_library/internal/domains"
+	"film_library/internal/handlers/response"
+	"film_library/internal/logger"
+	"film_library/pkg/middlewares/auth"
+	"film_library/pkg/mux"
+	"fmt"
+	"log/slog"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+type limits struct {
+	anonymous *Limit
+	apiKeys   *Limit
+	roles     map[domains.Role]*Limit
+	routes    map[string]*Limit
+}
+
+// New limits requests per principal from auth.UserKey, or per client IP when
+// the route is anonymous. It must be used after the auth middleware on
+// authenticated groups. Store failures let requests through.
+func New(log *slog.Logger, store Store, cfg config.RateLimit) func(http.Handler) http.Handler {
+	l := limits{
+		anonymous: newLimit(cfg.Anonymous),
+		apiKeys:   newLimit(cfg.APIKeys),
+		roles:     make(map[domains.Role]*Limit, len(cfg.Roles)),
+		routes:    make(map[string]*Limit, len(cfg.Routes)),
+	}
+	for role, limit := range cfg.Roles {
+		l.roles[domains.Role(role)] = newLimit(limit)
+	}
+	for pattern, limit := range cfg.Routes {
+		l.routes[pattern] = newLimit(limit)
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key, limit := l.forRequest(r)
+
+			var buckets []Bucket
+			if limit != nil {
+				buckets = append(buckets, Bucket{Key: key, Limit: *limit})
+			}
+			if limit := l.routes[mux.Pattern(r)]; limit != nil {
+				buckets = append(buckets, Bucket{Key: key + " " + mux.Pattern(r), Limit: *limit})
+			}
+
+			if take(log, store, w, r, buckets) {
+				next.ServeHTTP(w, r)
+			}
+		})
+	}
+}
+
+// NewClientIP limits requests per client IP before the auth middleware, so
+// that requests with invalid credentials are limited too. Store failures let
+// requests through.
+func NewClientIP(log *slog.Logger, store Store, cfg config.Limit) func(http.Handler) http.Handler {
+	limit := newLimit(cfg)
+
+	return func(next http.Handler) http.Handler {
+		if limit == nil {
+			return next
+		}
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if take(log, store, w, r, []Bucket{{Key: "client:" + mux.ClientIP(r), Limit: *limit}}) {
+				next.ServeHTTP(w, r)
+			}
+		})
+	}
+}
+
+// take charges the buckets and reports whether the request may go on. The
+// headers describe the bucket that denied the request, or the one with the
+// fewest tokens left.
+func take(log *slog.Logger, store Store, w http.ResponseWriter, r *http.Request, buckets []Bucket) bool {
+	if len(buckets) == 0 {
+		return true
+	}
+
+	results, err := store.Take(r.Context(), buckets...)
+	if err != nil {
+		logger.FromContext(r.Context(), log).Error(fmt.Sprintf("rate limit: %s", err.Error()))
+		return true
+	}
+
+	tightest := 0
+	for i, res := range results {
+		if res.RetryAfter > results[tightest].RetryAfter ||
+			res.RetryAfter == results[tightest].RetryAfter && res.Remaining < results[tightest].Remaining {
+			tightest = i
+		}
+	}
+	res := results[tightest]
+	writeHeaders(w, buckets[tightest].Limit, res)
+
+	if !res.Allowed {
+		response.Error(w, r, &response.RetryError{Err: response.ErrTooManyRequests, Retry: res.RetryAfter}, log)
+		return false
+	}
+
+	return true
+}
+
+func (l limits) forRequest(r *http.Request) (string, *Limit) {
+	principal, ok := r.Context().Value(auth.UserKey("user")).(domains.Principal)
+	switch {
+	case !ok:
+		return "ip:" + mux.ClientIP(r), l.anonymous
+	case principal.APIKeyID != 0:
+		return fmt.Sprintf("apikey:%d", principal.APIKeyID), l.apiKeys
+	default:
+		return fmt.Sprintf("user:%d", principal.UserID), l.roles[principal.Role]
+	}
+}
+
+func newLimit(cfg config.Limit) *Limit {
+	if cfg.Requests <= 0 {
+		return nil
+	}
+
+	per := cfg.Per
+	if per <= 0 {
+		per = time.Second
+	}
+	burst := cfg.Burst
+	if burst <= 0 {
+		burst = cfg.Requests
+	}
+
+	return &Limit{
+		Rate:  float64(cfg.Requests) / per.Seconds(),
+		Burst: burst,
+	}
+}
+
+// writeHeaders follows the IETF RateLimit header fields draft.
+func writeHeaders(w http.ResponseWriter, limit Limit, res Result) {
+	w.Header().Set("RateLimit-Limit", strconv.Itoa(limit.Burst))
+	w.Header().Set("RateLimit-Remaining", strconv.Itoa(res.Remaining))
+	w.Header().Set("RateLimit-Reset", strconv.Itoa(int(math.Ceil(res.Reset.Seconds()))))
+	w.Header().Set("RateLimit-Policy", fmt.Sprintf("%d;w=%d", limit.Burst, int(math.Ceil(float64(limit.Burst)/limit.Rate))))
+}
@@ -0,0 +1,201 @@
+package ratelimitmw
+
+import (
+	"context"
+	"film_library/internal/config"
+	"film_library/internal/domains"
+	"film_library/pkg/middlewares/auth"
+	"film_library/pkg/mux"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+type request struct {
+	principal          *domains.Principal
+	remoteAddr         string
+	path               string
+	expectedStatusCode int
+	expectedRemaining  string
+}
+
+func TestRateLimit(t *testing.T) {
+	viewer := &domains.Principal{UserID: 1, Role: domains.RoleViewer}
+	admin := &domains.Principal{UserID: 2, Role: domains.RoleAdmin}
+
+	cfg := config.RateLimit{
+		Anonymous: config.Limit{Requests: 2, Per: time.Minute},
+		Roles: map[string]config.Limit{
+			"viewer": {Requests: 3, Per: time.Second},
+		},
+		Routes: map[string]config.Limit{
+			"GET /api/films": {Requests: 1, Per: time.Second},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		requests []request
+	}{
+		{
+			name: "Anonymous by ip",
+			requests: []request{
+				{remoteAddr: "192.0.2.1:1000", path: "/api/actors", expectedStatusCode: http.StatusOK, expectedRemaining: "1"},
+				{remoteAddr: "192.0.2.1:1001", path: "/api/actors", expectedStatusCode: http.StatusOK, expectedRemaining: "0"},
+				{remoteAddr: "192.0.2.1:1002", path: "/api/actors", expectedStatusCode: http.StatusTooManyRequests, expectedRemaining: "0"},
+				{remoteAddr: "192.0.2.2:1000", path: "/api/actors", expectedStatusCode: http.StatusOK, expectedRemaining: "1"},
+			},
+		},
+		{
+			name: "Role limit",
+			requests: []request{
+				{principal: viewer, path: "/api/actors", expectedStatusCode: http.StatusOK, expectedRemaining: "2"},
+				{principal: viewer, path: "/api/actors", expectedStatusCode: http.StatusOK, expectedRemaining: "1"},
+				{principal: viewer, path: "/api/actors", expectedStatusCode: http.StatusOK, expectedRemaining: "0"},
+				{principal: viewer, path: "/api/actors", expectedStatusCode: http.StatusTooManyRequests, expectedRemaining: "0"},
+			},
+		},
+		{
+			name: "Unlimited role",
+			requests: []request{
+				{principal: admin, path: "/api/actors", expectedStatusCode: http.StatusOK},
+				{principal: admin, path: "/api/actors", expectedStatusCode: http.StatusOK},
+				{principal: admin, path: "/api/actors", expectedStatusCode: http.StatusOK},
+				{principal: admin, path: "/api/actors", expectedStatusCode: http.StatusOK},
+			},
+		},
+		{
+			name: "Route limit",
+			requests: []request{
+				{principal: viewer, path: "/api/films", expectedStatusCode: http.StatusOK, expectedRemaining: "0"},
+				{principal: viewer, path: "/api/films", expectedStatusCode: http.StatusTooManyRequests, expectedRemaining: "0"},
+				// The denied request took no token from the role bucket.
+				{principal: viewer, path: "/api/actors", expectedStatusCode: http.StatusOK, expectedRemaining: "1"},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			store := NewMemoryStore()
+			now := time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC)
+			store.now = func() time.Time { return now }
+
+			r := mux.New()
+			r.Use(func(next http.Handler) http.Handler {
+				return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					if p, ok := r.Context().Value(principalKey{}).(*domains.Principal); ok {
+						r = r.WithContext(context.WithValue(r.Context(), auth.UserKey("user"), *p))
+					}
+					next.ServeHTTP(w, r)
+				})
+			})
+			r.Use(New(slog.New(slog.NewTextHandler(io.Discard, nil)), store, cfg))
+			r.HandleFunc("GET /api/films", func(w http.ResponseWriter, r *http.Request) {})
+			r.HandleFunc("GET /api/actors", func(w http.ResponseWriter, r *http.Request) {})
+
+			for i, req := range tc.requests {
+				w := httptest.NewRecorder()
+				httpReq := httptest.NewRequest(http.MethodGet, req.path, nil)
+				if req.remoteAddr != "" {
+					httpReq.RemoteAddr = req.remoteAddr
+				}
+				if req.principal != nil {
+					httpReq = httpReq.WithContext(context.WithValue(httpReq.Context(), principalKey{}, req.principal))
+				}
+
+				r.ServeHTTP(w, httpReq)
+
+				if req.expectedStatusCode != w.Code {
+					t.Errorf("request %d expected: %d\ngot: %d", i, req.expectedStatusCode, w.Code)
+				}
+				if got := w.Header().Get("RateLimit-Remaining"); req.expectedRemaining != got {
+					t.Errorf("request %d expected: %s\ngot: %s", i, req.expectedRemaining, got)
+				}
+				if w.Code == http.StatusTooManyRequests && w.Header().Get("Retry-After") == "" {
+					t.Errorf("request %d expected Retry-After", i)
+				}
+			}
+		})
+	}
+}
+
+type principalKey struct{}
+
+func TestRateLimitClientIP(t *testing.T) {
+	store := NewMemoryStore()
+	now := time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC)
+	store.now = func() time.Time { return now }
+
+	// The client IP is limited before the credentials are rejected.
+	limit := NewClientIP(slog.New(slog.NewTextHandler(io.Discard, nil)), store, config.Limit{Requests: 2, Per: time.Minute})
+	handler := limit(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+
+	requests := []request{
+		{remoteAddr: "192.0.2.1:1000", expectedStatusCode: http.StatusUnauthorized, expectedRemaining: "1"},
+		{remoteAddr: "192.0.2.1:1001", expectedStatusCode: http.StatusUnauthorized, expectedRemaining: "0"},
+		{remoteAddr: "192.0.2.1:1002", expectedStatusCode: http.StatusTooManyRequests, expectedRemaining: "0"},
+		{remoteAddr: "192.0.2.2:1000", expectedStatusCode: http.StatusUnauthorized, expectedRemaining: "1"},
+	}
+
+	for i, req := range requests {
+		w := httptest.NewRecorder()
+		httpReq := httptest.NewRequest(http.MethodGet, "/api/films", nil)
+		httpReq.RemoteAddr = req.remoteAddr
+
+		handler.ServeHTTP(w, httpReq)
+
+		if req.expectedStatusCode != w.Code {
+			t.Errorf("request %d expected: %d\ngot: %d", i, req.expectedStatusCode, w.Code)
+		}
+		if got := w.Header().Get("RateLimit-Remaining"); req.expectedRemaining != got {
+			t.Errorf("request %d expected: %s\ngot: %s", i, req.expectedRemaining, got)
+		}
+	}
+}
+
+func TestMemoryStoreRefill(t *testing.T) {
+	store := NewMemoryStore()
+	now := time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC)
+	store.now = func() time.Time { return now }
+	bucket := Bucket{Key: "k", Limit: Limit{Rate: 2, Burst: 2}}
+
+	for i := 0; i < 2; i++ {
+		if res, _ := store.Take(context.Background(), bucket); !res[0].Allowed {
+			t.Fatalf("request %d expected to be allowed", i)
+		}
+	}
+
+	res, _ := store.Take(context.Background(), bucket)
+	if res[0].Allowed || res[0].RetryAfter != 500*time.Millisecond {
+		t.Errorf("expected: denied, retry after 500ms\ngot: %#v", res[0])
+	}
+
+	now = now.Add(500 * time.Millisecond)
+	res, _ = store.Take(context.Background(), bucket)
+	if !res[0].Allowed || res[0].Reset != time.Second {
+		t.Errorf("expected: allowed, reset after 1s\ngot: %#v", res[0])
+	}
+}
+
+func TestMemoryStoreTakeAll(t *testing.T) {
+	store := NewMemoryStore()
+	now := time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC)
+	store.now = func() time.Time { return now }
+	wide := Bucket{Key: "wide", Limit: Limit{Rate: 1, Burst: 5}}
+	narrow := Bucket{Key: "narrow", Limit: Limit{Rate: 1, Burst: 1}}
+
+	if res, _ := store.Take(context.Background(), wide, narrow); !res[0].Allowed || !res[1].Allowed {
+		t.Fatalf("expected: allowed\ngot: %#v", res)
+	}
+
+	res, _ := store.Take(context.Background(), wide, narrow)
+	if res[0].Allowed || res[0].Remaining != 4 || res[0].RetryAfter != 0 || res[1].RetryAfter != time.Second {
+		t.Errorf("expected: denied by the narrow bucket only, 4 tokens left in the wide one\ngot: %#v", res)
+	}
+}
@@ -0,0 +1,128 @@
+package ratelimitmw
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// Limit is a token bucket: Rate tokens per second refill a bucket of Burst.
+type Limit struct {
+	Rate  float64
+	Burst int
+}
+
+type Result struct {
+	// Allowed is the same for all buckets of a Take.
+	Allowed   bool
+	Remaining int
+	// RetryAfter is the wait for the next token of an empty bucket.
+	RetryAfter time.Duration
+	// Reset is the time until the bucket is full again.
+	Reset time.Duration
+}
+
+// Bucket names the bucket of a key and its limit.
+type Bucket struct {
+	Key   string
+	Limit Limit
+}
+
+// Store keeps the buckets. It is an interface so that instances can share a
+// store, the default one lives in memory of a single instance.
+type Store interface {
+	// Take takes a token from each of the buckets, or from none of them when
+	// any is empty, and returns a result per bucket.
+	Take(ctx context.Context, buckets ...Bucket) ([]Result, error)
+}
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+	// idle is when the bucket will be full and can be forgotten.
+	idle time.Time
+}
+
+type MemoryStore struct {
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		buckets: map[string]*bucket{},
+		now:     time.Now,
+	}
+}
+
+func (s *MemoryStore) Take(ctx context.Context, buckets ...Bucket) ([]Result, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.sweep(now)
+
+	refilled := make([]*bucket, len(buckets))
+	allowed := true
+	for i, bk := range buckets {
+		refilled[i] = s.refill(bk, now)
+		allowed = allowed && refilled[i].tokens >= 1
+	}
+
+	results := make([]Result, len(buckets))
+	for i, bk := range buckets {
+		b, limit := refilled[i], bk.Limit
+
+		res := Result{Allowed: allowed}
+		switch {
+		case allowed:
+			b.tokens--
+		case b.tokens < 1:
+			res.RetryAfter = seconds((1 - b.tokens) / limit.Rate)
+		}
+
+		res.Remaining = int(b.tokens)
+		res.Reset = seconds((float64(limit.Burst) - b.tokens) / limit.Rate)
+		b.idle = now.Add(res.Reset)
+
+		results[i] = res
+	}
+
+	return results, nil
+}
+
+// refill adds the tokens earned since the bucket was last used.
+func (s *MemoryStore) refill(bk Bucket, now time.Time) *bucket {
+	burst := float64(bk.Limit.Burst)
+	b, ok := s.buckets[bk.Key]
+	if !ok {
+		b = &bucket{tokens: burst, last: now}
+		s.buckets[bk.Key] = b
+	}
+
+	b.tokens = math.Min(burst, b.tokens+now.Sub(b.last).Seconds()*bk.Limit.Rate)
+	b.last = now
+
+	return b
+}
+
+// sweep drops full buckets once a minute: they are the same as new ones.
+func (s *MemoryStore) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < time.Minute {
+		return
+	}
+	s.lastSweep = now
+
+	for key, b := range s.buckets {
+		if !now.Before(b.idle) {
+			delete(s.buckets, key)
+		}
+	}
+}
+
+func seconds(s float64) time.Duration {
+	return time.Duration(s * float64(time.Second))
+}
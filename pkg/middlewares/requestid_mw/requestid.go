@@ -0,0 +1,72 @@
+package requestidmw
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"film_library/internal/logger"
+	"film_library/pkg/mux"
+	"log/slog"
+	"net/http"
+)
+
+const (
+	Header      = "X-Request-ID"
+	maxIDLength = 128
+)
+
+type ctxKey struct{}
+
+// New accepts the request ID sent by the client or generates a new one,
+// echoes it in the response and stores a logger carrying it in the context.
+func New(log *slog.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			id := r.Header.Get(Header)
+			if !isValid(id) {
+				id = generate()
+			}
+			w.Header().Set(Header, id)
+
+			reqLog := log.With(
+				slog.String("request_id", id),
+				slog.String("route", mux.Pattern(r)),
+			)
+
+			ctx := context.WithValue(r.Context(), ctxKey{}, id)
+			ctx = logger.WithContext(ctx, reqLog)
+
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// ID returns the request ID stored in the context.
+func ID(ctx context.Context) string {
+	id, _ := ctx.Value(ctxKey{}).(string)
+	return id
+}
+
+func generate() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return ""
+	}
+	return hex.EncodeToString(b)
+}
+
+func isValid(id string) bool {
+	if len(id) == 0 || len(id) > maxIDLength {
+		return false
+	}
+
+	for _, c := range id {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9':
+		case c == '-', c == '_', c == '.', c == ':':
+		default:
+			return false
+		}
+	}
+	return true
+}
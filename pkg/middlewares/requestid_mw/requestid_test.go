@@ -0,0 +1,72 @@
+package requestidmw
+
+import (
+	"bytes"
+	"film_library/internal/logger"
+	"film_library/pkg/mux"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRequestID(t *testing.T) {
+	tests := []struct {
+		name       string
+		inID       string
+		expectedID string
+	}{
+		{
+			name:       "Client id",
+			inID:       "abc-123",
+			expectedID: "abc-123",
+		},
+		{
+			name: "Generated id",
+		},
+		{
+			name: "Invalid client id",
+			inID: "abc\n123",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			log := slog.New(slog.NewTextHandler(&buf, nil))
+
+			var ctxID string
+			r := mux.New()
+			r.Use(New(log))
+			r.HandleFunc("GET /api/films", func(w http.ResponseWriter, r *http.Request) {
+				ctxID = ID(r.Context())
+				logger.FromContext(r.Context(), log).Info("handled")
+			})
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/api/films", nil)
+			if tc.inID != "" {
+				req.Header.Set(Header, tc.inID)
+			}
+
+			r.ServeHTTP(w, req)
+
+			gotID := w.Header().Get(Header)
+			if tc.expectedID != "" && gotID != tc.expectedID {
+				t.Errorf("expected: %s\ngot: %s", tc.expectedID, gotID)
+			}
+			if len(gotID) != 32 && tc.expectedID == "" {
+				t.Errorf("expected generated id, got: %q", gotID)
+			}
+			if ctxID != gotID {
+				t.Errorf("expected: %s\ngot: %s", gotID, ctxID)
+			}
+
+			line := buf.String()
+			if !strings.Contains(line, "request_id="+gotID) || !strings.Contains(line, `route="GET /api/films"`) {
+				t.Errorf("log line is not correlated: %s", line)
+			}
+		})
+	}
+}
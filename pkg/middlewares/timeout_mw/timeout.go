@@ -0,0 +1,24 @@
+package timeoutmw
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// New sets a deadline on the request context, so queries started by the
+// request are cancelled once it expires.
+func New(timeout time.Duration) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if timeout <= 0 {
+			return next
+		}
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx, cancel := context.WithTimeout(r.Context(), timeout)
+			defer cancel()
+
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
@@ -1,9 +1,13 @@
 package mux
 
 import (
+	"context"
+	"net"
 	"net/http"
 )
 
+type patternKey struct{}
+
 type Mux struct {
 	mux         *http.ServeMux
 	middlewares []func(http.Handler) http.Handler
@@ -25,7 +29,7 @@ func (m *Mux) HandleFunc(pattern string, h http.HandlerFunc) {
 }
 
 func (m *Mux) Handle(pattern string, h http.Handler) {
-	m.mux.Handle(pattern, m.applyMiddleware(h, m.middlewares...))
+	m.mux.Handle(pattern, withPattern(pattern, m.applyMiddleware(h, m.middlewares...)))
 }
 
 func (m *Mux) applyMiddleware(h http.Handler, mws ...func(http.Handler) http.Handler) http.Handler {
@@ -46,3 +50,26 @@ func (m *Mux) Group(group func(*Mux)) {
 	newMux := &Mux{mux: m.mux, middlewares: middlewaresCopy}
 	group(newMux)
 }
+
+// Pattern returns the pattern of the route that matched the request,
+// so middlewares can label requests without the cardinality of raw paths.
+func Pattern(r *http.Request) string {
+	pattern, _ := r.Context().Value(patternKey{}).(string)
+	return pattern
+}
+
+// ClientIP returns the address of the peer that sent the request.
+// Forwarding headers are ignored, since any client can set them.
+func ClientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+func withPattern(pattern string, h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		h.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), patternKey{}, pattern)))
+	})
+}
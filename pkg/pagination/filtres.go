@@ -1,43 +1,105 @@
 package pagination
 
-import "net/http"
+import (
+	"net/http"
+	"slices"
+	"strings"
+)
 
 const (
 	QueryFilmName      = "film"
 	QueryActorName     = "actor"
+	QueryCreditType    = "credit"
 	QueryOrderByName   = "sort"
 	QueryDirectionName = "direct"
+	QueryGenreName     = "genre"
+	QueryGenreMatch    = "genreMatch"
 
 	DefaultSortBy        = "rating"
 	DefaultSortDirection = "desc"
+
+	// GenreMatchAny keeps films with at least one of the genres, GenreMatchAll
+	// films with every one of them.
+	GenreMatchAny = "any"
+	GenreMatchAll = "all"
+
+	// ReviewSortNewest orders reviews by creation time, ReviewSortHelpful by
+	// the number of helpful votes. Both put the largest values first.
+	ReviewSortNewest  = "newest"
+	ReviewSortHelpful = "helpful"
+
+	// WatchlistSortAddedAt and WatchedSortWatchedAt order the watchlist and
+	// the watched history on top of the sort keys of films.
+	WatchlistSortAddedAt = "added_at"
+	WatchedSortWatchedAt = "watched_at"
 )
 
 var (
-	fieldsForOrderFilms = map[string]struct{}{"name": struct{}{}, "rating": struct{}{}, "release_date": struct{}{}}
+	fieldsForOrderFilms = map[string]struct{}{"name": struct{}{}, "rating": struct{}{}, "release_date": struct{}{}, "score": struct{}{}}
 )
 
+// FilmFilter selects films. Genres are genre names compared ignoring case and
+// combined as set by GenreMatch. A non-zero UserID marks the films the user
+// has in the watchlist or has watched.
 type FilmFilter struct {
 	Pagination        *Pagination
 	NameContains      string
 	ActorNameContains string
+	Genres            []string
+	GenreMatch        string
 	OrderBy           string
 	Direction         string
+	UserID            uint32
 }
 
+// ActorsFilter selects persons. A non-empty CreditType keeps only credits of
+// that type.
 type ActorsFilter struct {
 	Pagination       *Pagination `json:"pagination"`
 	FullNameContains string      `json:"fullNameContains"`
+	CreditType       string      `json:"creditType"`
+}
+
+// ReviewFilter selects the visible reviews of a film.
+type ReviewFilter struct {
+	Pagination *Pagination
+	FilmID     uint32
+	SortBy     string
 }
 
-func (f *FilmFilter) Validate() {
+// Validate falls back to the default order for sort keys other than those of
+// films and sortKeys.
+func (f *FilmFilter) Validate(sortKeys ...string) {
 	f.Pagination.ValidatePagination()
-	if _, ok := fieldsForOrderFilms[f.OrderBy]; !ok {
+	if _, ok := fieldsForOrderFilms[f.OrderBy]; !ok && !slices.Contains(sortKeys, f.OrderBy) {
 		f.OrderBy = DefaultSortBy
 		f.Direction = DefaultSortDirection
 	}
 	if f.Direction != "asc" && f.Direction != "desc" {
 		f.Direction = "asc"
 	}
+	if f.GenreMatch != GenreMatchAll {
+		f.GenreMatch = GenreMatchAny
+	}
+
+	seen := make(map[string]struct{}, len(f.Genres))
+	genres := make([]string, 0, len(f.Genres))
+	for _, genre := range f.Genres {
+		genre = strings.ToLower(strings.TrimSpace(genre))
+		if _, ok := seen[genre]; ok || genre == "" {
+			continue
+		}
+		seen[genre] = struct{}{}
+		genres = append(genres, genre)
+	}
+	f.Genres = genres
+}
+
+func (f *ReviewFilter) Validate() {
+	f.Pagination.ValidatePagination()
+	if f.SortBy != ReviewSortHelpful {
+		f.SortBy = ReviewSortNewest
+	}
 }
 
 func NewFilmFilterFromRequest(r *http.Request) *FilmFilter {
@@ -45,10 +107,19 @@ func NewFilmFilterFromRequest(r *http.Request) *FilmFilter {
 	actorNameContains := r.URL.Query().Get(QueryActorName)
 	orderBy := r.URL.Query().Get(QueryOrderByName)
 	direction := r.URL.Query().Get(QueryDirectionName)
+
+	// Genres may be repeated or comma separated: genre=drama,crime&genre=war.
+	genres := []string{}
+	for _, value := range r.URL.Query()[QueryGenreName] {
+		genres = append(genres, strings.Split(value, ",")...)
+	}
+
 	return &FilmFilter{
 		Pagination:        NewFromRequest(r),
 		NameContains:      nameContains,
 		ActorNameContains: actorNameContains,
+		Genres:            genres,
+		GenreMatch:        r.URL.Query().Get(QueryGenreMatch),
 		OrderBy:           orderBy,
 		Direction:         direction,
 	}
@@ -59,5 +130,14 @@ func NewActorFilterFromRequest(r *http.Request) *ActorsFilter {
 	return &ActorsFilter{
 		Pagination:       NewFromRequest(r),
 		FullNameContains: fullNameContains,
+		CreditType:       strings.ToLower(r.URL.Query().Get(QueryCreditType)),
+	}
+}
+
+func NewReviewFilterFromRequest(r *http.Request, filmID uint32) *ReviewFilter {
+	return &ReviewFilter{
+		Pagination: NewFromRequest(r),
+		FilmID:     filmID,
+		SortBy:     strings.ToLower(r.URL.Query().Get(QueryOrderByName)),
 	}
 }
@@ -0,0 +1,63 @@
+package querier
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+// Observer receives the duration and the error of every statement together
+// with the repository method that issued it, e.g. "filmRepository.GetFilms".
+type Observer func(ctx context.Context, method string, duration time.Duration, err error)
+
+type methodKey struct{}
+
+// WithMethod labels the statements executed with ctx with the repository
+// method that issues them. Repositories call it first thing in each exported
+// method, so that unexported helpers like updateField are reported as the
+// method that called them.
+func WithMethod(ctx context.Context, method string) context.Context {
+	return context.WithValue(ctx, methodKey{}, method)
+}
+
+func methodFrom(ctx context.Context) string {
+	if method, ok := ctx.Value(methodKey{}).(string); ok {
+		return method
+	}
+	return "unknown"
+}
+
+type instrumented struct {
+	q       Querier
+	observe Observer
+}
+
+// Instrument reports the duration of the statements executed through q.
+// Query is measured until the rows are returned, not until they are read.
+func Instrument(q Querier, observe Observer) Querier {
+	if observe == nil {
+		return q
+	}
+	return &instrumented{q: q, observe: observe}
+}
+
+func (i *instrumented) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	start := time.Now()
+	res, err := i.q.ExecContext(ctx, query, args...)
+	i.observe(ctx, methodFrom(ctx), time.Since(start), err)
+	return res, err
+}
+
+func (i *instrumented) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	start := time.Now()
+	rows, err := i.q.QueryContext(ctx, query, args...)
+	i.observe(ctx, methodFrom(ctx), time.Since(start), err)
+	return rows, err
+}
+
+func (i *instrumented) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	start := time.Now()
+	row := i.q.QueryRowContext(ctx, query, args...)
+	i.observe(ctx, methodFrom(ctx), time.Since(start), row.Err())
+	return row
+}
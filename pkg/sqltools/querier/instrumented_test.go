@@ -0,0 +1,46 @@
+package querier
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestInstrumentMethod(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+	defer db.Close()
+
+	var methods []string
+	q := Instrument(db, func(ctx context.Context, method string, duration time.Duration, err error) {
+		methods = append(methods, method)
+	})
+
+	tests := []struct {
+		name   string
+		ctx    context.Context
+		method string
+	}{
+		{name: "Labelled", ctx: WithMethod(context.Background(), "filmRepository.GetFilms"), method: "filmRepository.GetFilms"},
+		{name: "Unlabelled", ctx: context.Background(), method: "unknown"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			methods = nil
+			mock.ExpectExec("DELETE FROM films").WillReturnResult(sqlmock.NewResult(0, 1))
+
+			if _, err := q.ExecContext(tc.ctx, "DELETE FROM films"); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if len(methods) != 1 || methods[0] != tc.method {
+				t.Errorf("expected: [%s]\ngot: %v", tc.method, methods)
+			}
+		})
+	}
+}
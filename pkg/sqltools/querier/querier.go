@@ -0,0 +1,14 @@
+package querier
+
+import (
+	"context"
+	"database/sql"
+)
+
+// Querier is implemented by both *sql.DB and *sql.Tx, so repositories built on
+// it can run either standalone or inside a transaction.
+type Querier interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
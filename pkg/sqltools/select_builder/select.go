@@ -6,12 +6,20 @@ import (
 	"strings"
 )
 
+// Placeholder marks a bind argument in conditions passed to Where.
+// Build replaces every placeholder with a numbered postgres parameter ($1, $2, ...).
+const Placeholder = "?"
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
 type SelectQueryBuilder struct {
-	query      *strings.Builder
-	joins      []string
-	conditions []string
-	orders     []string
-	pagination *pagination.Pagination
+	query       *strings.Builder
+	joins       []string
+	conditions  []string
+	args        []any
+	orders      []string
+	sortColumns map[string]string
+	pagination  *pagination.Pagination
 }
 
 func New(selectQuery string) *SelectQueryBuilder {
@@ -19,10 +27,12 @@ func New(selectQuery string) *SelectQueryBuilder {
 	query.WriteString(selectQuery + " ")
 
 	return &SelectQueryBuilder{
-		query:      query,
-		joins:      []string{},
-		conditions: []string{},
-		orders:     []string{},
+		query:       query,
+		joins:       []string{},
+		conditions:  []string{},
+		args:        []any{},
+		orders:      []string{},
+		sortColumns: map[string]string{},
 	}
 }
 
@@ -36,16 +46,41 @@ func (b *SelectQueryBuilder) LeftJoin(joinQuery string) *SelectQueryBuilder {
 	return b
 }
 
-func (b *SelectQueryBuilder) Where(conditionFormat string, params ...any) *SelectQueryBuilder {
-	b.conditions = append(b.conditions, fmt.Sprintf(conditionFormat, params...))
+// Where adds a condition joined with AND. Values must not be formatted into the
+// condition: use Placeholder for each of them and pass them as args.
+func (b *SelectQueryBuilder) Where(condition string, args ...any) *SelectQueryBuilder {
+	if strings.Count(condition, Placeholder) != len(args) {
+		panic(fmt.Sprintf("selectbuilder: condition %q expects %d args, got %d",
+			condition, strings.Count(condition, Placeholder), len(args)))
+	}
+
+	for _, arg := range args {
+		b.args = append(b.args, arg)
+		condition = strings.Replace(condition, Placeholder, fmt.Sprintf("$%d", len(b.args)), 1)
+	}
+	b.conditions = append(b.conditions, condition)
 	return b
 }
 
+// SortColumns sets the allowlist for OrderBy: keys are the names accepted from
+// clients, values are the SQL expressions they are sorted by.
+func (b *SelectQueryBuilder) SortColumns(columns map[string]string) *SelectQueryBuilder {
+	b.sortColumns = columns
+	return b
+}
+
+// OrderBy adds a sort column. Columns missing from SortColumns are ignored.
 func (b *SelectQueryBuilder) OrderBy(orderBy, direction string) *SelectQueryBuilder {
-	if strings.ToLower(direction) != "asc" && strings.ToLower(direction) != "desc" {
+	column, ok := b.sortColumns[orderBy]
+	if !ok {
+		return b
+	}
+
+	direction = strings.ToLower(direction)
+	if direction != "asc" && direction != "desc" {
 		direction = "asc"
 	}
-	b.orders = append(b.orders, fmt.Sprintf("%s %s", orderBy, direction))
+	b.orders = append(b.orders, fmt.Sprintf("%s %s", column, direction))
 	return b
 }
 
@@ -54,7 +89,8 @@ func (b *SelectQueryBuilder) AddPagination(pagination *pagination.Pagination) *S
 	return b
 }
 
-func (b *SelectQueryBuilder) Build() string {
+// Build returns the query and its bind arguments in placeholder order.
+func (b *SelectQueryBuilder) Build() (string, []any) {
 	for _, join := range b.joins {
 		b.query.WriteString(join)
 	}
@@ -73,8 +109,17 @@ func (b *SelectQueryBuilder) Build() string {
 		b.query.WriteString(", " + b.orders[i])
 	}
 
-	b.query.WriteString(fmt.Sprintf(" LIMIT %d", b.pagination.GetLimit()))
-	b.query.WriteString(fmt.Sprintf(" OFFSET %d", b.pagination.GetOffset()))
+	args := b.args
+	if b.pagination != nil {
+		args = append(args, b.pagination.GetLimit(), b.pagination.GetOffset())
+		b.query.WriteString(fmt.Sprintf(" LIMIT $%d OFFSET $%d", len(args)-1, len(args)))
+	}
+
+	return b.query.String(), args
+}
 
-	return b.query.String()
+// Contains returns a LIKE pattern matching values that contain s.
+// Wildcards in s are escaped so they are matched literally.
+func Contains(s string) string {
+	return "%" + likeEscaper.Replace(s) + "%"
 }
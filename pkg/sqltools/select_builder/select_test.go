@@ -0,0 +1,74 @@
+package selectbuilder
+
+import (
+	"film_library/pkg/pagination"
+	"reflect"
+	"testing"
+)
+
+func TestSelectQueryBuilderBuild(t *testing.T) {
+	sortColumns := map[string]string{"name": "f.name", "rating": "f.rating"}
+
+	tests := []struct {
+		name    string
+		builder func() *SelectQueryBuilder
+		query   string
+		args    []any
+	}{
+		{
+			name: "Numbered placeholders",
+			builder: func() *SelectQueryBuilder {
+				return New("SELECT f.id FROM films AS f").
+					Where("f.name LIKE ?", "%a%").
+					Where("f.rating BETWEEN ? AND ?", 1, 5).
+					AddPagination(pagination.New(2, 10))
+			},
+			query: "SELECT f.id FROM films AS f  WHERE f.name LIKE $1 AND f.rating BETWEEN $2 AND $3 LIMIT $4 OFFSET $5",
+			args:  []any{"%a%", 1, 5, 10, 10},
+		},
+		{
+			name: "Allowed sort column",
+			builder: func() *SelectQueryBuilder {
+				return New("SELECT f.id FROM films AS f").
+					SortColumns(sortColumns).
+					OrderBy("rating", "DESC")
+			},
+			query: "SELECT f.id FROM films AS f  ORDER BY f.rating desc",
+			args:  []any{},
+		},
+		{
+			name: "Unknown sort column and direction",
+			builder: func() *SelectQueryBuilder {
+				return New("SELECT f.id FROM films AS f").
+					SortColumns(sortColumns).
+					OrderBy("id; DROP TABLE films", "asc").
+					OrderBy("name", "; DROP TABLE films")
+			},
+			query: "SELECT f.id FROM films AS f  ORDER BY f.name asc",
+			args:  []any{},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			query, args := tc.builder().Build()
+
+			if query != tc.query {
+				t.Errorf("expected: %s\ngot: %s", tc.query, query)
+			}
+
+			if !reflect.DeepEqual(args, tc.args) {
+				t.Errorf("expected: %#v\ngot: %#v", tc.args, args)
+			}
+		})
+	}
+}
+
+func TestContains(t *testing.T) {
+	got := Contains(`50%_off\`)
+	expected := `%50\%\_off\\%`
+
+	if got != expected {
+		t.Errorf("expected: %s\ngot: %s", expected, got)
+	}
+}
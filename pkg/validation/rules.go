@@ -0,0 +1,133 @@
+package validation
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Rule codes are returned to clients and must stay stable.
+const (
+	CodeInvalid   = "invalid"
+	CodeRequired  = "required"
+	CodeLength    = "length"
+	CodeRange     = "range"
+	CodePattern   = "pattern"
+	CodeOneOf     = "one_of"
+	CodeDateRange = "date_range"
+	CodeInFuture  = "in_future"
+)
+
+// now is replaced in tests.
+var now = time.Now
+
+// Rule checks a single value and returns nil when it is valid.
+type Rule[V any] func(value V) *FieldError
+
+// Err replaces the message of a failed rule with err. The error is kept in
+// the chain, so errors.Is matches it.
+func (r Rule[V]) Err(err error) Rule[V] {
+	return func(value V) *FieldError {
+		fieldErr := r(value)
+		if fieldErr != nil {
+			fieldErr.Message = err.Error()
+			fieldErr.err = err
+		}
+		return fieldErr
+	}
+}
+
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 |
+		~float32 | ~float64
+}
+
+func Required() Rule[string] {
+	return func(value string) *FieldError {
+		if strings.TrimSpace(value) == "" {
+			return newError(CodeRequired, "must not be empty")
+		}
+		return nil
+	}
+}
+
+// Length checks the length of a string in runes.
+func Length(min, max int) Rule[string] {
+	return func(value string) *FieldError {
+		if l := utf8.RuneCountInString(value); l < min || l > max {
+			return newError(CodeLength, fmt.Sprintf("length must be between %d and %d", min, max))
+		}
+		return nil
+	}
+}
+
+func Match(re *regexp.Regexp) Rule[string] {
+	return func(value string) *FieldError {
+		if !re.MatchString(value) {
+			return newError(CodePattern, fmt.Sprintf("must match %s", re.String()))
+		}
+		return nil
+	}
+}
+
+func Range[N Number](min, max N) Rule[N] {
+	return func(value N) *FieldError {
+		if value < min || value > max {
+			return newError(CodeRange, fmt.Sprintf("must be between %v and %v", min, max))
+		}
+		return nil
+	}
+}
+
+func OneOf[V comparable](values ...V) Rule[V] {
+	set := make(map[V]struct{}, len(values))
+	for _, value := range values {
+		set[value] = struct{}{}
+	}
+	return Enum(set)
+}
+
+// Enum checks that the value is a key of set.
+func Enum[V comparable](set map[V]struct{}) Rule[V] {
+	allowed := make([]string, 0, len(set))
+	for value := range set {
+		allowed = append(allowed, fmt.Sprint(value))
+	}
+	sort.Strings(allowed)
+	msg := fmt.Sprintf("must be one of: %s", strings.Join(allowed, ", "))
+
+	return func(value V) *FieldError {
+		if _, ok := set[value]; !ok {
+			return newError(CodeOneOf, msg)
+		}
+		return nil
+	}
+}
+
+// DateBetween checks that the value lies within [from, to].
+func DateBetween(from, to time.Time) Rule[time.Time] {
+	return func(value time.Time) *FieldError {
+		if value.Before(from) || value.After(to) {
+			return newError(CodeDateRange, fmt.Sprintf("must be between %s and %s",
+				from.Format(time.DateOnly), to.Format(time.DateOnly)))
+		}
+		return nil
+	}
+}
+
+func NotInFuture() Rule[time.Time] {
+	return func(value time.Time) *FieldError {
+		if value.After(now()) {
+			return newError(CodeInFuture, "must not be in the future")
+		}
+		return nil
+	}
+}
+
+func newError(code, msg string) *FieldError {
+	return &FieldError{Code: code, Message: msg}
+}
@@ -1,11 +1,36 @@
 package validation
 
 import (
+	"errors"
 	"fmt"
 	"strings"
+	"time"
 )
 
-type ValidateError []error
+// FieldError is a failed rule. Field is a path to the value, for example
+// "actors[1].fullName", and is empty when the whole object is invalid.
+type FieldError struct {
+	Field   string `json:"field"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+
+	err error
+}
+
+func (e *FieldError) Error() string {
+	if e.Field == "" {
+		return e.Message
+	}
+	return fmt.Sprintf("%s: %s", e.Field, e.Message)
+}
+
+// Unwrap returns the error set by Rule.Err, so callers can still match
+// domain sentinels with errors.Is.
+func (e *FieldError) Unwrap() error {
+	return e.err
+}
+
+type ValidateError []*FieldError
 
 func (e ValidateError) Error() string {
 	var res strings.Builder
@@ -15,12 +40,12 @@ func (e ValidateError) Error() string {
 	return res.String()
 }
 
-func (e ValidateError) ToArrayErrors() []string {
-	var res []string
-	for _, err := range e {
-		res = append(res, err.Error())
+func (e ValidateError) Unwrap() []error {
+	errs := make([]error, len(e))
+	for i, err := range e {
+		errs[i] = err
 	}
-	return res
+	return errs
 }
 
 type Validator[T any] struct {
@@ -34,22 +59,108 @@ func NewValidator[T any](object T) *Validator[T] {
 	}
 }
 
-func (v *Validator[T]) Between(getField func(T) int, start, end int, errMsg string) *Validator[T] {
-	return v.Must(func(t T) bool {
-		return getField(t) >= start && getField(t) <= end
-	}, errMsg)
+func (v *Validator[T]) String(field string, getField func(T) string, rules ...Rule[string]) *Validator[T] {
+	v.errors = append(v.errors, apply(field, getField(v.object), rules)...)
+	return v
 }
 
-func (v *Validator[T]) Must(f func(T) bool, errMsg string) *Validator[T] {
+func (v *Validator[T]) Int(field string, getField func(T) int, rules ...Rule[int]) *Validator[T] {
+	v.errors = append(v.errors, apply(field, getField(v.object), rules)...)
+	return v
+}
+
+func (v *Validator[T]) Time(field string, getField func(T) time.Time, rules ...Rule[time.Time]) *Validator[T] {
+	v.errors = append(v.errors, apply(field, getField(v.object), rules)...)
+	return v
+}
+
+// Must adds err for field when f returns false.
+func (v *Validator[T]) Must(field string, f func(T) bool, err error) *Validator[T] {
 	if !f(v.object) {
-		v.errors = append(v.errors, fmt.Errorf(errMsg))
+		v.errors = append(v.errors, &FieldError{
+			Field:   field,
+			Code:    CodeInvalid,
+			Message: err.Error(),
+			err:     err,
+		})
 	}
 	return v
 }
 
+// Nested validates a nested object or slice with validate, typically another
+// Validator or Each, and reports its errors under field.
+func (v *Validator[T]) Nested(field string, validate func(T) error) *Validator[T] {
+	v.errors = append(v.errors, prefix(field, validate(v.object))...)
+	return v
+}
+
 func (v *Validator[T]) Validate() error {
 	if len(v.errors) == 0 {
 		return nil
 	}
 	return &v.errors
 }
+
+// Check validates a single value against rules.
+func Check[V any](field string, value V, rules ...Rule[V]) error {
+	errs := apply(field, value, rules)
+	if len(errs) == 0 {
+		return nil
+	}
+	return &errs
+}
+
+// Each validates every item and reports errors under their index, "[i]".
+func Each[E any](items []E, validate func(E) error) error {
+	var errs ValidateError
+	for i, item := range items {
+		errs = append(errs, prefix(fmt.Sprintf("[%d]", i), validate(item))...)
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return &errs
+}
+
+func apply[V any](field string, value V, rules []Rule[V]) ValidateError {
+	var errs ValidateError
+	for _, rule := range rules {
+		if err := rule(value); err != nil {
+			err.Field = field
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
+
+func prefix(field string, err error) ValidateError {
+	if err == nil {
+		return nil
+	}
+
+	var validateErr *ValidateError
+	if !errors.As(err, &validateErr) {
+		return ValidateError{{Field: field, Code: CodeInvalid, Message: err.Error(), err: err}}
+	}
+
+	errs := make(ValidateError, 0, len(*validateErr))
+	for _, fieldErr := range *validateErr {
+		prefixed := *fieldErr
+		prefixed.Field = joinPath(field, fieldErr.Field)
+		errs = append(errs, &prefixed)
+	}
+	return errs
+}
+
+func joinPath(parent, child string) string {
+	switch {
+	case parent == "":
+		return child
+	case child == "":
+		return parent
+	case strings.HasPrefix(child, "["):
+		return parent + child
+	default:
+		return parent + "." + child
+	}
+}
@@ -0,0 +1,183 @@
+package validation
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+)
+
+type testActor struct {
+	FullName string
+	Birthday time.Time
+}
+
+type testFilm struct {
+	Name   string
+	Rating int
+	Genre  string
+	Actors []testActor
+}
+
+func validateTestActor(a testActor) error {
+	return NewValidator(a).
+		String("fullName", func(a testActor) string { return a.FullName }, Required()).
+		Time("birthday", func(a testActor) time.Time { return a.Birthday }, NotInFuture()).
+		Validate()
+}
+
+func TestValidator(t *testing.T) {
+	now = func() time.Time { return time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC) }
+	defer func() { now = time.Now }()
+
+	validate := func(f testFilm) error {
+		return NewValidator(f).
+			String("name", func(f testFilm) string { return f.Name }, Length(1, 5)).
+			Int("rating", func(f testFilm) int { return f.Rating }, Range(0, 10)).
+			String("genre", func(f testFilm) string { return f.Genre }, OneOf("drama", "comedy")).
+			Nested("actors", func(f testFilm) error { return Each(f.Actors, validateTestActor) }).
+			Validate()
+	}
+
+	tests := []struct {
+		name     string
+		film     testFilm
+		expected string
+	}{
+		{
+			name: "Valid",
+			film: testFilm{
+				Name:   "Брат",
+				Rating: 10,
+				Genre:  "drama",
+				Actors: []testActor{{FullName: "Сергей Бодров", Birthday: time.Date(1971, 12, 27, 0, 0, 0, 0, time.UTC)}},
+			},
+			expected: "null",
+		},
+		{
+			name: "Invalid fields",
+			film: testFilm{
+				Name:   "Брат 2!",
+				Rating: 11,
+				Genre:  "horror",
+			},
+			expected: `[{"field":"name","code":"length","message":"length must be between 1 and 5"},` +
+				`{"field":"rating","code":"range","message":"must be between 0 and 10"},` +
+				`{"field":"genre","code":"one_of","message":"must be one of: comedy, drama"}]`,
+		},
+		{
+			name: "Invalid slice elements",
+			film: testFilm{
+				Name:  "Брат",
+				Genre: "drama",
+				Actors: []testActor{
+					{FullName: "Сергей Бодров"},
+					{FullName: " ", Birthday: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)},
+				},
+			},
+			expected: `[{"field":"actors[1].fullName","code":"required","message":"must not be empty"},` +
+				`{"field":"actors[1].birthday","code":"in_future","message":"must not be in the future"}]`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var errs ValidateError
+			if err := validate(tc.film); err != nil {
+				var validateErr *ValidateError
+				if !errors.As(err, &validateErr) {
+					t.Fatalf("expected: *ValidateError\ngot: %T", err)
+				}
+				errs = *validateErr
+			}
+
+			b, err := json.Marshal(errs)
+			if err != nil {
+				t.Fatalf("%s", err.Error())
+			}
+			if string(b) != tc.expected {
+				t.Errorf("expected: %s\ngot: %s", tc.expected, string(b))
+			}
+		})
+	}
+}
+
+func TestRules(t *testing.T) {
+	from := time.Date(1895, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		err          error
+		expectedCode string
+	}{
+		{
+			name: "Length counts runes",
+			err:  Check("name", "Сталкер", Length(1, 7)),
+		},
+		{
+			name:         "Length too long",
+			err:          Check("name", "Сталкер", Length(1, 6)),
+			expectedCode: CodeLength,
+		},
+		{
+			name: "Pattern",
+			err:  Check("login", "denis_1", Match(regexp.MustCompile(`^[a-z0-9_]+$`))),
+		},
+		{
+			name:         "Pattern mismatch",
+			err:          Check("login", "denis 1", Match(regexp.MustCompile(`^[a-z0-9_]+$`))),
+			expectedCode: CodePattern,
+		},
+		{
+			name:         "Enum",
+			err:          Check("role", "root", Enum(map[string]struct{}{"admin": {}, "viewer": {}})),
+			expectedCode: CodeOneOf,
+		},
+		{
+			name: "Date in range",
+			err:  Check("releaseDate", time.Date(1979, 5, 25, 0, 0, 0, 0, time.UTC), DateBetween(from, to)),
+		},
+		{
+			name:         "Date out of range",
+			err:          Check("releaseDate", time.Date(1894, 5, 25, 0, 0, 0, 0, time.UTC), DateBetween(from, to)),
+			expectedCode: CodeDateRange,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.expectedCode == "" {
+				if tc.err != nil {
+					t.Errorf("expected: nil\ngot: %s", tc.err.Error())
+				}
+				return
+			}
+
+			var validateErr *ValidateError
+			if !errors.As(tc.err, &validateErr) {
+				t.Fatalf("expected: *ValidateError\ngot: %T", tc.err)
+			}
+			if code := (*validateErr)[0].Code; code != tc.expectedCode {
+				t.Errorf("expected: %s\ngot: %s", tc.expectedCode, code)
+			}
+		})
+	}
+}
+
+func TestRuleErr(t *testing.T) {
+	errInvalidName := fmt.Errorf("invalid film name")
+
+	err := fmt.Errorf("filmService.UpdateFilmName: %w", Check("name", "", Length(1, 10).Err(errInvalidName)))
+
+	if !errors.Is(err, errInvalidName) {
+		t.Errorf("expected: %s\ngot: %s", errInvalidName, err)
+	}
+
+	expected := "filmService.UpdateFilmName: name: invalid film name\n"
+	if err.Error() != expected {
+		t.Errorf("expected: %s\ngot: %s", expected, err.Error())
+	}
+}
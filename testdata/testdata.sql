@@ -1,4 +1,4 @@
-INSERT INTO actors (full_name, gender, birthday)
+INSERT INTO persons (full_name, gender, birthday)
 VALUES 
     ('Tom Hanks', 'male', '1956-07-09'),
     ('Meryl Streep', 'female', '1949-06-22'),
@@ -26,19 +26,58 @@ VALUES
     ('The Avengers', 'Earth''s mightiest heroes must come together and learn to fight as a team if they are going to stop the mischievous Loki and his alien army from enslaving humanity.', '2012-05-04', 9),
     ('Oppenheimer', 'A biographical film about J. Robert Oppenheimer, the scientist who headed the Manhattan Project.', '2023-01-01', 10);
 
-INSERT INTO film_actor (film_id, actor_id)
+INSERT INTO credits (film_id, person_id, type, character_name, billing_order)
+VALUES 
+    (1, 1, 'actor', NULL, 1),
+    (2, 2, 'actor', NULL, 1),
+    (3, 3, 'actor', NULL, 1),
+    (4, 4, 'actor', NULL, 1),
+    (5, 5, 'actor', NULL, 1),
+    (6, 6, 'actor', NULL, 1),
+    (7, 7, 'actor', NULL, 1),
+    (8, 8, 'actor', NULL, 1),
+    (9, 9, 'actor', NULL, 1),
+    (10, 10, 'actor', NULL, 1),
+    (11, 11, 'actor', NULL, 1);
+
+INSERT INTO genres (name)
+VALUES 
+    ('Drama'),
+    ('Comedy'),
+    ('Sci-Fi'),
+    ('Fantasy'),
+    ('Action'),
+    ('Adventure'),
+    ('Thriller'),
+    ('Biography');
+
+INSERT INTO film_genre (film_id, genre_id)
 VALUES 
     (1, 1),
+    (1, 2),
+    (2, 1),
     (2, 2),
     (3, 3),
+    (3, 5),
+    (3, 7),
     (4, 4),
-    (5, 5),
+    (4, 6),
+    (5, 1),
+    (5, 7),
+    (6, 3),
+    (6, 5),
     (6, 6),
-    (7, 7),
-    (8, 8),
-    (9, 9),
-    (10, 10),
-    (11, 11);
+    (7, 4),
+    (7, 5),
+    (7, 6),
+    (8, 1),
+    (8, 7),
+    (9, 3),
+    (9, 5),
+    (10, 3),
+    (10, 5),
+    (11, 1),
+    (11, 8);
 
 INSERT INTO users (login, password, role)
 VALUES 